@@ -0,0 +1,324 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ActivityKind string
+
+const (
+	ActivityCreated    ActivityKind = "created"
+	ActivityStatus     ActivityKind = "status"
+	ActivityPriority   ActivityKind = "priority"
+	ActivitySource     ActivityKind = "source"
+	ActivityAssignment ActivityKind = "assignment" // agent
+	ActivityGroup      ActivityKind = "group"
+	ActivityTags       ActivityKind = "tags"
+	ActivityNote       ActivityKind = "note"
+	ActivityAction     ActivityKind = "action" // e-mail sent, automation run
+	ActivityField      ActivityKind = "field"  // any other field, custom ones included
+)
+
+// TicketEvent is one change of a TicketActivity. Value is the exported
+// value, decoded into Status, Priority, Source, AgentID or GroupID for
+// the matching kinds; names of statuses unknown to ParseStatus leave
+// Status at zero.
+type TicketEvent struct {
+	Kind          ActivityKind
+	Field         string
+	Value         interface{}
+	TicketID      uint64
+	PerformerType string
+	PerformerID   uint64
+	PerformedAt   time.Time
+	Status        Status
+	Priority      Priority
+	Source        Source
+	AgentID       uint64 // 0 when unassigned
+	GroupID       uint64 // 0 when unassigned or exported by name
+}
+
+// TicketState is a ticket as rebuilt from its activities. Fields holds the
+// other fields, keyed as in the export, and the group when exported by
+// name.
+type TicketState struct {
+	TicketID  uint64
+	CreatedAt *time.Time // nil when the creation is not part of the activities
+	UpdatedAt time.Time
+	Status    Status
+	Priority  Priority
+	Source    Source
+	AgentID   uint64
+	GroupID   uint64
+	Tags      []string
+	Fields    map[string]interface{}
+}
+
+var activityKinds = map[string]ActivityKind{
+	"new_ticket":   ActivityCreated,
+	"status":       ActivityStatus,
+	"priority":     ActivityPriority,
+	"source":       ActivitySource,
+	"agent_id":     ActivityAssignment,
+	"responder_id": ActivityAssignment,
+	"group":        ActivityGroup,
+	"group_id":     ActivityGroup,
+	"tags":         ActivityTags,
+	"added_tags":   ActivityTags,
+	"removed_tags": ActivityTags,
+	"note":         ActivityNote,
+	"send_email":   ActivityAction,
+	"automation":   ActivityAction,
+}
+
+// ListTicketActivityExports returns the URLs of the files holding the
+// ticket activities of the day of createdAt. Freshdesk has no per-ticket
+// activity endpoint, FilterTicketActivities selects the activities of one
+// ticket once downloaded.
+func (service *freshDeskService) ListTicketActivityExports(createdAt time.Time) ([]string, error) {
+	var responseSchema TicketActivitiesExport
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParam("created_at", createdAt.Format("2006-01-02")).
+		Get("/api/v2/export/ticket_activities")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	urls := make([]string, len(responseSchema.Export))
+	for i, file := range responseSchema.Export {
+		urls[i] = file.URL
+	}
+	return urls, nil
+}
+
+// DownloadTicketActivities downloads an export file. Its URL is signed, so
+// it is requested without the API credentials.
+func (service *freshDeskService) DownloadTicketActivities(url string) ([]TicketActivity, error) {
+	resp, err := service.restyClient.GetClient().Get(url)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if len(body) == 0 {
+			return nil, fmt.Errorf("Invalid status received: %d", resp.StatusCode)
+		}
+		return nil, errors.New(string(body))
+	}
+
+	var responseSchema TicketActivitiesData
+	if err := json.Unmarshal(body, &responseSchema); err != nil {
+		return nil, err
+	}
+	return responseSchema.ActivitiesData, nil
+}
+
+// ExportTicketActivities downloads every file of the export of the day of
+// createdAt.
+func (service *freshDeskService) ExportTicketActivities(createdAt time.Time) ([]TicketActivity, error) {
+	urls, err := service.ListTicketActivityExports(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseAll []TicketActivity
+	for _, url := range urls {
+		activities, err := service.DownloadTicketActivities(url)
+		if err != nil {
+			return nil, err
+		}
+		responseAll = append(responseAll, activities...)
+	}
+	return responseAll, nil
+}
+
+// FilterTicketActivities returns the activities of a ticket, oldest first.
+func FilterTicketActivities(activities []TicketActivity, ticketID uint64) []TicketActivity {
+	var filtered []TicketActivity
+	for _, activity := range activities {
+		if activity.TicketID == ticketID {
+			filtered = append(filtered, activity)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].PerformedAt.Before(filtered[j].PerformedAt) })
+	return filtered
+}
+
+// Events splits the activity into one event per changed property, the
+// creation first and the others by property name.
+func (activity *TicketActivity) Events() []TicketEvent {
+	names := make([]string, 0, len(activity.Activity))
+	for name := range activity.Activity {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "new_ticket") != (names[j] == "new_ticket") {
+			return names[i] == "new_ticket"
+		}
+		return names[i] < names[j]
+	})
+
+	events := make([]TicketEvent, len(names))
+	for i, name := range names {
+		event := TicketEvent{
+			Kind:          ActivityField,
+			Field:         name,
+			Value:         activity.Activity[name],
+			TicketID:      activity.TicketID,
+			PerformerType: activity.PerformerType,
+			PerformerID:   activity.PerformerID,
+			PerformedAt:   activity.PerformedAt,
+		}
+		if kind, ok := activityKinds[name]; ok {
+			event.Kind = kind
+		}
+
+		text := strings.TrimSpace(fmt.Sprint(event.Value))
+		switch event.Kind {
+		case ActivityStatus:
+			event.Status, _ = ParseStatus(text)
+		case ActivityPriority:
+			event.Priority, _ = ParsePriority(text)
+		case ActivitySource:
+			event.Source, _ = ParseSource(text)
+		case ActivityAssignment:
+			event.AgentID, _ = activityID(event.Value)
+		case ActivityGroup:
+			event.GroupID, _ = activityID(event.Value)
+		}
+		events[i] = event
+	}
+	return events
+}
+
+// TicketStateAt replays the activities of a ticket performed until at.
+// It returns false when there are none.
+func TicketStateAt(activities []TicketActivity, ticketID uint64, at time.Time) (*TicketState, bool) {
+	state := &TicketState{TicketID: ticketID, Fields: map[string]interface{}{}}
+	found := false
+	for _, activity := range FilterTicketActivities(activities, ticketID) {
+		if activity.PerformedAt.After(at) {
+			break
+		}
+		found = true
+		state.UpdatedAt = activity.PerformedAt
+		for _, event := range activity.Events() {
+			state.apply(event)
+		}
+	}
+	return state, found
+}
+
+func (state *TicketState) apply(event TicketEvent) {
+	switch event.Kind {
+	case ActivityCreated:
+		createdAt := event.PerformedAt
+		state.CreatedAt = &createdAt
+	case ActivityStatus:
+		state.Status = event.Status
+	case ActivityPriority:
+		state.Priority = event.Priority
+	case ActivitySource:
+		state.Source = event.Source
+	case ActivityAssignment:
+		state.AgentID = event.AgentID
+	case ActivityGroup:
+		state.GroupID = event.GroupID
+		if _, ok := activityID(event.Value); ok {
+			delete(state.Fields, event.Field)
+		} else {
+			state.Fields[event.Field] = event.Value
+		}
+	case ActivityTags:
+		tags := activityStrings(event.Value)
+		switch event.Field {
+		case "added_tags":
+			state.Tags = append(state.Tags, tags...)
+		case "removed_tags":
+			kept := state.Tags[:0]
+			for _, tag := range state.Tags {
+				if !containsString(tags, tag) {
+					kept = append(kept, tag)
+				}
+			}
+			state.Tags = kept
+		default:
+			state.Tags = tags
+		}
+	case ActivityField:
+		state.Fields[event.Field] = event.Value
+	}
+}
+
+// activityID reads an ID exported as a number or a numeric string.
+func activityID(value interface{}) (uint64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return uint64(value), true
+	case string:
+		id, err := strconv.ParseUint(value, 10, 64)
+		return id, err == nil
+	}
+	return 0, false
+}
+
+func activityStrings(value interface{}) []string {
+	var list []string
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			list = append(list, fmt.Sprint(item))
+		}
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// UnmarshalJSON reads performed_at, exported as "02-01-2006 15:04:05 -0700"
+// rather than RFC 3339.
+func (activity *TicketActivity) UnmarshalJSON(data []byte) error {
+	type plain TicketActivity
+	raw := struct {
+		*plain
+		PerformedAt string `json:"performed_at"`
+	}{plain: (*plain)(activity)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for _, layout := range []string{"02-01-2006 15:04:05 -0700", time.RFC3339} {
+		if performedAt, err := time.Parse(layout, raw.PerformedAt); err == nil {
+			activity.PerformedAt = performedAt
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected performed_at %q", raw.PerformedAt)
+}
@@ -0,0 +1,129 @@
+package freshdesk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const activitiesFile = `{"activities_data":[
+	{"ticket_id":7,"performer_type":"agent","performer_id":3,"performed_at":"15-06-2017 10:30:00 +0000","activity":{"status":"Pending","agent_id":3,"added_tags":["vip"],"cf_region":"EU"}},
+	{"ticket_id":8,"performer_type":"contact","performer_id":9,"performed_at":"15-06-2017 10:00:00 +0000","activity":{"new_ticket":true}},
+	{"ticket_id":7,"performer_type":"contact","performer_id":9,"performed_at":"15-06-2017 10:00:00 +0000","activity":{"new_ticket":true,"status":"Open","priority":"Low","source":"Email","group":"Billing","tags":["billing","refund"]}},
+	{"ticket_id":7,"performer_type":"agent","performer_id":4,"performed_at":"15-06-2017 11:00:00 +0000","activity":{"status":"Resolved","agent_id":null,"group_id":"12","removed_tags":["refund"],"note":{"id":1,"type":"private"}}}
+]}`
+
+func TestExportTicketActivities(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/export/ticket_activities", 200, `{"export":[{"url":"`+stub.URL+`/exports/1.json"},{"url":"`+stub.URL+`/exports/2.json"}]}`)
+	stub.reply("GET", "/exports/1.json", 200, activitiesFile)
+	stub.reply("GET", "/exports/2.json", 200, `{"activities_data":[]}`)
+
+	activities, err := stub.client().ExportTicketActivities(time.Date(2017, 6, 15, 23, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 4 || activities[0].PerformerType != "agent" || !activities[0].PerformedAt.Equal(time.Date(2017, 6, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %+v", activities)
+	}
+
+	requests := stub.received()
+	if got := requests[0].Query.Get("created_at"); got != "2017-06-15" {
+		t.Errorf("created_at = %q", got)
+	}
+	// Export files are signed URLs, credentials must not be sent along.
+	if got := requests[1].Header.Get("Authorization"); got != "" {
+		t.Errorf("download sent Authorization %q", got)
+	}
+}
+
+func TestDownloadTicketActivitiesErrors(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/exports/expired.json", 403, `<Error><Code>AccessDenied</Code></Error>`)
+	stub.reply("GET", "/exports/gone.json", 404, "")
+	stub.reply("GET", "/exports/broken.json", 200, `{"activities_data":[{"performed_at":"yesterday"}]}`)
+	client := stub.client()
+
+	if _, err := client.DownloadTicketActivities(stub.URL + "/exports/expired.json"); err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("expired: got %v", err)
+	}
+	if _, err := client.DownloadTicketActivities(stub.URL + "/exports/gone.json"); err == nil || err.Error() != "Invalid status received: 404" {
+		t.Errorf("gone: got %v", err)
+	}
+	if _, err := client.DownloadTicketActivities(stub.URL + "/exports/broken.json"); err == nil || !strings.Contains(err.Error(), "yesterday") {
+		t.Errorf("broken: got %v", err)
+	}
+}
+
+func TestTicketActivityEvents(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/exports/1.json", 200, activitiesFile)
+	activities, err := stub.client().DownloadTicketActivities(stub.URL + "/exports/1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := activities[2].Events()
+	var kinds []ActivityKind
+	for _, event := range events {
+		kinds = append(kinds, event.Kind)
+	}
+	want := []ActivityKind{ActivityCreated, ActivityGroup, ActivityPriority, ActivitySource, ActivityStatus, ActivityTags}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("kinds = %v, want %v", kinds, want)
+	}
+	if events[2].Priority != PriorityLow || events[3].Source != SourceEmail || events[4].Status != StatusOpen {
+		t.Errorf("got %+v", events)
+	}
+	if events[0].PerformerID != 9 || events[0].TicketID != 7 {
+		t.Errorf("performer = %+v", events[0])
+	}
+
+	events = activities[0].Events()
+	if events[1].Kind != ActivityAssignment || events[1].AgentID != 3 || events[2].Kind != ActivityField || events[2].Field != "cf_region" {
+		t.Errorf("got %+v", events)
+	}
+}
+
+func TestTicketStateAt(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/exports/1.json", 200, activitiesFile)
+	activities, err := stub.client().DownloadTicketActivities(stub.URL + "/exports/1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(hour, minute int) time.Time { return time.Date(2017, 6, 15, hour, minute, 0, 0, time.UTC) }
+
+	if _, ok := TicketStateAt(activities, 7, at(9, 0)); ok {
+		t.Error("state before creation")
+	}
+
+	state, ok := TicketStateAt(activities, 7, at(10, 45))
+	if !ok {
+		t.Fatal("no state")
+	}
+	if state.CreatedAt == nil || !state.CreatedAt.Equal(at(10, 0)) || !state.UpdatedAt.Equal(at(10, 30)) {
+		t.Errorf("times = %v, %v", state.CreatedAt, state.UpdatedAt)
+	}
+	if state.Status != StatusPending || state.Priority != PriorityLow || state.Source != SourceEmail || state.AgentID != 3 {
+		t.Errorf("got %+v", state)
+	}
+	if !reflect.DeepEqual(state.Tags, []string{"billing", "refund", "vip"}) {
+		t.Errorf("tags = %v", state.Tags)
+	}
+	if state.Fields["group"] != "Billing" || state.Fields["cf_region"] != "EU" {
+		t.Errorf("fields = %v", state.Fields)
+	}
+
+	state, _ = TicketStateAt(activities, 7, at(12, 0))
+	if state.Status != StatusResolved || state.AgentID != 0 || state.GroupID != 12 {
+		t.Errorf("got %+v", state)
+	}
+	if !reflect.DeepEqual(state.Tags, []string{"billing", "vip"}) {
+		t.Errorf("tags = %v", state.Tags)
+	}
+	if _, ok := state.Fields["note"]; ok {
+		t.Errorf("note kept as a field: %v", state.Fields)
+	}
+}
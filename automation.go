@@ -0,0 +1,225 @@
+package freshdesk
+
+import "encoding/json"
+
+// Payload returns the writable part of an exported rule, ready to be
+// applied to the same or another helpdesk.
+func (rule *AutomationRule) Payload() AutomationRulePayload {
+	extra := map[string]json.RawMessage{}
+	for name, value := range rule.Extra {
+		extra[name] = value
+	}
+	return AutomationRulePayload{
+		Name:       rule.Name,
+		Position:   rule.Position,
+		Active:     rule.Active,
+		Performer:  rule.Performer,
+		Events:     rule.Events,
+		Conditions: rule.Conditions,
+		Operator:   rule.Operator,
+		Actions:    rule.Actions,
+		Extra:      extra,
+	}
+}
+
+// Payload returns the writable part of an exported ticket form.
+func (form *TicketForm) Payload() TicketFormPayload {
+	return TicketFormPayload{
+		Name:        form.Name,
+		Title:       form.Title,
+		Description: form.Description,
+		Fields:      form.Fields,
+		Portals:     form.Portals,
+		Extra:       form.Extra,
+	}
+}
+
+func (rule *AutomationRule) UnmarshalJSON(data []byte) error {
+	type plain AutomationRule
+	if err := json.Unmarshal(data, (*plain)(rule)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, rule)
+	rule.Extra = extra
+	return err
+}
+
+func (rule AutomationRule) MarshalJSON() ([]byte, error) {
+	type plain AutomationRule
+	known, err := json.Marshal(plain(rule))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, rule.Extra)
+}
+
+func (performer *AutomationPerformer) UnmarshalJSON(data []byte) error {
+	type plain AutomationPerformer
+	if err := json.Unmarshal(data, (*plain)(performer)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, performer)
+	performer.Extra = extra
+	return err
+}
+
+func (performer AutomationPerformer) MarshalJSON() ([]byte, error) {
+	type plain AutomationPerformer
+	known, err := json.Marshal(plain(performer))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, performer.Extra)
+}
+
+func (event *AutomationEvent) UnmarshalJSON(data []byte) error {
+	type plain AutomationEvent
+	if err := json.Unmarshal(data, (*plain)(event)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, event)
+	event.Extra = extra
+	return err
+}
+
+func (event AutomationEvent) MarshalJSON() ([]byte, error) {
+	type plain AutomationEvent
+	known, err := json.Marshal(plain(event))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, event.Extra)
+}
+
+func (set *AutomationConditionSet) UnmarshalJSON(data []byte) error {
+	type plain AutomationConditionSet
+	if err := json.Unmarshal(data, (*plain)(set)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, set)
+	set.Extra = extra
+	return err
+}
+
+func (set AutomationConditionSet) MarshalJSON() ([]byte, error) {
+	type plain AutomationConditionSet
+	known, err := json.Marshal(plain(set))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, set.Extra)
+}
+
+func (condition *AutomationCondition) UnmarshalJSON(data []byte) error {
+	type plain AutomationCondition
+	if err := json.Unmarshal(data, (*plain)(condition)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, condition)
+	condition.Extra = extra
+	return err
+}
+
+func (condition AutomationCondition) MarshalJSON() ([]byte, error) {
+	type plain AutomationCondition
+	known, err := json.Marshal(plain(condition))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, condition.Extra)
+}
+
+func (action *AutomationAction) UnmarshalJSON(data []byte) error {
+	type plain AutomationAction
+	if err := json.Unmarshal(data, (*plain)(action)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, action)
+	action.Extra = extra
+	return err
+}
+
+func (action AutomationAction) MarshalJSON() ([]byte, error) {
+	type plain AutomationAction
+	known, err := json.Marshal(plain(action))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, action.Extra)
+}
+
+func (payload *AutomationRulePayload) UnmarshalJSON(data []byte) error {
+	type plain AutomationRulePayload
+	if err := json.Unmarshal(data, (*plain)(payload)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, payload)
+	payload.Extra = extra
+	return err
+}
+
+func (payload AutomationRulePayload) MarshalJSON() ([]byte, error) {
+	type plain AutomationRulePayload
+	known, err := json.Marshal(plain(payload))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, payload.Extra)
+}
+
+func (form *TicketForm) UnmarshalJSON(data []byte) error {
+	type plain TicketForm
+	if err := json.Unmarshal(data, (*plain)(form)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, form)
+	form.Extra = extra
+	return err
+}
+
+func (form TicketForm) MarshalJSON() ([]byte, error) {
+	type plain TicketForm
+	known, err := json.Marshal(plain(form))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, form.Extra)
+}
+
+func (field *TicketFormField) UnmarshalJSON(data []byte) error {
+	type plain TicketFormField
+	if err := json.Unmarshal(data, (*plain)(field)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, field)
+	field.Extra = extra
+	return err
+}
+
+func (field TicketFormField) MarshalJSON() ([]byte, error) {
+	type plain TicketFormField
+	known, err := json.Marshal(plain(field))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, field.Extra)
+}
+
+func (payload *TicketFormPayload) UnmarshalJSON(data []byte) error {
+	type plain TicketFormPayload
+	if err := json.Unmarshal(data, (*plain)(payload)); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, payload)
+	payload.Extra = extra
+	return err
+}
+
+func (payload TicketFormPayload) MarshalJSON() ([]byte, error) {
+	type plain TicketFormPayload
+	known, err := json.Marshal(plain(payload))
+	if err != nil {
+		return nil, err
+	}
+	return withUnknownFields(known, payload.Extra)
+}
@@ -0,0 +1,144 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// The unknown members are named after their level, "beta_*" ones.
+const ruleWithUnknownFields = `{
+	"id": 7,
+	"name": "Route billing",
+	"position": 2,
+	"active": true,
+	"performer": {"type": 1, "members": [3], "beta_performer": {"any": true}},
+	"events": [{"field_name": "status", "from": "--", "to": 2, "beta_event": "x"}],
+	"conditions": [{
+		"name": "condition_set_1",
+		"match_type": "all",
+		"properties": [{
+			"resource_type": "ticket",
+			"field_name": "subject",
+			"operator": "contains",
+			"value": ["invoice"],
+			"case_sensitive": false,
+			"beta_condition": [1, 2]
+		}],
+		"beta_condition_set": 1
+	}],
+	"operator": "condition_set_1",
+	"actions": [{"field_name": "group_id", "value": 5, "beta_action": {"nested": [true]}}],
+	"summary": {"actions": ["Set group"]},
+	"last_updated_by": 9,
+	"created_at": "2024-01-08T10:00:00Z",
+	"updated_at": "2024-01-09T10:00:00Z",
+	"beta_rule": "keep me"
+}`
+
+const formWithUnknownFields = `{
+	"id": 3,
+	"name": "billing",
+	"title": "Billing",
+	"description": "Billing questions",
+	"default": true,
+	"fields": [{"id": 11, "name": "subject", "label": "Subject", "position": 1, "required_for_agents": true, "beta_field": {"section": 4}}],
+	"portals": [1],
+	"created_at": "2024-01-08T10:00:00Z",
+	"updated_at": "2024-01-09T10:00:00Z",
+	"beta_form": "keep me"
+}`
+
+// sameJSON reports whether two JSON documents hold the same values.
+func sameJSON(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(g, w)
+}
+
+// without returns the JSON object document without the named members.
+func without(t *testing.T, document string, names ...string) string {
+	t.Helper()
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(document), &object); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		delete(object, name)
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestAutomationRuleRoundTrip(t *testing.T) {
+	var rule AutomationRule
+	if err := json.Unmarshal([]byte(ruleWithUnknownFields), &rule); err != nil {
+		t.Fatal(err)
+	}
+	if rule.Name != "Route billing" || rule.Conditions[0].Properties[0].Operator != "contains" {
+		t.Fatalf("declared fields not decoded: %+v", rule)
+	}
+	if string(rule.Extra["beta_rule"]) != `"keep me"` {
+		t.Errorf("rule extra = %s", rule.Extra)
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameJSON(t, data, ruleWithUnknownFields) {
+		t.Errorf("rule sent as %s", data)
+	}
+
+	data, err = json.Marshal(rule.Payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := without(t, ruleWithUnknownFields, "id", "summary", "last_updated_by", "created_at", "updated_at")
+	if !sameJSON(t, data, want) {
+		t.Errorf("payload sent as %s, want %s", data, want)
+	}
+
+	var payload AutomationRulePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := json.Marshal(payload); !sameJSON(t, again, want) {
+		t.Errorf("payload sent again as %s", again)
+	}
+}
+
+func TestTicketFormRoundTrip(t *testing.T) {
+	var form TicketForm
+	if err := json.Unmarshal([]byte(formWithUnknownFields), &form); err != nil {
+		t.Fatal(err)
+	}
+	if form.Fields[0].Label != "Subject" || string(form.Fields[0].Extra["beta_field"]) != `{"section": 4}` {
+		t.Fatalf("got %+v", form.Fields[0])
+	}
+
+	data, err := json.Marshal(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameJSON(t, data, formWithUnknownFields) {
+		t.Errorf("form sent as %s", data)
+	}
+
+	data, err = json.Marshal(form.Payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := without(t, formWithUnknownFields, "id", "default", "created_at", "updated_at"); !sameJSON(t, data, want) {
+		t.Errorf("payload sent as %s, want %s", data, want)
+	}
+}
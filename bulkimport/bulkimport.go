@@ -0,0 +1,339 @@
+// Package bulkimport loads contacts and companies from CSV or JSONL files,
+// creating the records that do not exist and updating the others. The
+// import runs under the rate limit of the client, which every request
+// waits for and which retries the requests answered 429.
+package bulkimport
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+type Kind string
+
+const (
+	Contacts  Kind = "contacts"
+	Companies Kind = "companies"
+)
+
+// MatchBy is the field used to find existing contacts. Companies are
+// always matched by name.
+type MatchBy string
+
+const (
+	MatchEmail      MatchBy = "email"
+	MatchExternalID MatchBy = "unique_external_id"
+)
+
+type Options struct {
+	Kind    Kind
+	MatchBy MatchBy // MatchEmail by default
+	Mapping Mapping
+
+	// ListSeparator splits CSV cells of list fields such as tags or
+	// domains, ";" by default.
+	ListSeparator string
+
+	// Concurrency is the number of rows processed at the same time, 4 by
+	// default. Rows with the same key are processed in order by the same
+	// worker, so duplicates in the input do not create duplicates.
+	Concurrency int
+
+	// DryRun looks records up but does not create or update them.
+	DryRun bool
+}
+
+// Action is what was done with a row; the values of the upserts are the
+// ones of freshdesk.UpsertResult.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+	ActionFailed    Action = "failed"
+)
+
+// Result is the outcome of one input row. In a dry run Action tells what
+// would have been done, ActionUpdated for any existing record, and ID is
+// only set for updates.
+type Result struct {
+	Line   int
+	Key    string
+	Action Action
+	ID     uint64
+	Error  string
+}
+
+type Importer struct {
+	client  freshdesk.Client
+	options Options
+	fields  map[string]reflect.Type
+
+	companiesMu sync.Mutex
+	companies   map[string]*companyLookup
+
+	// Records created by this import, by lower case key. Search results
+	// lag behind writes, so a repeated key would not find its record.
+	createdMu sync.Mutex
+	created   map[string]uint64
+}
+
+type companyLookup struct {
+	once sync.Once
+	ID   uint64
+	err  error
+}
+
+func New(client freshdesk.Client, options Options) (*Importer, error) {
+	importer := &Importer{client: client, options: options, companies: map[string]*companyLookup{}, created: map[string]uint64{}}
+
+	switch options.Kind {
+	case Contacts:
+		importer.fields = contactFields
+		if importer.options.MatchBy == "" {
+			importer.options.MatchBy = MatchEmail
+		}
+		if importer.options.MatchBy != MatchEmail && importer.options.MatchBy != MatchExternalID {
+			return nil, fmt.Errorf("bulkimport: cannot match contacts by %q", options.MatchBy)
+		}
+	case Companies:
+		importer.fields = companyFields
+		importer.options.MatchBy = "name"
+	default:
+		return nil, fmt.Errorf("bulkimport: unknown kind %q", options.Kind)
+	}
+
+	if importer.options.ListSeparator == "" {
+		importer.options.ListSeparator = ";"
+	}
+	if importer.options.Concurrency <= 0 {
+		importer.options.Concurrency = 4
+	}
+	return importer, nil
+}
+
+type job struct {
+	row    Row
+	key    string
+	mapped map[string]interface{}
+}
+
+// Run imports every row of the reader and returns the results in input
+// order. Failed rows do not stop the import; the error is only set when
+// the input cannot be read.
+func (importer *Importer) Run(reader RowReader) ([]Result, error) {
+	results := make(chan Result)
+	var collected []Result
+	collectorDone := make(chan struct{})
+	go func() {
+		for result := range results {
+			collected = append(collected, result)
+		}
+		close(collectorDone)
+	}()
+
+	workers := make([]chan job, importer.options.Concurrency)
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = make(chan job, 16)
+		wg.Add(1)
+		go func(jobs chan job) {
+			defer wg.Done()
+			for job := range jobs {
+				results <- importer.process(job)
+			}
+		}(workers[i])
+	}
+
+	var readErr error
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			readErr = err
+			break
+		}
+
+		mapped, err := mapRow(row, importer.options.Mapping, importer.fields, importer.options.ListSeparator)
+		if err != nil {
+			results <- Result{Line: row.Line, Action: ActionFailed, Error: err.Error()}
+			continue
+		}
+		key, _ := mapped[string(importer.options.MatchBy)].(string)
+		if key == "" {
+			results <- Result{Line: row.Line, Action: ActionFailed, Error: fmt.Sprintf("missing %v", importer.options.MatchBy)}
+			continue
+		}
+
+		hash := fnv.New32a()
+		hash.Write([]byte(strings.ToLower(key)))
+		workers[hash.Sum32()%uint32(len(workers))] <- job{row: row, key: key, mapped: mapped}
+	}
+
+	for _, jobs := range workers {
+		close(jobs)
+	}
+	wg.Wait()
+	close(results)
+	<-collectorDone
+
+	sort.SliceStable(collected, func(i, j int) bool { return collected[i].Line < collected[j].Line })
+	return collected, readErr
+}
+
+func (importer *Importer) process(job job) Result {
+	result := Result{Line: job.row.Line, Key: job.key}
+
+	var err error
+	if importer.options.Kind == Contacts {
+		result.Action, result.ID, err = importer.upsertContact(job)
+	} else {
+		result.Action, result.ID, err = importer.upsertCompany(job)
+	}
+	if err != nil {
+		result.Action = ActionFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (importer *Importer) upsertContact(job job) (Action, uint64, error) {
+	if name, ok := job.mapped[companyField]; ok {
+		delete(job.mapped, companyField)
+		ID, err := importer.companyID(fmt.Sprint(name))
+		if err != nil {
+			return "", 0, err
+		}
+		job.mapped["company_id"] = ID
+	}
+
+	var payload freshdesk.ContactCreatePayload
+	if err := decode(job.mapped, &payload); err != nil {
+		return "", 0, err
+	}
+
+	// Search results lag behind writes, a record created by this import
+	// is updated by ID. Nothing is created in a dry run.
+	if ID, ok := importer.createdID(job.key); ok {
+		if _, err := importer.client.UpdateContact(ID, freshdesk.ContactUpdatePayload(payload)); err != nil {
+			return "", 0, err
+		}
+		return ActionUpdated, ID, nil
+	}
+
+	key := freshdesk.UpsertByEmail
+	if importer.options.MatchBy == MatchExternalID {
+		key = freshdesk.UpsertByExternalID
+	}
+	if importer.options.DryRun {
+		var existing freshdesk.Contact
+		var err error
+		if key == freshdesk.UpsertByExternalID {
+			existing, err = importer.client.FindContactByExternalID(job.key)
+		} else {
+			existing, err = importer.client.FindContactByEmail(job.key)
+		}
+		return dryRunAction(existing.ID, err, freshdesk.ERR_CONTACT_NOT_FOUND)
+	}
+
+	contact, result, err := importer.client.UpsertContact(key, payload)
+	if err != nil {
+		return "", 0, err
+	}
+	if result == freshdesk.UpsertCreated {
+		importer.setCreatedID(job.key, contact.ID)
+	}
+	return Action(result), contact.ID, nil
+}
+
+func (importer *Importer) upsertCompany(job job) (Action, uint64, error) {
+	var payload freshdesk.CompanyCreatePayload
+	if err := decode(job.mapped, &payload); err != nil {
+		return "", 0, err
+	}
+
+	if ID, ok := importer.createdID(job.key); ok {
+		if _, err := importer.client.UpdateCompany(ID, freshdesk.CompanyUpdatePayload(payload)); err != nil {
+			return "", 0, err
+		}
+		return ActionUpdated, ID, nil
+	}
+
+	if importer.options.DryRun {
+		existing, err := importer.client.GetCompanyByName(job.key)
+		ID := uint64(0)
+		if err == nil {
+			ID = existing.ID
+		}
+		return dryRunAction(ID, err, freshdesk.ERR_COMPANY_NOT_FOUND)
+	}
+
+	company, result, err := importer.client.UpsertCompany(freshdesk.UpsertByName, payload)
+	if err != nil {
+		return "", 0, err
+	}
+	if result == freshdesk.UpsertCreated {
+		importer.setCreatedID(job.key, company.ID)
+	}
+	return Action(result), company.ID, nil
+}
+
+// dryRunAction returns what the import would do after looking the record
+// up: create it when the lookup failed with notFound, update it otherwise.
+func dryRunAction(ID uint64, err error, notFound string) (Action, uint64, error) {
+	if err != nil && err.Error() == notFound {
+		return ActionCreated, 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return ActionUpdated, ID, nil
+}
+
+func (importer *Importer) createdID(key string) (uint64, bool) {
+	importer.createdMu.Lock()
+	defer importer.createdMu.Unlock()
+	ID, ok := importer.created[strings.ToLower(key)]
+	return ID, ok
+}
+
+func (importer *Importer) setCreatedID(key string, ID uint64) {
+	importer.createdMu.Lock()
+	defer importer.createdMu.Unlock()
+	importer.created[strings.ToLower(key)] = ID
+}
+
+// companyID resolves a company name once per import.
+func (importer *Importer) companyID(name string) (uint64, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	importer.companiesMu.Lock()
+	lookup, ok := importer.companies[key]
+	if !ok {
+		lookup = &companyLookup{}
+		importer.companies[key] = lookup
+	}
+	importer.companiesMu.Unlock()
+
+	lookup.once.Do(func() {
+		company, err := importer.client.GetCompanyByName(strings.TrimSpace(name))
+		if err != nil {
+			if err.Error() == freshdesk.ERR_COMPANY_NOT_FOUND {
+				err = fmt.Errorf("company %q not found", name)
+			}
+			lookup.err = err
+			return
+		}
+		lookup.ID = company.ID
+	})
+	return lookup.ID, lookup.err
+}
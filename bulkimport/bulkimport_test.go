@@ -0,0 +1,163 @@
+package bulkimport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/freshdeskmock"
+)
+
+func companiesByName(companies ...freshdesk.Company) func(name string) (*freshdesk.Company, error) {
+	return func(name string) (*freshdesk.Company, error) {
+		for _, company := range companies {
+			if strings.EqualFold(company.Name, name) {
+				return &company, nil
+			}
+		}
+		return nil, errors.New(freshdesk.ERR_COMPANY_NOT_FOUND)
+	}
+}
+
+func TestRunContacts(t *testing.T) {
+	client := &freshdeskmock.Client{}
+	client.GetCompanyByNameFunc = companiesByName(freshdesk.Company{ID: 5, Name: "Acme"})
+	client.UpsertContactFunc = func(key freshdesk.UpsertKey, payload freshdesk.ContactCreatePayload) (*freshdesk.Contact, freshdesk.UpsertResult, error) {
+		if payload.Email == "jane@example.com" {
+			return &freshdesk.Contact{ID: 7}, freshdesk.UpsertCreated, nil
+		}
+		return &freshdesk.Contact{ID: 8}, freshdesk.UpsertUnchanged, nil
+	}
+
+	importer, err := New(client, Options{Kind: Contacts, Mapping: Mapping{"Mail": "email"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := importer.Run(NewCSVReader(strings.NewReader(
+		"Mail,name,company\n" +
+			"jane@example.com,Jane,Acme\n" +
+			"john@example.com,John,acme\n" +
+			"JANE@example.com,Jane Doe,\n" +
+			",Nobody,\n" +
+			"mary@example.com,Mary,Initech\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Result{
+		{Line: 2, Key: "jane@example.com", Action: ActionCreated, ID: 7},
+		{Line: 3, Key: "john@example.com", Action: ActionUnchanged, ID: 8},
+		{Line: 4, Key: "JANE@example.com", Action: ActionUpdated, ID: 7},
+		{Line: 5, Action: ActionFailed, Error: "missing email"},
+		{Line: 6, Key: "mary@example.com", Action: ActionFailed, Error: `company "Initech" not found`},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %+v", results)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("line %d: got %+v, want %+v", want[i].Line, results[i], want[i])
+		}
+	}
+
+	calls := client.CallsTo("UpsertContact")
+	if len(calls) != 2 {
+		t.Fatalf("UpsertContact calls = %+v", calls)
+	}
+	// Rows are spread across workers, so the calls come in any order.
+	for _, call := range calls {
+		payload := call.Args[1].(freshdesk.ContactCreatePayload)
+		if call.Args[0] != freshdesk.UpsertByEmail || payload.CompanyID != 5 {
+			t.Errorf("upserted %+v", call)
+		}
+		if payload.Email == "jane@example.com" && payload.Name != "Jane" {
+			t.Errorf("upserted %+v", call)
+		}
+	}
+	// The contact created by the import is updated by id, search results
+	// lag behind.
+	if calls := client.CallsTo("UpdateContact"); len(calls) != 1 || calls[0].Args[0] != uint64(7) {
+		t.Errorf("UpdateContact calls = %+v", calls)
+	}
+	if count := client.CallCount("GetCompanyByName"); count != 2 {
+		t.Errorf("looked companies up %d times", count)
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	client := &freshdeskmock.Client{}
+	client.GetCompanyByNameFunc = companiesByName(freshdesk.Company{ID: 5, Name: "Acme"})
+
+	importer, err := New(client, Options{Kind: Companies, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := importer.Run(NewJSONLReader(strings.NewReader(
+		`{"name": "Acme", "domains": ["acme.com"]}` + "\n\n" +
+			`{"name": "Newco"}` + "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0] != (Result{Line: 1, Key: "Acme", Action: ActionUpdated, ID: 5}) ||
+		results[1] != (Result{Line: 3, Key: "Newco", Action: ActionCreated}) {
+		t.Errorf("got %+v", results)
+	}
+	for _, method := range []string{"UpsertCompany", "CreateCompany", "UpdateCompany"} {
+		if count := client.CallCount(method); count != 0 {
+			t.Errorf("dry run called %s %d times", method, count)
+		}
+	}
+}
+
+func TestRunDryRunByExternalID(t *testing.T) {
+	client := &freshdeskmock.Client{}
+	client.FindContactByExternalIDFunc = func(ID string) (freshdesk.Contact, error) {
+		if ID == "A-1" {
+			return freshdesk.Contact{ID: 3}, nil
+		}
+		return freshdesk.Contact{}, errors.New(freshdesk.ERR_CONTACT_NOT_FOUND)
+	}
+
+	importer, err := New(client, Options{Kind: Contacts, MatchBy: MatchExternalID, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := importer.Run(NewCSVReader(strings.NewReader("unique_external_id,name\nA-1,Jane\nA-2,John\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Action != ActionUpdated || results[0].ID != 3 || results[1].Action != ActionCreated {
+		t.Errorf("got %+v", results)
+	}
+	if client.CallCount("FindContactByEmail") != 0 || client.CallCount("UpsertContact") != 0 {
+		t.Errorf("calls = %+v", client.Calls())
+	}
+}
+
+func TestRunReadError(t *testing.T) {
+	client := &freshdeskmock.Client{}
+	client.UpsertCompanyFunc = func(key freshdesk.UpsertKey, payload freshdesk.CompanyCreatePayload) (*freshdesk.Company, freshdesk.UpsertResult, error) {
+		return &freshdesk.Company{ID: 1}, freshdesk.UpsertUpdated, nil
+	}
+	importer, err := New(client, Options{Kind: Companies})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := importer.Run(NewJSONLReader(strings.NewReader(`{"name": "Acme"}` + "\n" + `{"name":` + "\n")))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("got error %v", err)
+	}
+	if len(results) != 1 || results[0].Action != ActionUpdated {
+		t.Errorf("got %+v", results)
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	if _, err := New(&freshdeskmock.Client{}, Options{Kind: "tickets"}); err == nil {
+		t.Error("accepted tickets")
+	}
+	if _, err := New(&freshdeskmock.Client{}, Options{Kind: Contacts, MatchBy: "phone"}); err == nil {
+		t.Error("accepted matching by phone")
+	}
+}
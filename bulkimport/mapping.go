@@ -0,0 +1,122 @@
+package bulkimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// Mapping renames input columns to payload fields. Columns without an
+// entry keep their name and columns mapped to "-" are ignored.
+//
+// Fields are the JSON names of ContactCreatePayload or
+// CompanyCreatePayload, custom_fields.<name> for custom fields, and for
+// contacts "company", the name of the main company, resolved to its ID.
+type Mapping map[string]string
+
+const (
+	customFieldsPrefix = "custom_fields."
+	companyField       = "company"
+)
+
+var (
+	contactFields = withCompanyField(payloadFields(reflect.TypeOf(freshdesk.ContactCreatePayload{})))
+	companyFields = payloadFields(reflect.TypeOf(freshdesk.CompanyCreatePayload{}))
+)
+
+func withCompanyField(fields map[string]reflect.Type) map[string]reflect.Type {
+	fields[companyField] = reflect.TypeOf("")
+	return fields
+}
+
+func payloadFields(payloadType reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < payloadType.NumField(); i++ {
+		field := payloadType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = field.Type
+		}
+	}
+	return fields
+}
+
+// mapRow applies the mapping to a row and converts the text values of the
+// CSV input to the types of the payload fields. Lists are split on
+// separator. Custom field values are passed as they are.
+func mapRow(row Row, mapping Mapping, fields map[string]reflect.Type, separator string) (map[string]interface{}, error) {
+	mapped := map[string]interface{}{}
+	for column, value := range row.Fields {
+		name := column
+		if target, ok := mapping[column]; ok {
+			name = target
+		}
+		if name == "-" || name == "" {
+			continue
+		}
+
+		if strings.HasPrefix(name, customFieldsPrefix) {
+			custom, _ := mapped["custom_fields"].(map[string]interface{})
+			if custom == nil {
+				custom = map[string]interface{}{}
+				mapped["custom_fields"] = custom
+			}
+			custom[strings.TrimPrefix(name, customFieldsPrefix)] = value
+			continue
+		}
+
+		fieldType, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q for column %q", name, column)
+		}
+
+		converted, err := convert(value, fieldType, separator)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %v", column, err)
+		}
+		mapped[name] = converted
+	}
+	return mapped, nil
+}
+
+func convert(value interface{}, fieldType reflect.Type, separator string) (interface{}, error) {
+	text, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(text)
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(text, 10, 64)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(text, 10, 64)
+	case reflect.Slice:
+		if fieldType.Elem().Kind() == reflect.String {
+			var items []string
+			for _, item := range strings.Split(text, separator) {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		}
+		var items interface{}
+		err := json.Unmarshal([]byte(text), &items)
+		return items, err
+	}
+	return text, nil
+}
+
+// decode fills a payload from mapped fields.
+func decode(mapped map[string]interface{}, payload interface{}) error {
+	data, err := json.Marshal(mapped)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, payload)
+}
@@ -0,0 +1,74 @@
+package bulkimport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapRow(t *testing.T) {
+	row := Row{Line: 2, Fields: map[string]interface{}{
+		"E-mail":      "jane@example.com",
+		"name":        "Jane",
+		"view_all":    "true",
+		"tags":        "vip; billing ;",
+		"company":     "Acme",
+		"Notes":       "ignored",
+		"plan":        "gold",
+		"other_roles": `[{"company_id": 7}]`,
+	}}
+	mapping := Mapping{
+		"E-mail":      "email",
+		"view_all":    "view_all_tickets",
+		"Notes":       "-",
+		"plan":        "custom_fields.plan",
+		"other_roles": "other_companies",
+	}
+
+	mapped, err := mapRow(row, mapping, contactFields, ";")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"email":            "jane@example.com",
+		"name":             "Jane",
+		"view_all_tickets": true,
+		"tags":             []string{"vip", "billing"},
+		"company":          "Acme",
+		"custom_fields":    map[string]interface{}{"plan": "gold"},
+		"other_companies":  []interface{}{map[string]interface{}{"company_id": float64(7)}},
+	}
+	if !reflect.DeepEqual(mapped, want) {
+		t.Errorf("got %#v", mapped)
+	}
+}
+
+func TestMapRowJSONValues(t *testing.T) {
+	// JSONL rows already hold typed values, which are kept.
+	row := Row{Line: 1, Fields: map[string]interface{}{"name": "Acme", "domains": []interface{}{"acme.com"}, "custom_fields.size": float64(12)}}
+	mapped, err := mapRow(row, nil, companyFields, ";")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mapped["domains"], []interface{}{"acme.com"}) {
+		t.Errorf("domains = %#v", mapped["domains"])
+	}
+	if custom := mapped["custom_fields"].(map[string]interface{}); custom["size"] != float64(12) {
+		t.Errorf("custom fields = %#v", custom)
+	}
+}
+
+func TestMapRowErrors(t *testing.T) {
+	cases := map[string]Row{
+		`unknown field "nickname"`:  {Fields: map[string]interface{}{"nickname": "JJ"}},
+		`column "view_all_tickets"`: {Fields: map[string]interface{}{"view_all_tickets": "maybe"}},
+	}
+	for want, row := range cases {
+		if _, err := mapRow(row, nil, contactFields, ";"); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("got %v, want %s", err, want)
+		}
+	}
+	if _, err := mapRow(Row{Fields: map[string]interface{}{"company": "Acme"}}, nil, companyFields, ";"); err == nil {
+		t.Error("company accepted as a company field")
+	}
+}
@@ -0,0 +1,32 @@
+package bulkimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// WriteReport writes the results as CSV with the columns line, key,
+// action, id and error.
+func WriteReport(w io.Writer, results []Result) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"line", "key", "action", "id", "error"})
+	for _, result := range results {
+		ID := ""
+		if result.ID != 0 {
+			ID = fmt.Sprint(result.ID)
+		}
+		out.Write([]string{fmt.Sprint(result.Line), result.Key, string(result.Action), ID, result.Error})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// Summary counts the results by action.
+func Summary(results []Result) map[Action]int {
+	counts := map[Action]int{}
+	for _, result := range results {
+		counts[result.Action]++
+	}
+	return counts
+}
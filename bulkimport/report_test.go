@@ -0,0 +1,30 @@
+package bulkimport
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteReport(t *testing.T) {
+	results := []Result{
+		{Line: 2, Key: "jane@example.com", Action: ActionCreated, ID: 7},
+		{Line: 3, Key: "john@example.com", Action: ActionUnchanged, ID: 8},
+		{Line: 4, Action: ActionFailed, Error: "missing email, or \"mail\""},
+	}
+	var out bytes.Buffer
+	if err := WriteReport(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "line,key,action,id,error\n" +
+		"2,jane@example.com,created,7,\n" +
+		"3,john@example.com,unchanged,8,\n" +
+		"4,,failed,,\"missing email, or \"\"mail\"\"\"\n"
+	if out.String() != want {
+		t.Errorf("got\n%s", out.String())
+	}
+
+	summary := Summary(results)
+	if summary[ActionCreated] != 1 || summary[ActionUnchanged] != 1 || summary[ActionFailed] != 1 || summary[ActionUpdated] != 0 {
+		t.Errorf("summary = %v", summary)
+	}
+}
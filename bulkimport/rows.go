@@ -0,0 +1,87 @@
+package bulkimport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Row is one input record. Line is its line number in the input file.
+type Row struct {
+	Line   int
+	Fields map[string]interface{}
+}
+
+// RowReader returns the input rows one by one and io.EOF after the last.
+type RowReader interface {
+	Next() (Row, error)
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+// NewCSVReader reads a CSV file whose first row names the columns. Empty
+// cells are left out of the row, so they do not clear existing values.
+func NewCSVReader(r io.Reader) RowReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return &csvReader{reader: reader}
+}
+
+func (reader *csvReader) Next() (Row, error) {
+	if reader.header == nil {
+		header, err := reader.reader.Read()
+		if err != nil {
+			return Row{}, err
+		}
+		reader.header = header
+	}
+
+	record, err := reader.reader.Read()
+	if err != nil {
+		return Row{}, err
+	}
+	line, _ := reader.reader.FieldPos(0)
+
+	row := Row{Line: line, Fields: map[string]interface{}{}}
+	for i, value := range record {
+		if i < len(reader.header) && value != "" {
+			row.Fields[reader.header[i]] = value
+		}
+	}
+	return row, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewJSONLReader reads one JSON object per line. Blank lines are skipped.
+func NewJSONLReader(r io.Reader) RowReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &jsonlReader{scanner: scanner}
+}
+
+func (reader *jsonlReader) Next() (Row, error) {
+	for reader.scanner.Scan() {
+		reader.line++
+		if len(reader.scanner.Bytes()) == 0 {
+			continue
+		}
+		row := Row{Line: reader.line}
+		if err := json.Unmarshal(reader.scanner.Bytes(), &row.Fields); err != nil {
+			return row, fmt.Errorf("line %v: %v", reader.line, err)
+		}
+		return row, nil
+	}
+	if err := reader.scanner.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
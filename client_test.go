@@ -0,0 +1,110 @@
+package freshdesk_test
+
+import (
+	"fmt"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/freshdesktest"
+)
+
+// End-to-end cases against the fake helpdesk, which keeps state across
+// requests; the per-method cases are in freshdesk_test.go.
+
+func newServer(t *testing.T) (*freshdesktest.Server, freshdesk.Client) {
+	t.Helper()
+	server := freshdesktest.NewServer()
+	t.Cleanup(server.Close)
+	return server, server.Client()
+}
+
+func TestTicketConversation(t *testing.T) {
+	server, client := newServer(t)
+
+	ticket, err := client.CreateTicket(freshdesk.TicketCreatePayload{
+		Email:       "ann@example.com",
+		Subject:     "Printer on fire",
+		Description: "<p>It burns</p>",
+		Status:      freshdesk.StatusOpen,
+		Priority:    freshdesk.PriorityHigh,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.DescriptionText != "It burns" {
+		t.Errorf("description text %q", ticket.DescriptionText)
+	}
+	// The requester is created from the email, as the API does.
+	requester, err := client.FindContactByEmail("ann@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(ticket.RequesterID) != requester.ID {
+		t.Errorf("requester %d, contact %d", ticket.RequesterID, requester.ID)
+	}
+
+	for i := 0; i < 12; i++ {
+		if _, err := client.CreateTicketMessage(ticket.ID, freshdesk.TicketMessageCreatePayload{BodyHtml: fmt.Sprint("Reply ", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	withConversations, err := client.GetTicketWithConversations(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withConversations.Conversations) != 10 {
+		t.Errorf("included %d conversations", len(withConversations.Conversations))
+	}
+	all, err := client.GetAllTicketConversations(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 12 || all[11].BodyText != "Reply 11" {
+		t.Errorf("got %d conversations", len(all))
+	}
+
+	if _, err := client.DeleteTicket(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := server.Ticket(ticket.ID); !stored.Deleted {
+		t.Error("ticket not deleted")
+	}
+}
+
+func TestCompanyContacts(t *testing.T) {
+	_, client := newServer(t)
+
+	company, err := client.CreateCompany(freshdesk.CompanyCreatePayload{Name: "Acme", Domains: []string{"acme.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, email := range []string{"ann@acme.com", "bob@acme.com"} {
+		if _, err := client.CreateContact(freshdesk.ContactCreatePayload{Name: email, Email: email, CompanyID: company.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counted, err := client.GetCompanyWithContactCount(company.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counted.ContactCount == nil || *counted.ContactCount != 2 {
+		t.Errorf("contact count %v", counted.ContactCount)
+	}
+	byName, err := client.GetCompanyByName("Acme")
+	if err != nil || byName.ID != company.ID {
+		t.Errorf("by name: %+v, %v", byName, err)
+	}
+	byDomain, err := client.GetCompanyByDomain("acme.com")
+	if err != nil || byDomain.ID != company.ID {
+		t.Errorf("by domain: %+v, %v", byDomain, err)
+	}
+	if _, err := client.GetCompanyByName("Initech"); err == nil || err.Error() != freshdesk.ERR_COMPANY_NOT_FOUND {
+		t.Errorf("unknown company: %v", err)
+	}
+
+	_, result, err := client.UpsertCompany(freshdesk.UpsertByName, freshdesk.CompanyCreatePayload{Name: "Acme", Domains: []string{"acme.com"}})
+	if err != nil || result != freshdesk.UpsertUnchanged {
+		t.Errorf("upsert: %v, %v", result, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Command fd-import loads contacts or companies from a CSV or JSONL file.
+//
+// Rows are matched with existing records by email or unique external ID
+// for contacts and by name for companies; matches are updated, the other
+// rows are created. Columns are named after the API fields and can be
+// renamed with -map:
+//
+//	fd-import -kind contacts -f people.csv -map "E-mail=email" -map "Org=company" -map "Region=custom_fields.cf_region"
+//
+// A "company" column holds the name of the main company of a contact. The
+// outcome of every row is written to the -report file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/bulkimport"
+)
+
+type mappingFlag bulkimport.Mapping
+
+func (mapping mappingFlag) String() string {
+	return fmt.Sprint(bulkimport.Mapping(mapping))
+}
+
+func (mapping mappingFlag) Set(value string) error {
+	column, field, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected column=field, got %q", value)
+	}
+	mapping[column] = field
+	return nil
+}
+
+func main() {
+	mapping := mappingFlag{}
+	var (
+		file        = flag.String("f", "", "input file, .csv or .jsonl")
+		kind        = flag.String("kind", "contacts", "contacts or companies")
+		match       = flag.String("match", "email", "contact match field, email or unique_external_id")
+		separator   = flag.String("separator", ";", "separator of list values in CSV cells")
+		concurrency = flag.Int("concurrency", 4, "rows processed at the same time")
+		report      = flag.String("report", "import-report.csv", "result report file")
+		dryRun      = flag.Bool("dry-run", false, "look records up without creating or updating them")
+		baseUrl     = flag.String("url", os.Getenv("FRESHDESK_URL"), "helpdesk URL, e.g. https://domain.freshdesk.com")
+		user        = flag.String("user", os.Getenv("FRESHDESK_API_KEY"), "API key or user name")
+		password    = flag.String("password", envOr("FRESHDESK_PASSWORD", "X"), "password, X when authenticating with an API key")
+		rpm         = flag.Int("rpm", 50, "maximum requests per minute")
+	)
+	flag.Var(mapping, "map", "column=field mapping, may be repeated; field - ignores the column")
+	flag.Parse()
+
+	if *file == "" || *baseUrl == "" || *user == "" {
+		fmt.Fprintln(os.Stderr, "fd-import: -f, -url and -user (or FRESHDESK_URL and FRESHDESK_API_KEY) are required")
+		os.Exit(2)
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer input.Close()
+
+	var reader bulkimport.RowReader
+	switch strings.ToLower(filepath.Ext(*file)) {
+	case ".jsonl", ".ndjson":
+		reader = bulkimport.NewJSONLReader(input)
+	default:
+		reader = bulkimport.NewCSVReader(input)
+	}
+
+	client := freshdesk.NewClient(*baseUrl, *user, *password, *rpm)
+	importer, err := bulkimport.New(client, bulkimport.Options{
+		Kind:          bulkimport.Kind(*kind),
+		MatchBy:       bulkimport.MatchBy(*match),
+		Mapping:       bulkimport.Mapping(mapping),
+		ListSeparator: *separator,
+		Concurrency:   *concurrency,
+		DryRun:        *dryRun,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, runErr := importer.Run(reader)
+	if err := writeReport(*report, results); err != nil {
+		log.Fatal(err)
+	}
+
+	summary := bulkimport.Summary(results)
+	fmt.Printf("%v rows: %v created, %v updated, %v unchanged, %v failed. Report written to %v.\n",
+		len(results), summary[bulkimport.ActionCreated], summary[bulkimport.ActionUpdated], summary[bulkimport.ActionUnchanged], summary[bulkimport.ActionFailed], *report)
+	if runErr != nil {
+		log.Fatal(runErr)
+	}
+	if summary[bulkimport.ActionFailed] > 0 {
+		os.Exit(1)
+	}
+}
+
+func writeReport(path string, results []bulkimport.Result) error {
+	var out io.WriteCloser = os.Stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		out = file
+	}
+	if err := bulkimport.WriteReport(out, results); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
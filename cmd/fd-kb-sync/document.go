@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"gopkg.in/yaml.v3"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// frontMatter is the YAML header of a Markdown article.
+type frontMatter struct {
+	ID       uint64   `yaml:"id"`
+	Title    string   `yaml:"title"`
+	Category string   `yaml:"category"`
+	Folder   string   `yaml:"folder"`
+	Tags     []string `yaml:"tags"`
+	Status   string   `yaml:"status"`
+}
+
+// document is a Markdown article ready to be published.
+type document struct {
+	Path     string
+	ID       uint64
+	Title    string
+	Category string
+	Folder   string
+	Tags     []string
+	Status   freshdesk.ArticleStatus
+	HTML     string
+}
+
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// loadDocuments reads every Markdown file below root, sorted by path.
+func loadDocuments(root string) ([]document, error) {
+	var docs []document
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		doc, err := loadDocument(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+	return docs, nil
+}
+
+func loadDocument(path string) (document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return document{}, err
+	}
+
+	header, body, err := splitFrontMatter(data)
+	if err != nil {
+		return document{}, err
+	}
+
+	var meta frontMatter
+	if err := yaml.Unmarshal(header, &meta); err != nil {
+		return document{}, err
+	}
+	if meta.Category == "" || meta.Folder == "" {
+		return document{}, errors.New("front matter must set category and folder")
+	}
+
+	doc := document{
+		Path:     path,
+		ID:       meta.ID,
+		Title:    meta.Title,
+		Category: meta.Category,
+		Folder:   meta.Folder,
+		Tags:     meta.Tags,
+		Status:   freshdesk.ArticleStatusPublished,
+	}
+	switch strings.ToLower(meta.Status) {
+	case "", "published":
+	case "draft":
+		doc.Status = freshdesk.ArticleStatusDraft
+	default:
+		return document{}, fmt.Errorf("unknown status %q", meta.Status)
+	}
+	if doc.Title == "" {
+		doc.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	var out bytes.Buffer
+	if err := markdown.Convert(body, &out); err != nil {
+		return document{}, err
+	}
+	doc.HTML = out.String()
+
+	return doc, nil
+}
+
+// splitFrontMatter separates the leading "---" delimited block from the
+// Markdown body.
+func splitFrontMatter(data []byte) ([]byte, []byte, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(data, []byte("---\n")) {
+		return nil, nil, errors.New("missing front matter")
+	}
+
+	rest := data[len("---\n"):]
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return nil, nil, errors.New("unterminated front matter")
+	}
+
+	body := rest[end+len("\n---"):]
+	if i := bytes.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = nil
+	}
+	return rest[:end], body, nil
+}
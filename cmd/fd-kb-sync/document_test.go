@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		header string
+		body   string
+		err    string
+	}{
+		{"header and body", "---\ntitle: Install\n---\n# Install\n", "title: Install", "# Install\n", ""},
+		{"windows line endings", "---\r\ntitle: Install\r\n---\r\nText\r\n", "title: Install", "Text\n", ""},
+		{"byte order mark", "\ufeff---\ntitle: Install\n---\nText", "title: Install", "Text", ""},
+		{"no body", "---\ntitle: Install\n---", "title: Install", "", ""},
+		{"empty header", "---\n\n---\nText", "", "Text", ""},
+		{"rule in the body", "---\ntitle: Install\n---\nA\n\n---\n\nB\n", "title: Install", "A\n\n---\n\nB\n", ""},
+		{"no front matter", "# Install\n", "", "", "missing front matter"},
+		{"not at the start", "\n---\ntitle: Install\n---\n", "", "", "missing front matter"},
+		{"empty file", "", "", "", "missing front matter"},
+		{"unterminated", "---\ntitle: Install\n# Install\n", "", "", "unterminated front matter"},
+	}
+	for _, tc := range cases {
+		header, body, err := splitFrontMatter([]byte(tc.data))
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s: got error %v, want %q", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if string(header) != tc.header || string(body) != tc.body {
+			t.Errorf("%s: got header %q and body %q", tc.name, header, body)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDocument(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Resetting passwords.md")
+	writeFile(t, path, "---\nid: 43000512345\ncategory: Getting started\nfolder: Accounts\ntags: [password, login]\nstatus: Draft\n---\n# Reset\n\n| a | b |\n|---|---|\n| 1 | 2 |\n")
+
+	doc, err := loadDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ID != 43000512345 || doc.Title != "Resetting passwords" || doc.Category != "Getting started" || doc.Folder != "Accounts" ||
+		doc.Status != freshdesk.ArticleStatusDraft || len(doc.Tags) != 2 || doc.Tags[1] != "login" {
+		t.Errorf("got %+v", doc)
+	}
+	if !strings.Contains(doc.HTML, "<h1>Reset</h1>") || !strings.Contains(doc.HTML, "<table>") {
+		t.Errorf("got HTML %q", doc.HTML)
+	}
+}
+
+func TestLoadDocumentErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no front matter", "# Install\n", "missing front matter"},
+		{"unterminated front matter", "---\ncategory: Guides\n", "unterminated front matter"},
+		{"malformed front matter", "---\ncategory: [Guides\nfolder: Setup\n---\n", "yaml:"},
+		{"wrong type", "---\nid: first\ncategory: Guides\nfolder: Setup\n---\n", "yaml:"},
+		{"missing folder", "---\ncategory: Guides\n---\n", "front matter must set category and folder"},
+		{"unknown status", "---\ncategory: Guides\nfolder: Setup\nstatus: hidden\n---\n", `unknown status "hidden"`},
+	}
+	dir := t.TempDir()
+	for i, tc := range cases {
+		path := filepath.Join(dir, string(rune('a'+i))+".md")
+		writeFile(t, path, tc.content)
+		if _, err := loadDocument(path); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestLoadDocuments(t *testing.T) {
+	dir := t.TempDir()
+	header := "---\ncategory: Guides\nfolder: Setup\n---\n"
+	writeFile(t, filepath.Join(dir, "b", "upgrade.MD"), header+"Upgrade")
+	writeFile(t, filepath.Join(dir, "a.md"), "---\ntitle: Install\ncategory: Guides\nfolder: Setup\n---\nInstall")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "not an article")
+
+	docs, err := loadDocuments(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || docs[0].Title != "Install" || docs[1].Title != "upgrade" || docs[1].Status != freshdesk.ArticleStatusPublished {
+		t.Errorf("got %+v", docs)
+	}
+
+	writeFile(t, filepath.Join(dir, "c.md"), "no front matter")
+	if _, err := loadDocuments(dir); err == nil || !strings.Contains(err.Error(), "c.md: missing front matter") {
+		t.Errorf("got error %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Command fd-kb-sync publishes a directory tree of Markdown files to the
+// Freshdesk knowledge base (Solutions).
+//
+// Every *.md file starts with a YAML front matter block naming the
+// category and folder the article belongs to:
+//
+//	---
+//	title: Resetting your password
+//	category: Getting started
+//	folder: Accounts
+//	tags: [password, login]
+//	id: 43000512345
+//	status: published
+//	---
+//
+// The body is rendered to HTML and compared with the articles already
+// present in the referenced folders. Missing categories, folders and
+// articles are created, changed articles are updated and published
+// articles that no longer have a source file are archived (moved back to
+// draft). With -dry-run the plan is printed and nothing is changed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func main() {
+	var (
+		dir      = flag.String("dir", ".", "root of the Markdown tree")
+		baseUrl  = flag.String("url", os.Getenv("FRESHDESK_URL"), "helpdesk URL, e.g. https://domain.freshdesk.com")
+		user     = flag.String("user", os.Getenv("FRESHDESK_API_KEY"), "API key or user name")
+		password = flag.String("password", envOr("FRESHDESK_PASSWORD", "X"), "password, X when authenticating with an API key")
+		rpm      = flag.Int("rpm", 50, "maximum requests per minute")
+		dryRun   = flag.Bool("dry-run", false, "print the plan without applying it")
+		archive  = flag.Bool("archive", true, "archive published articles that have no source file")
+	)
+	flag.Parse()
+
+	if *baseUrl == "" || *user == "" {
+		fmt.Fprintln(os.Stderr, "fd-kb-sync: -url and -user (or FRESHDESK_URL and FRESHDESK_API_KEY) are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	docs, err := loadDocuments(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := freshdesk.NewClient(*baseUrl, *user, *password, *rpm)
+	plan, err := buildPlan(client, docs, *archive)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	plan.Print(os.Stdout)
+	if *dryRun || plan.Empty() {
+		return
+	}
+
+	if err := plan.Apply(client); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
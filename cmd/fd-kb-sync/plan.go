@@ -0,0 +1,337 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+type actionKind int
+
+const (
+	createCategory actionKind = iota
+	createFolder
+	createArticle
+	updateArticle
+	archiveArticle
+)
+
+type folderKey struct {
+	Category string
+	Folder   string
+}
+
+func (key folderKey) String() string {
+	return key.Category + " / " + key.Folder
+}
+
+type action struct {
+	Kind    actionKind
+	Folder  folderKey
+	Doc     *document
+	Article *freshdesk.SolutionArticle
+	Changes []string
+}
+
+// plan is the ordered list of changes needed to bring the knowledge base
+// in line with the Markdown tree.
+type plan struct {
+	Actions   []action
+	Unchanged int
+
+	categoryIDs map[string]uint64
+	folderIDs   map[folderKey]uint64
+}
+
+func buildPlan(client freshdesk.Client, docs []document, archive bool) (*plan, error) {
+	result := &plan{
+		categoryIDs: map[string]uint64{},
+		folderIDs:   map[folderKey]uint64{},
+	}
+
+	categories, err := client.GetAllSolutionCategories()
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %w", err)
+	}
+	for _, category := range categories {
+		result.categoryIDs[category.Name] = category.ID
+	}
+
+	var structure []action
+	folders := map[string][]freshdesk.SolutionFolder{}
+	articles := map[folderKey][]freshdesk.SolutionArticle{}
+	var keys []folderKey
+	for _, doc := range docs {
+		key := folderKey{Category: doc.Category, Folder: doc.Folder}
+		if _, seen := articles[key]; seen {
+			continue
+		}
+		articles[key] = nil
+		keys = append(keys, key)
+
+		categoryID, ok := result.categoryIDs[key.Category]
+		if !ok {
+			if !hasAction(structure, createCategory, key) {
+				structure = append(structure, action{Kind: createCategory, Folder: key})
+			}
+			structure = append(structure, action{Kind: createFolder, Folder: key})
+			continue
+		}
+
+		if _, fetched := folders[key.Category]; !fetched {
+			folders[key.Category], err = client.GetAllSolutionFolders(categoryID)
+			if err != nil {
+				return nil, fmt.Errorf("listing folders of %q: %w", key.Category, err)
+			}
+		}
+		folderID, ok := findFolder(folders[key.Category], key.Folder)
+		if !ok {
+			structure = append(structure, action{Kind: createFolder, Folder: key})
+			continue
+		}
+		result.folderIDs[key] = folderID
+
+		articles[key], err = client.GetAllSolutionArticles(folderID)
+		if err != nil {
+			return nil, fmt.Errorf("listing articles of %s: %w", key, err)
+		}
+	}
+
+	var changes []action
+	matched := map[uint64]string{}
+	for i := range docs {
+		doc := &docs[i]
+		key := folderKey{Category: doc.Category, Folder: doc.Folder}
+
+		existing, err := findArticle(client, articles[key], doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", doc.Path, err)
+		}
+		if existing == nil {
+			changes = append(changes, action{Kind: createArticle, Folder: key, Doc: doc})
+			continue
+		}
+		if folderID, ok := result.folderIDs[key]; !ok || existing.FolderID != 0 && existing.FolderID != folderID {
+			return nil, fmt.Errorf("%s: article %d is not in folder %s", doc.Path, existing.ID, key)
+		}
+		if other, dup := matched[existing.ID]; dup {
+			return nil, fmt.Errorf("%s: article %d is also published from %s", doc.Path, existing.ID, other)
+		}
+		matched[existing.ID] = doc.Path
+
+		if diff := diffArticle(existing, doc); len(diff) > 0 {
+			changes = append(changes, action{Kind: updateArticle, Folder: key, Doc: doc, Article: existing, Changes: diff})
+		} else {
+			result.Unchanged++
+		}
+	}
+
+	if archive {
+		for _, key := range keys {
+			for i := range articles[key] {
+				article := &articles[key][i]
+				if _, ok := matched[article.ID]; ok || article.Status != freshdesk.ArticleStatusPublished {
+					continue
+				}
+				changes = append(changes, action{Kind: archiveArticle, Folder: key, Article: article})
+			}
+		}
+	}
+
+	result.Actions = append(structure, changes...)
+	return result, nil
+}
+
+func hasAction(actions []action, kind actionKind, key folderKey) bool {
+	for _, a := range actions {
+		if a.Kind == kind && a.Folder.Category == key.Category && (kind == createCategory || a.Folder.Folder == key.Folder) {
+			return true
+		}
+	}
+	return false
+}
+
+func findFolder(folders []freshdesk.SolutionFolder, name string) (uint64, bool) {
+	for _, folder := range folders {
+		if folder.Name == name {
+			return folder.ID, true
+		}
+	}
+	return 0, false
+}
+
+// findArticle looks a document up by its id, falling back to the title
+// within the target folder for documents that were never published.
+func findArticle(client freshdesk.Client, articles []freshdesk.SolutionArticle, doc *document) (*freshdesk.SolutionArticle, error) {
+	if doc.ID == 0 {
+		for i := range articles {
+			if articles[i].Title == doc.Title {
+				return &articles[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	for i := range articles {
+		if articles[i].ID == doc.ID {
+			return &articles[i], nil
+		}
+	}
+	article, err := client.GetSolutionArticle(doc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching article %d: %w", doc.ID, err)
+	}
+	return article, nil
+}
+
+func diffArticle(article *freshdesk.SolutionArticle, doc *document) []string {
+	var diff []string
+	if article.Title != doc.Title {
+		diff = append(diff, "title")
+	}
+	if normalizeHTML(article.Description) != normalizeHTML(doc.HTML) {
+		diff = append(diff, "body")
+	}
+	if !sameTags(article.Tags, doc.Tags) {
+		diff = append(diff, "tags")
+	}
+	if article.Status != doc.Status {
+		diff = append(diff, "status")
+	}
+	return diff
+}
+
+// normalizeHTML drops the whitespace differences Freshdesk introduces when
+// it stores an article body.
+func normalizeHTML(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	return strings.ReplaceAll(body, "> <", "><")
+}
+
+func sameTags(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	// Freshdesk does not keep the case of tags.
+	a = lowerSorted(a)
+	b = lowerSorted(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerSorted(values []string) []string {
+	lower := make([]string, len(values))
+	for i, value := range values {
+		lower[i] = strings.ToLower(value)
+	}
+	sort.Strings(lower)
+	return lower
+}
+
+func (p *plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+func (p *plan) Print(w io.Writer) {
+	var created, updated, archived int
+	for _, a := range p.Actions {
+		fmt.Fprintln(w, a.describe())
+		switch a.Kind {
+		case updateArticle:
+			updated++
+		case archiveArticle:
+			archived++
+		default:
+			created++
+		}
+	}
+	if len(p.Actions) > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to archive, %d unchanged.\n", created, updated, archived, p.Unchanged)
+}
+
+func (a action) describe() string {
+	switch a.Kind {
+	case createCategory:
+		return fmt.Sprintf("+ category %q", a.Folder.Category)
+	case createFolder:
+		return fmt.Sprintf("+ folder %q", a.Folder.String())
+	case createArticle:
+		return fmt.Sprintf("+ article %q (%s)", a.Doc.Title, a.Doc.Path)
+	case updateArticle:
+		return fmt.Sprintf("~ article %d %q (%s): %s", a.Article.ID, a.Doc.Title, a.Doc.Path, strings.Join(a.Changes, ", "))
+	default:
+		return fmt.Sprintf("- article %d %q in %q (archive)", a.Article.ID, a.Article.Title, a.Folder.String())
+	}
+}
+
+// Apply performs the planned actions in order, stopping at the first
+// failure.
+func (p *plan) Apply(client freshdesk.Client) error {
+	for _, a := range p.Actions {
+		switch a.Kind {
+		case createCategory:
+			category, err := client.CreateSolutionCategory(freshdesk.SolutionCategoryCreatePayload{Name: a.Folder.Category})
+			if err != nil {
+				return fmt.Errorf("creating category %q: %w", a.Folder.Category, err)
+			}
+			p.categoryIDs[a.Folder.Category] = category.ID
+
+		case createFolder:
+			folder, err := client.CreateSolutionFolder(p.categoryIDs[a.Folder.Category], freshdesk.SolutionFolderCreatePayload{
+				Name:       a.Folder.Folder,
+				Visibility: 1,
+			})
+			if err != nil {
+				return fmt.Errorf("creating folder %q: %w", a.Folder.String(), err)
+			}
+			p.folderIDs[a.Folder] = folder.ID
+
+		case createArticle:
+			article, err := client.CreateSolutionArticle(p.folderIDs[a.Folder], freshdesk.SolutionArticleCreatePayload{
+				Title:       a.Doc.Title,
+				Description: a.Doc.HTML,
+				Status:      a.Doc.Status,
+				Tags:        a.Doc.Tags,
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", a.Doc.Path, err)
+			}
+			if a.Doc.ID == 0 {
+				log.Printf("%s: created article %d, add \"id: %d\" to its front matter", a.Doc.Path, article.ID, article.ID)
+			}
+
+		case updateArticle:
+			// An empty list is sent too, so that removing every tag from
+			// the document removes them from the article.
+			tags := append([]string{}, a.Doc.Tags...)
+			_, err := client.UpdateSolutionArticle(a.Article.ID, freshdesk.SolutionArticleUpdatePayload{
+				Title:       a.Doc.Title,
+				Description: a.Doc.HTML,
+				Status:      a.Doc.Status,
+				Tags:        &tags,
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", a.Doc.Path, err)
+			}
+
+		case archiveArticle:
+			_, err := client.UpdateSolutionArticle(a.Article.ID, freshdesk.SolutionArticleUpdatePayload{
+				Status: freshdesk.ArticleStatusDraft,
+			})
+			if err != nil {
+				return fmt.Errorf("archiving article %d: %w", a.Article.ID, err)
+			}
+		}
+	}
+	return nil
+}
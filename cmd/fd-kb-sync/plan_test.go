@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/freshdeskmock"
+)
+
+// kbClient serves one category "Guides" (1) holding the folder "Setup"
+// (10) with the given articles. Created categories and folders get ids
+// from 2 and 11 on.
+func kbClient(articles ...freshdesk.SolutionArticle) *freshdeskmock.Client {
+	client := &freshdeskmock.Client{}
+	client.GetAllSolutionCategoriesFunc = func() ([]freshdesk.SolutionCategory, error) {
+		return []freshdesk.SolutionCategory{{ID: 1, Name: "Guides"}}, nil
+	}
+	client.GetAllSolutionFoldersFunc = func(categoryID uint64) ([]freshdesk.SolutionFolder, error) {
+		if categoryID != 1 {
+			return nil, errors.New("unknown category")
+		}
+		return []freshdesk.SolutionFolder{{ID: 10, Name: "Setup"}}, nil
+	}
+	client.GetAllSolutionArticlesFunc = func(folderID uint64) ([]freshdesk.SolutionArticle, error) {
+		if folderID != 10 {
+			return nil, errors.New("unknown folder")
+		}
+		return articles, nil
+	}
+
+	nextCategory, nextFolder := uint64(2), uint64(11)
+	client.CreateSolutionCategoryFunc = func(payload freshdesk.SolutionCategoryCreatePayload) (*freshdesk.SolutionCategory, error) {
+		nextCategory++
+		return &freshdesk.SolutionCategory{ID: nextCategory - 1, Name: payload.Name}, nil
+	}
+	client.CreateSolutionFolderFunc = func(categoryID uint64, payload freshdesk.SolutionFolderCreatePayload) (*freshdesk.SolutionFolder, error) {
+		nextFolder++
+		return &freshdesk.SolutionFolder{ID: nextFolder - 1, Name: payload.Name}, nil
+	}
+	client.CreateSolutionArticleFunc = func(folderID uint64, payload freshdesk.SolutionArticleCreatePayload) (*freshdesk.SolutionArticle, error) {
+		return &freshdesk.SolutionArticle{ID: 500, FolderID: folderID, Title: payload.Title}, nil
+	}
+	client.UpdateSolutionArticleFunc = func(ID uint64, payload freshdesk.SolutionArticleUpdatePayload) (*freshdesk.SolutionArticle, error) {
+		return &freshdesk.SolutionArticle{ID: ID}, nil
+	}
+	return client
+}
+
+func guide(ID uint64, folder string, title string) document {
+	return document{
+		Path:     strings.ToLower(title) + ".md",
+		ID:       ID,
+		Title:    title,
+		Category: "Guides",
+		Folder:   folder,
+		Status:   freshdesk.ArticleStatusPublished,
+		HTML:     "<p>" + title + "</p>\n",
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	client := kbClient(
+		freshdesk.SolutionArticle{ID: 100, FolderID: 10, Title: "Install", Description: "<p>Install</p>", Tags: []string{"Setup"}, Status: freshdesk.ArticleStatusPublished},
+		freshdesk.SolutionArticle{ID: 101, FolderID: 10, Title: "Retired", Status: freshdesk.ArticleStatusPublished},
+		freshdesk.SolutionArticle{ID: 102, FolderID: 10, Title: "Unfinished", Status: freshdesk.ArticleStatusDraft},
+	)
+	client.GetSolutionArticleFunc = func(ID uint64) (*freshdesk.SolutionArticle, error) {
+		return &freshdesk.SolutionArticle{ID: ID, FolderID: 10, Title: "Old title", Description: "<p>Upgrade</p>", Status: freshdesk.ArticleStatusPublished}, nil
+	}
+
+	install := guide(100, "Setup", "Install")
+	install.Tags = []string{"setup"}
+	faq := guide(0, "General", "Questions")
+	faq.Category = "FAQ"
+	docs := []document{
+		install,
+		guide(0, "Setup", "Configure"),
+		guide(200, "Setup", "Upgrade"),
+		guide(0, "Advanced", "Tuning"),
+		faq,
+	}
+
+	plan, err := buildPlan(client, docs, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, a := range plan.Actions {
+		got = append(got, a.describe())
+	}
+	want := []string{
+		`+ folder "Guides / Advanced"`,
+		`+ category "FAQ"`,
+		`+ folder "FAQ / General"`,
+		`+ article "Configure" (configure.md)`,
+		`~ article 200 "Upgrade" (upgrade.md): title`,
+		`+ article "Tuning" (tuning.md)`,
+		`+ article "Questions" (questions.md)`,
+		`- article 101 "Retired" in "Guides / Setup" (archive)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got actions\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if plan.Unchanged != 1 {
+		t.Errorf("unchanged = %d", plan.Unchanged)
+	}
+	if calls := client.CallsTo("GetSolutionArticle"); len(calls) != 1 || calls[0].Args[0] != uint64(200) {
+		t.Errorf("GetSolutionArticle calls = %+v", calls)
+	}
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	if !strings.Contains(out.String(), "Plan: 6 to create, 1 to update, 1 to archive, 1 unchanged.") {
+		t.Errorf("plan output:\n%s", out.String())
+	}
+}
+
+func TestBuildPlanWithoutArchive(t *testing.T) {
+	client := kbClient(freshdesk.SolutionArticle{ID: 101, FolderID: 10, Title: "Retired", Status: freshdesk.ArticleStatusPublished})
+	plan, err := buildPlan(client, []document{guide(0, "Setup", "Install")}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Actions) != 1 || plan.Actions[0].Kind != createArticle {
+		t.Errorf("got actions %+v", plan.Actions)
+	}
+}
+
+func TestBuildPlanErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		docs    []document
+		article *freshdesk.SolutionArticle
+		want    string
+	}{
+		{
+			name:    "article in another folder",
+			docs:    []document{guide(200, "Setup", "Upgrade")},
+			article: &freshdesk.SolutionArticle{ID: 200, FolderID: 99},
+			want:    "upgrade.md: article 200 is not in folder Guides / Setup",
+		},
+		{
+			name: "article published twice",
+			docs: []document{guide(100, "Setup", "Install"), guide(100, "Setup", "Reinstall")},
+			want: "reinstall.md: article 100 is also published from install.md",
+		},
+		{
+			name: "unknown article",
+			docs: []document{guide(200, "Setup", "Upgrade")},
+			want: "upgrade.md: fetching article 200: not found",
+		},
+	}
+	for _, tc := range cases {
+		client := kbClient(freshdesk.SolutionArticle{ID: 100, FolderID: 10, Title: "Install"})
+		article := tc.article
+		client.GetSolutionArticleFunc = func(ID uint64) (*freshdesk.SolutionArticle, error) {
+			if article == nil {
+				return nil, errors.New("not found")
+			}
+			return article, nil
+		}
+		if _, err := buildPlan(client, tc.docs, true); err == nil || err.Error() != tc.want {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestFindArticle(t *testing.T) {
+	articles := []freshdesk.SolutionArticle{{ID: 1, Title: "Install"}, {ID: 2, Title: "Upgrade"}}
+	client := &freshdeskmock.Client{}
+
+	if article, err := findArticle(client, articles, &document{Title: "Upgrade"}); err != nil || article == nil || article.ID != 2 {
+		t.Errorf("by title = %+v, %v", article, err)
+	}
+	if article, err := findArticle(client, articles, &document{Title: "Tuning"}); err != nil || article != nil {
+		t.Errorf("unknown title = %+v, %v", article, err)
+	}
+	// A document with an id is never matched by title.
+	if article, err := findArticle(client, articles, &document{ID: 1, Title: "Upgrade"}); err != nil || article == nil || article.ID != 1 {
+		t.Errorf("by id = %+v, %v", article, err)
+	}
+	if count := client.CallCount("GetSolutionArticle"); count != 0 {
+		t.Errorf("fetched %d articles listed in the folder", count)
+	}
+}
+
+func TestDiffArticle(t *testing.T) {
+	article := &freshdesk.SolutionArticle{
+		Title:       "Install",
+		Description: "<h1>Install</h1>\n<p>Run   the\n installer.</p>",
+		Tags:        []string{"Setup", "windows"},
+		Status:      freshdesk.ArticleStatusPublished,
+	}
+	doc := document{
+		Title:  "Install",
+		HTML:   "<h1>Install</h1> <p>Run the installer.</p>\n",
+		Tags:   []string{"Windows", "setup"},
+		Status: freshdesk.ArticleStatusPublished,
+	}
+
+	cases := []struct {
+		name   string
+		change func(doc *document)
+		want   []string
+	}{
+		{"unchanged", func(doc *document) {}, nil},
+		{"title", func(doc *document) { doc.Title = "Installing" }, []string{"title"}},
+		{"body", func(doc *document) { doc.HTML = "<p>Run the setup.</p>" }, []string{"body"}},
+		{"tag removed", func(doc *document) { doc.Tags = doc.Tags[:1] }, []string{"tags"}},
+		{"tag renamed", func(doc *document) { doc.Tags = []string{"setup", "linux"} }, []string{"tags"}},
+		{"status", func(doc *document) { doc.Status = freshdesk.ArticleStatusDraft }, []string{"status"}},
+		{"everything", func(doc *document) {
+			*doc = document{Title: "Other", HTML: "<p>Other</p>", Status: freshdesk.ArticleStatusDraft}
+		}, []string{"title", "body", "tags", "status"}},
+	}
+	for _, tc := range cases {
+		changed := doc
+		changed.Tags = append([]string(nil), doc.Tags...)
+		tc.change(&changed)
+		if got := diffArticle(article, &changed); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	client := kbClient(
+		freshdesk.SolutionArticle{ID: 100, FolderID: 10, Title: "Install", Tags: []string{"old"}, Status: freshdesk.ArticleStatusPublished},
+		freshdesk.SolutionArticle{ID: 101, FolderID: 10, Title: "Retired", Status: freshdesk.ArticleStatusPublished},
+	)
+	faq := guide(0, "General", "Questions")
+	faq.Category = "FAQ"
+	faq.Status = freshdesk.ArticleStatusDraft
+
+	plan, err := buildPlan(client, []document{guide(100, "Setup", "Install"), guide(0, "Setup", "Configure"), faq}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(client); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls := client.CallsTo("CreateSolutionCategory"); len(calls) != 1 ||
+		calls[0].Args[0].(freshdesk.SolutionCategoryCreatePayload).Name != "FAQ" {
+		t.Errorf("CreateSolutionCategory calls = %+v", calls)
+	}
+	if calls := client.CallsTo("CreateSolutionFolder"); len(calls) != 1 || calls[0].Args[0] != uint64(2) ||
+		calls[0].Args[1].(freshdesk.SolutionFolderCreatePayload).Name != "General" {
+		t.Errorf("CreateSolutionFolder calls = %+v", calls)
+	}
+
+	created := client.CallsTo("CreateSolutionArticle")
+	if len(created) != 2 {
+		t.Fatalf("CreateSolutionArticle calls = %+v", created)
+	}
+	if payload := created[0].Args[1].(freshdesk.SolutionArticleCreatePayload); created[0].Args[0] != uint64(10) || payload.Title != "Configure" {
+		t.Errorf("created %+v", created[0])
+	}
+	if payload := created[1].Args[1].(freshdesk.SolutionArticleCreatePayload); created[1].Args[0] != uint64(11) ||
+		payload.Title != "Questions" || payload.Status != freshdesk.ArticleStatusDraft || payload.Description != "<p>Questions</p>\n" {
+		t.Errorf("created %+v", created[1])
+	}
+
+	updated := client.CallsTo("UpdateSolutionArticle")
+	if len(updated) != 2 {
+		t.Fatalf("UpdateSolutionArticle calls = %+v", updated)
+	}
+	if payload := updated[0].Args[1].(freshdesk.SolutionArticleUpdatePayload); updated[0].Args[0] != uint64(100) ||
+		payload.Title != "Install" || payload.Tags == nil || len(*payload.Tags) != 0 {
+		t.Errorf("updated %+v", updated[0])
+	}
+	// Archiving only moves the article back to draft.
+	if payload := updated[1].Args[1].(freshdesk.SolutionArticleUpdatePayload); updated[1].Args[0] != uint64(101) ||
+		!reflect.DeepEqual(payload, freshdesk.SolutionArticleUpdatePayload{Status: freshdesk.ArticleStatusDraft}) {
+		t.Errorf("archived %+v", updated[1])
+	}
+	if count := client.CallCount("DeleteSolutionArticle"); count != 0 {
+		t.Errorf("deleted %d articles", count)
+	}
+}
+
+func TestPlanApplyStops(t *testing.T) {
+	client := kbClient()
+	client.CreateSolutionArticleFunc = func(folderID uint64, payload freshdesk.SolutionArticleCreatePayload) (*freshdesk.SolutionArticle, error) {
+		return nil, errors.New("validation failed")
+	}
+
+	plan, err := buildPlan(client, []document{guide(0, "Setup", "Install"), guide(0, "Setup", "Configure")}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(client); err == nil || err.Error() != "install.md: validation failed" {
+		t.Errorf("got error %v", err)
+	}
+	if count := client.CallCount("CreateSolutionArticle"); count != 1 {
+		t.Errorf("created %d articles after the failure", count)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// item is a resource as written in the configuration file or as returned
+// by the API, keyed by the API attribute names.
+type item map[string]interface{}
+
+// config holds the managed sections of the configuration file.
+type config map[string][]item
+
+func loadConfig(path string) (config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is a subset of YAML, one decoder handles both.
+	var raw map[string][]item
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	result := config{}
+	for section, items := range raw {
+		kind := kindBySection(section)
+		if kind == nil {
+			return nil, fmt.Errorf("%s: unknown section %q", path, section)
+		}
+
+		allowed := allowedFields(kind)
+		seen := map[string]bool{}
+		for i, spec := range items {
+			for field := range spec {
+				if !allowed[field] {
+					return nil, fmt.Errorf("%s: %s[%d]: unknown attribute %q", path, section, i, field)
+				}
+			}
+			key := kind.Key(spec)
+			if key == "" {
+				return nil, fmt.Errorf("%s: %s[%d]: missing %s", path, section, i, kind.KeyDescription)
+			}
+			if seen[key] {
+				return nil, fmt.Errorf("%s: %s: %q is declared twice", path, section, key)
+			}
+			seen[key] = true
+		}
+		result[section] = items
+	}
+	return result, nil
+}
+
+// allowedFields returns the attributes an entry of the kind may set: the
+// fields of its create payload plus the kind's own extras.
+func allowedFields(kind *resourceKind) map[string]bool {
+	allowed := map[string]bool{}
+	t := reflect.TypeOf(kind.Payload)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			allowed[name] = true
+		}
+	}
+	for _, name := range kind.ExtraFields {
+		allowed[name] = true
+	}
+	for _, name := range kind.HiddenFields {
+		delete(allowed, name)
+	}
+	return allowed
+}
@@ -0,0 +1,94 @@
+// Command fdconfig manages helpdesk configuration declaratively.
+//
+// The configuration file (YAML or JSON) lists the desired groups, ticket
+// fields, products, canned responses and SLA policies:
+//
+//	groups:
+//	  - name: Billing
+//	    description: Invoices and refunds
+//	    agent_ids: [43000012345]
+//	canned_responses:
+//	  - folder: General
+//	    title: Greeting
+//	    content_html: <p>Hello!</p>
+//
+// "fdconfig plan" compares the file with the helpdesk and prints the
+// creates, updates and deletes needed to reconcile them. "fdconfig apply"
+// prints the same plan and performs it after confirmation. Only sections
+// present in the file are managed; within a managed section, resources
+// missing from the file are deleted when the API allows it. Attributes
+// left out of an entry are not compared.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "plan" && os.Args[1] != "apply") {
+		fmt.Fprintln(os.Stderr, "usage: fdconfig plan|apply -f config.yaml [flags]")
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet("fdconfig "+command, flag.ExitOnError)
+	var (
+		file        = flags.String("f", "helpdesk.yaml", "configuration file")
+		baseUrl     = flags.String("url", os.Getenv("FRESHDESK_URL"), "helpdesk URL, e.g. https://domain.freshdesk.com")
+		user        = flags.String("user", os.Getenv("FRESHDESK_API_KEY"), "API key or user name")
+		password    = flags.String("password", envOr("FRESHDESK_PASSWORD", "X"), "password, X when authenticating with an API key")
+		rpm         = flags.Int("rpm", 50, "maximum requests per minute")
+		autoApprove = flags.Bool("auto-approve", false, "apply without asking for confirmation")
+	)
+	flags.Parse(os.Args[2:])
+
+	if *baseUrl == "" || *user == "" {
+		fmt.Fprintln(os.Stderr, "fdconfig: -url and -user (or FRESHDESK_URL and FRESHDESK_API_KEY) are required")
+		os.Exit(2)
+	}
+
+	config, err := loadConfig(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := freshdesk.NewClient(*baseUrl, *user, *password, *rpm)
+	plan, err := buildPlan(client, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	plan.Print(os.Stdout)
+	if command == "plan" || plan.Empty() {
+		return
+	}
+
+	if !*autoApprove && !confirm() {
+		fmt.Println("Apply cancelled.")
+		return
+	}
+	if err := plan.Apply(client); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Apply complete.")
+}
+
+func confirm() bool {
+	fmt.Print("\nDo you want to perform these actions? Only 'yes' will be accepted: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+type changeKind int
+
+const (
+	create changeKind = iota
+	update
+	remove
+)
+
+type attributeChange struct {
+	Name     string
+	Old, New interface{}
+}
+
+type change struct {
+	Kind       changeKind
+	Resource   *resourceKind
+	Key        string
+	ID         uint64
+	Spec       item
+	Attributes []attributeChange
+}
+
+type plan struct {
+	Changes []change
+}
+
+func buildPlan(client freshdesk.Client, desired config) (*plan, error) {
+	result := &plan{}
+	for _, kind := range kinds {
+		specs, managed := desired[kind.Section]
+		if !managed {
+			continue
+		}
+
+		current, err := kind.List(client)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", kind.Section, err)
+		}
+		// Resources sharing a key cannot be told apart, none of them could
+		// be updated or deleted reliably.
+		existing := map[string]item{}
+		for _, resource := range current {
+			key := kind.Key(resource)
+			if other, ok := existing[key]; ok {
+				return nil, fmt.Errorf("%s: ids %d and %d share the %s %q", kind.Section, itemID(other), itemID(resource), kind.KeyDescription, key)
+			}
+			existing[key] = resource
+		}
+
+		for _, spec := range specs {
+			key := kind.Key(spec)
+			resource, ok := existing[key]
+			if !ok {
+				result.Changes = append(result.Changes, change{Kind: create, Resource: kind, Key: key, Spec: spec, Attributes: attributes(spec, nil)})
+				continue
+			}
+			delete(existing, key)
+			if diff := attributes(spec, resource); len(diff) > 0 {
+				result.Changes = append(result.Changes, change{Kind: update, Resource: kind, Key: key, ID: itemID(resource), Spec: spec, Attributes: diff})
+			}
+		}
+
+		if kind.Delete == nil {
+			continue
+		}
+		var leftovers []string
+		for key, resource := range existing {
+			if kind.Protected == nil || !kind.Protected(resource) {
+				leftovers = append(leftovers, key)
+			}
+		}
+		sort.Strings(leftovers)
+		for _, key := range leftovers {
+			result.Changes = append(result.Changes, change{Kind: remove, Resource: kind, Key: key, ID: itemID(existing[key])})
+		}
+	}
+	return result, nil
+}
+
+// attributes lists the attributes of spec that differ from current, or
+// all of them when current is nil.
+func attributes(spec item, current item) []attributeChange {
+	var names []string
+	for name := range spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diff []attributeChange
+	for _, name := range names {
+		desired := normalize(spec[name])
+		if current == nil {
+			diff = append(diff, attributeChange{Name: name, New: desired})
+			continue
+		}
+		actual := normalize(current[name])
+		if !reflect.DeepEqual(desired, actual) {
+			diff = append(diff, attributeChange{Name: name, Old: actual, New: desired})
+		}
+	}
+	return diff
+}
+
+// normalize gives YAML and API values the same Go representation.
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func (p *plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *plan) Print(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "No changes. The helpdesk matches the configuration.")
+		return
+	}
+
+	counts := map[changeKind]int{}
+	fmt.Fprintln(w, "fdconfig will perform the following actions:")
+	fmt.Fprintln(w)
+	for _, c := range p.Changes {
+		counts[c.Kind]++
+		switch c.Kind {
+		case create:
+			fmt.Fprintf(w, "  + %s %q\n", c.Resource.Label, c.Key)
+			for _, attribute := range c.Attributes {
+				fmt.Fprintf(w, "      %s: %s\n", attribute.Name, compact(attribute.New))
+			}
+		case update:
+			fmt.Fprintf(w, "  ~ %s %q (id %d)\n", c.Resource.Label, c.Key, c.ID)
+			for _, attribute := range c.Attributes {
+				fmt.Fprintf(w, "      %s: %s => %s\n", attribute.Name, compact(attribute.Old), compact(attribute.New))
+			}
+		case remove:
+			fmt.Fprintf(w, "  - %s %q (id %d)\n", c.Resource.Label, c.Key, c.ID)
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to add, %d to change, %d to destroy.\n", counts[create], counts[update], counts[remove])
+}
+
+func compact(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.ReplaceAll(string(data), "\n", " ")
+}
+
+// Apply performs the changes in plan order and stops at the first error.
+func (p *plan) Apply(client freshdesk.Client) error {
+	for _, c := range p.Changes {
+		var err error
+		switch c.Kind {
+		case create:
+			err = c.Resource.Create(client, c.Spec)
+		case update:
+			err = c.Resource.Update(client, c.ID, c.Spec)
+		case remove:
+			err = c.Resource.Delete(client, c.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", c.Resource.Label, c.Key, err)
+		}
+	}
+	return nil
+}
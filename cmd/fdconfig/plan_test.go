@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/freshdeskmock"
+)
+
+func groupsClient(groups ...freshdesk.Group) *freshdeskmock.Client {
+	client := &freshdeskmock.Client{}
+	client.GetAllGroupsFunc = func() ([]freshdesk.Group, error) { return groups, nil }
+	return client
+}
+
+func TestBuildPlan(t *testing.T) {
+	client := groupsClient(
+		freshdesk.Group{ID: 1, Name: "Billing", Description: "Invoices"},
+		freshdesk.Group{ID: 2, Name: "Sales", Description: "Leads"},
+		freshdesk.Group{ID: 3, Name: "Legacy"},
+	)
+	desired := config{"groups": {
+		{"name": "Billing", "description": "Invoices and refunds"},
+		{"name": "Sales"},
+		{"name": "Support", "agent_ids": []interface{}{43}},
+	}}
+
+	plan, err := buildPlan(client, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 3 {
+		t.Fatalf("got %d changes: %+v", len(plan.Changes), plan.Changes)
+	}
+
+	updated, created, removed := plan.Changes[0], plan.Changes[1], plan.Changes[2]
+	if updated.Kind != update || updated.ID != 1 || len(updated.Attributes) != 1 ||
+		updated.Attributes[0].Old != "Invoices" || updated.Attributes[0].New != "Invoices and refunds" {
+		t.Errorf("update = %+v", updated)
+	}
+	if created.Kind != create || created.Key != "Support" || len(created.Attributes) != 2 {
+		t.Errorf("create = %+v", created)
+	}
+	if removed.Kind != remove || removed.ID != 3 || removed.Key != "Legacy" {
+		t.Errorf("remove = %+v", removed)
+	}
+	if calls := client.CallsTo("ListProducts"); len(calls) != 0 {
+		t.Error("unmanaged section listed")
+	}
+
+	var out bytes.Buffer
+	plan.Print(&out)
+	for _, want := range []string{
+		`~ group "Billing" (id 1)`,
+		`description: "Invoices" => "Invoices and refunds"`,
+		`+ group "Support"`,
+		`- group "Legacy" (id 3)`,
+		"Plan: 1 to add, 1 to change, 1 to destroy.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("plan output misses %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestBuildPlanUnchanged(t *testing.T) {
+	client := groupsClient(freshdesk.Group{ID: 1, Name: "Billing", Agents: []uint64{43}})
+	plan, err := buildPlan(client, config{"groups": {{"name": "Billing", "agent_ids": []interface{}{43}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("got changes %+v", plan.Changes)
+	}
+}
+
+func TestBuildPlanProtected(t *testing.T) {
+	client := &freshdeskmock.Client{}
+	client.ListTicketFieldsFunc = func() ([]freshdesk.TicketField, error) {
+		return []freshdesk.TicketField{
+			{ID: 10, Label: "Subject", Default: true},
+			{ID: 11, Label: "Order number"},
+		}, nil
+	}
+	client.ListProductsFunc = func() ([]freshdesk.Product, error) {
+		return []freshdesk.Product{{ID: 20, Name: "Widgets"}}, nil
+	}
+
+	plan, err := buildPlan(client, config{"ticket_fields": {}, "products": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The default field is protected and products cannot be deleted.
+	if len(plan.Changes) != 1 || plan.Changes[0].Kind != remove || plan.Changes[0].ID != 11 {
+		t.Errorf("got changes %+v", plan.Changes)
+	}
+}
+
+func TestBuildPlanDuplicateKeys(t *testing.T) {
+	client := groupsClient(
+		freshdesk.Group{ID: 1, Name: "Billing"},
+		freshdesk.Group{ID: 2, Name: "Billing"},
+	)
+	_, err := buildPlan(client, config{"groups": {{"name": "Billing"}}})
+	if err == nil || !strings.Contains(err.Error(), `ids 1 and 2 share the name "Billing"`) {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	client := groupsClient(
+		freshdesk.Group{ID: 1, Name: "Billing", Description: "Invoices"},
+		freshdesk.Group{ID: 3, Name: "Legacy"},
+	)
+	plan, err := buildPlan(client, config{"groups": {
+		{"name": "Billing", "description": "Invoices and refunds"},
+		{"name": "Support"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(client); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls := client.CallsTo("UpdateGroup"); len(calls) != 1 || calls[0].Args[0] != uint64(1) ||
+		calls[0].Args[1].(freshdesk.GroupUpdatePayload).Description != "Invoices and refunds" {
+		t.Errorf("UpdateGroup calls = %+v", calls)
+	}
+	if calls := client.CallsTo("CreateGroup"); len(calls) != 1 || calls[0].Args[0].(freshdesk.GroupCreatePayload).Name != "Support" {
+		t.Errorf("CreateGroup calls = %+v", calls)
+	}
+	if calls := client.CallsTo("DeleteGroup"); len(calls) != 1 || calls[0].Args[0] != uint64(3) {
+		t.Errorf("DeleteGroup calls = %+v", calls)
+	}
+}
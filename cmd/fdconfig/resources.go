@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// resourceKind describes how one configuration section maps onto the API.
+// Supporting a new admin endpoint means adding an entry to kinds.
+type resourceKind struct {
+	Section        string
+	Label          string
+	KeyDescription string
+	Key            func(spec item) string
+
+	// Payload is the zero create payload, its json tags name the
+	// attributes an entry may set.
+	Payload      interface{}
+	ExtraFields  []string
+	HiddenFields []string
+
+	List   func(client freshdesk.Client) ([]item, error)
+	Create func(client freshdesk.Client, spec item) error
+	Update func(client freshdesk.Client, ID uint64, spec item) error
+	Delete func(client freshdesk.Client, ID uint64) error // nil when the API cannot delete
+
+	// Protected reports resources that must never be deleted.
+	Protected func(current item) bool
+}
+
+var kinds = []*resourceKind{
+	{
+		Section:        "groups",
+		Label:          "group",
+		KeyDescription: "name",
+		Key:            stringField("name"),
+		Payload:        freshdesk.GroupCreatePayload{},
+		List: func(client freshdesk.Client) ([]item, error) {
+			groups, err := client.GetAllGroups()
+			if err != nil {
+				return nil, err
+			}
+			return toItems(groups)
+		},
+		Create: func(client freshdesk.Client, spec item) error {
+			var payload freshdesk.GroupCreatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.CreateGroup(payload)
+			return err
+		},
+		Update: func(client freshdesk.Client, ID uint64, spec item) error {
+			var payload freshdesk.GroupUpdatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.UpdateGroup(ID, payload)
+			return err
+		},
+		Delete: func(client freshdesk.Client, ID uint64) error {
+			_, err := client.DeleteGroup(ID)
+			return err
+		},
+	},
+	{
+		Section:        "ticket_fields",
+		Label:          "ticket field",
+		KeyDescription: "label",
+		Key:            stringField("label"),
+		Payload:        freshdesk.TicketFieldCreatePayload{},
+		List: func(client freshdesk.Client) ([]item, error) {
+			fields, err := client.ListTicketFields()
+			if err != nil {
+				return nil, err
+			}
+			return toItems(fields)
+		},
+		Create: func(client freshdesk.Client, spec item) error {
+			var payload freshdesk.TicketFieldCreatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.CreateTicketField(payload)
+			return err
+		},
+		Update: func(client freshdesk.Client, ID uint64, spec item) error {
+			var payload freshdesk.TicketFieldUpdatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.UpdateTicketField(ID, payload)
+			return err
+		},
+		Delete: func(client freshdesk.Client, ID uint64) error {
+			_, err := client.DeleteTicketField(ID)
+			return err
+		},
+		Protected: func(current item) bool {
+			isDefault, _ := current["default"].(bool)
+			return isDefault
+		},
+	},
+	{
+		Section:        "products",
+		Label:          "product",
+		KeyDescription: "name",
+		Key:            stringField("name"),
+		Payload:        freshdesk.ProductCreatePayload{},
+		List: func(client freshdesk.Client) ([]item, error) {
+			products, err := client.ListProducts()
+			if err != nil {
+				return nil, err
+			}
+			return toItems(products)
+		},
+		Create: func(client freshdesk.Client, spec item) error {
+			var payload freshdesk.ProductCreatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.CreateProduct(payload)
+			return err
+		},
+		Update: func(client freshdesk.Client, ID uint64, spec item) error {
+			var payload freshdesk.ProductUpdatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.UpdateProduct(ID, payload)
+			return err
+		},
+	},
+	{
+		Section:        "canned_responses",
+		Label:          "canned response",
+		KeyDescription: "folder and title",
+		Key: func(spec item) string {
+			folder, title := stringField("folder")(spec), stringField("title")(spec)
+			if folder == "" || title == "" {
+				return ""
+			}
+			return folder + "/" + title
+		},
+		Payload:      freshdesk.CannedResponseCreatePayload{},
+		ExtraFields:  []string{"folder"},
+		HiddenFields: []string{"folder_id"},
+		List: func(client freshdesk.Client) ([]item, error) {
+			folders, err := client.ListCannedResponseFolders()
+			if err != nil {
+				return nil, err
+			}
+			var all []item
+			for _, folder := range folders {
+				responses, err := client.ListCannedResponses(folder.ID)
+				if err != nil {
+					return nil, err
+				}
+				items, err := toItems(responses)
+				if err != nil {
+					return nil, err
+				}
+				for _, response := range items {
+					response["folder"] = folder.Name
+				}
+				all = append(all, items...)
+			}
+			return all, nil
+		},
+		Create: func(client freshdesk.Client, spec item) error {
+			var payload freshdesk.CannedResponseCreatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			folderID, err := cannedResponseFolder(client, stringField("folder")(spec))
+			if err != nil {
+				return err
+			}
+			payload.FolderID = folderID
+			_, err = client.CreateCannedResponse(payload)
+			return err
+		},
+		Update: func(client freshdesk.Client, ID uint64, spec item) error {
+			var payload freshdesk.CannedResponseUpdatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			folderID, err := cannedResponseFolder(client, stringField("folder")(spec))
+			if err != nil {
+				return err
+			}
+			payload.FolderID = folderID
+			_, err = client.UpdateCannedResponse(ID, payload)
+			return err
+		},
+	},
+	{
+		Section:        "sla_policies",
+		Label:          "SLA policy",
+		KeyDescription: "name",
+		Key:            stringField("name"),
+		Payload:        freshdesk.SLAPolicyCreatePayload{},
+		List: func(client freshdesk.Client) ([]item, error) {
+			policies, err := client.ListSLAPolicies()
+			if err != nil {
+				return nil, err
+			}
+			return toItems(policies)
+		},
+		Create: func(client freshdesk.Client, spec item) error {
+			var payload freshdesk.SLAPolicyCreatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.CreateSLAPolicy(payload)
+			return err
+		},
+		Update: func(client freshdesk.Client, ID uint64, spec item) error {
+			var payload freshdesk.SLAPolicyUpdatePayload
+			if err := convert(spec, &payload); err != nil {
+				return err
+			}
+			_, err := client.UpdateSLAPolicy(ID, payload)
+			return err
+		},
+	},
+}
+
+func kindBySection(section string) *resourceKind {
+	for _, kind := range kinds {
+		if kind.Section == section {
+			return kind
+		}
+	}
+	return nil
+}
+
+func stringField(name string) func(spec item) string {
+	return func(spec item) string {
+		value, _ := spec[name].(string)
+		return value
+	}
+}
+
+// cannedResponseFolder returns the id of the named folder, creating it
+// when it does not exist yet.
+func cannedResponseFolder(client freshdesk.Client, name string) (uint64, error) {
+	folders, err := client.ListCannedResponseFolders()
+	if err != nil {
+		return 0, err
+	}
+	for _, folder := range folders {
+		if folder.Name == name {
+			return folder.ID, nil
+		}
+	}
+	folder, err := client.CreateCannedResponseFolder(freshdesk.CannedResponseFolderCreatePayload{Name: name})
+	if err != nil {
+		return 0, fmt.Errorf("creating canned response folder %q: %w", name, err)
+	}
+	return folder.ID, nil
+}
+
+// convert copies v into out through their JSON representation.
+func convert(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func toItems(v interface{}) ([]item, error) {
+	var items []item
+	err := convert(v, &items)
+	return items, err
+}
+
+func itemID(current item) uint64 {
+	id, _ := current["id"].(float64)
+	return uint64(id)
+}
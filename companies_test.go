@@ -0,0 +1,306 @@
+package freshdesk
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetCompanyWithContactCount(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/7", 200, `{"id":7,"name":"Acme"}`)
+	stub.on("GET", "/api/v2/contacts",
+		stubResponse{Status: 200, Body: `[{"id":1},{"id":2}]`, Header: stub.link("/api/v2/contacts?company_id=7&per_page=100&page=2")},
+		stubResponse{Status: 200, Body: `[{"id":3}]`},
+	)
+
+	company, err := stub.client().GetCompanyWithContactCount(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.ContactCount == nil || *company.ContactCount != 3 {
+		t.Errorf("contact count %v", company.ContactCount)
+	}
+	if got := stub.received()[1].Query.Get("company_id"); got != "7" {
+		t.Errorf("company_id = %q", got)
+	}
+}
+
+func TestGetCompanyWithoutContactCount(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/7", 200, `{"id":7}`)
+
+	company, err := stub.client().GetCompany(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.ContactCount != nil || len(stub.received()) != 1 {
+		t.Errorf("contacts counted for GetCompany")
+	}
+}
+
+func TestGetCompanyWithContactCountFails(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/7", 200, `{"id":7}`)
+	stub.reply("GET", "/api/v2/contacts", 500, `{"message":"boom"}`)
+
+	if company, err := stub.client().GetCompanyWithContactCount(7); err == nil || company != nil {
+		t.Errorf("got %v, %v", company, err)
+	}
+}
+
+func TestGetCompanyByName(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[{"id":5,"name":"Acme Labs"},{"id":7,"name":"ACME"}]}`)
+	stub.reply("GET", "/api/v2/companies/7", 200, `{"id":7,"name":"ACME"}`)
+
+	company, err := stub.client().GetCompanyByName("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.ID != 7 {
+		t.Errorf("got company %d", company.ID)
+	}
+	if got := stub.received()[0].Query.Get("name"); got != "acme" {
+		t.Errorf("name = %q", got)
+	}
+}
+
+func TestGetCompanyByNameNotFound(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[{"id":5,"name":"Acme Labs"}]}`)
+
+	_, err := stub.client().GetCompanyByName("Acme")
+	if err == nil || err.Error() != ERR_COMPANY_NOT_FOUND {
+		t.Errorf("got %v", err)
+	}
+	if len(stub.received()) != 1 {
+		t.Errorf("sent %d requests", len(stub.received()))
+	}
+}
+
+func TestGetCompanyByNameSearchFails(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/autocomplete", 400, invalid)
+
+	if _, err := stub.client().GetCompanyByName("Acme"); err == nil || err.Error() == ERR_COMPANY_NOT_FOUND {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestGetCompanyByDomainQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/search/companies", 200, `{"total":1,"results":[{"id":7,"domains":["acme.com"]}]}`)
+
+	company, err := stub.client().GetCompanyByDomain("ACME.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.ID != 7 {
+		t.Errorf("got company %d", company.ID)
+	}
+	if got := stub.received()[0].Query.Get("query"); got != `"domain:'acme.com'"` {
+		t.Errorf("query = %q", got)
+	}
+}
+
+func TestGetCompanyByDomainNotFound(t *testing.T) {
+	for _, body := range []string{`{"total":0,"results":[]}`, `{"total":1,"results":[]}`} {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/companies", 200, body)
+
+		if _, err := stub.client().GetCompanyByDomain("acme.com"); err == nil || err.Error() != ERR_COMPANY_NOT_FOUND {
+			t.Errorf("%s: got %v", body, err)
+		}
+	}
+}
+
+func TestGetAllCompaniesPages(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/companies",
+		stubResponse{Status: 200, Body: `[{"id":1},{"id":2}]`, Header: stub.link("/api/v2/companies?per_page=100&page=2")},
+		stubResponse{Status: 200, Body: `[{"id":3}]`},
+	)
+
+	companies, err := stub.client().GetAllCompanies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies) != 3 || companies[2].ID != 3 {
+		t.Errorf("got %+v", companies)
+	}
+	if requests := stub.received(); requests[0].Query.Get("per_page") != "100" || requests[1].Query.Get("page") != "2" {
+		t.Errorf("requests %+v", requests)
+	}
+}
+
+func TestListCompaniesFiltersUpdatedSince(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies", 200, `[
+		{"id":1,"updated_at":"2023-04-30T23:59:59Z"},
+		{"id":2,"updated_at":"2023-05-01T00:00:00Z"},
+		{"id":3},
+		{"id":4,"updated_at":"2023-06-01T00:00:00Z"}
+	]`)
+	since := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	companies, err, _ := stub.client().ListCompanies(&since, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []uint64
+	for _, company := range companies {
+		ids = append(ids, company.ID)
+	}
+	if len(ids) != 3 || ids[0] != 2 || ids[1] != 3 || ids[2] != 4 {
+		t.Errorf("got companies %v, want [2 3 4]", ids)
+	}
+	if stub.received()[0].Query.Has("updated_since") {
+		t.Error("updated_since sent, the endpoint does not take it")
+	}
+}
+
+func TestListCompaniesWithoutSince(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/companies", stubResponse{Status: 200, Body: `[{"id":1,"updated_at":"2001-01-01T00:00:00Z"}]`, Header: stub.link("/api/v2/companies?page=2")})
+
+	companies, err, hasMore := stub.client().ListCompanies(nil, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies) != 1 || !hasMore {
+		t.Errorf("got %d companies, hasMore %v", len(companies), hasMore)
+	}
+}
+
+func TestSearchCompaniesMask(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[{"id":7,"name":"Acme"}]}`)
+
+	names, err := stub.client().SearchCompanies("Ac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0].Name != "Acme" {
+		t.Errorf("got %+v", names)
+	}
+	if got := stub.received()[0].Query.Get("name"); got != "Ac" {
+		t.Errorf("name = %q", got)
+	}
+}
+
+func TestCompanyIndexStart(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/companies", 200, `[{"id":7,"name":"Acme","domains":["Acme.com"]}]`)
+
+	index := NewCompanyIndex(stub.client(), 0)
+	if err := index.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Start(); err == nil || err.Error() != ERR_ALREADY_STARTED {
+		t.Errorf("second Start = %v", err)
+	}
+	if company, err := index.Resolve("jane@mail.acme.com"); err != nil || company.ID != 7 {
+		t.Errorf("Resolve = %v, %v", company, err)
+	}
+	index.Stop()
+
+	index = NewCompanyIndex(stub.client(), time.Millisecond)
+	if err := index.Start(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(stub.received()) < 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	index.Stop()
+	if len(stub.received()) < 4 {
+		t.Errorf("index refreshed %d times", len(stub.received())-1)
+	}
+	if err := index.Start(); err != nil {
+		t.Errorf("restart = %v", err)
+	}
+	index.Stop()
+}
+
+func TestCompanyIndexResolve(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/companies", stubResponse{Status: 200, Body: `[
+		{"id":1,"name":"Example","domains":["Example.com."]},
+		{"id":2,"name":"Example Europe","domains":["eu.example.com","EU.example.com"]},
+		{"id":3,"name":"Shared One","domains":["shared.org"]},
+		{"id":4,"name":"Shared Two","domains":["shared.org","two.org"]},
+		{"id":5,"name":"Sub Conflict","domains":["us.example.com"]},
+		{"id":6,"name":"Sub Conflict Too","domains":["US.Example.com"]}
+	]`}, stubResponse{Status: 500, Body: `{}`})
+
+	index := NewCompanyIndex(stub.client(), 0)
+	if err := index.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		address string
+		want    uint64
+		err     string
+	}{
+		{"jane@example.com", 1, ""},
+		{"Jane Doe <jane@sub.example.com>", 1, ""},
+		{"Jane Doe <JANE@Mail.EU.Example.COM.>", 2, ""},
+		{"eu.example.com", 2, ""},
+		{"  EXAMPLE.com.  ", 1, ""},
+		{"john@two.org", 4, ""},
+		{"john@shared.org", 0, ERR_DOMAIN_CONFLICT},
+		{"john@mail.us.example.com", 0, ERR_DOMAIN_CONFLICT},
+		{"john@example.net", 0, ERR_COMPANY_NOT_FOUND},
+		{"com", 0, ERR_COMPANY_NOT_FOUND},
+		{"", 0, ERR_COMPANY_NOT_FOUND},
+	}
+	for _, tc := range cases {
+		company, err := index.Resolve(tc.address)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Resolve(%q) = %+v, %v, want error %q", tc.address, company, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || company.ID != tc.want {
+			t.Errorf("Resolve(%q) = %+v, %v, want company %d", tc.address, company, err, tc.want)
+		}
+	}
+
+	if companies := index.ResolveAll("john@shared.org"); len(companies) != 2 || companies[0].ID != 3 || companies[1].ID != 4 {
+		t.Errorf("ResolveAll = %+v", companies)
+	}
+	if got, want := index.ConflictingDomains(), []string{"shared.org", "us.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ConflictingDomains = %v, want %v", got, want)
+	}
+	if conflicts := index.Conflicts(); len(conflicts) != 2 || len(conflicts["us.example.com"]) != 2 {
+		t.Errorf("Conflicts = %+v", conflicts)
+	}
+
+	// A failed refresh keeps the index.
+	if err := index.Refresh(); err == nil {
+		t.Error("expected a refresh error")
+	}
+	if company, err := index.Resolve("jane@example.com"); err != nil || company.ID != 1 {
+		t.Errorf("Resolve after a failed refresh = %+v, %v", company, err)
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	cases := map[string]string{
+		"example.com":                     "example.com",
+		"Mail.Example.COM.":               "mail.example.com",
+		"jane@Example.com":                "example.com",
+		"Jane Doe <jane@sub.example.com>": "sub.example.com",
+		`"Doe, Jane" <jane@example.com.>`: "example.com",
+		" <jane@example.com> ":            "example.com",
+		"":                                "",
+	}
+	for value, want := range cases {
+		if got := normalizeDomain(value); got != want {
+			t.Errorf("normalizeDomain(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package freshdesk
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CompanyIndex maps e-mail domains to the companies that own them, so that
+// the CompanyID of a new ticket can be derived from the sender address.
+// The index is built from Company.Domains and refreshed periodically once
+// Start has been called.
+type CompanyIndex struct {
+	client   Client
+	interval time.Duration
+
+	mu        sync.RWMutex
+	domains   map[string][]Company
+	refreshed time.Time
+
+	runMu   sync.Mutex
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewCompanyIndex returns an empty index. A refreshInterval of zero or less
+// disables the background refresh, the index then only changes on Refresh.
+func NewCompanyIndex(client Client, refreshInterval time.Duration) *CompanyIndex {
+	return &CompanyIndex{
+		client:   client,
+		interval: refreshInterval,
+		domains:  map[string][]Company{},
+	}
+}
+
+// Refresh reloads all companies from the API and rebuilds the index. The
+// previous index is kept if the request fails.
+func (index *CompanyIndex) Refresh() error {
+	companies, err := index.client.GetAllCompanies()
+	if err != nil {
+		return err
+	}
+
+	domains := map[string][]Company{}
+	for _, company := range companies {
+		seen := map[string]bool{}
+		for _, domain := range company.Domains {
+			domain = normalizeDomain(domain)
+			if domain == "" || seen[domain] {
+				continue
+			}
+			seen[domain] = true
+			domains[domain] = append(domains[domain], company)
+		}
+	}
+
+	index.mu.Lock()
+	index.domains = domains
+	index.refreshed = time.Now()
+	index.mu.Unlock()
+	return nil
+}
+
+// Start loads the index and keeps refreshing it in the background until
+// Stop is called. Background refresh failures are logged. It fails when the
+// index is already started.
+func (index *CompanyIndex) Start() error {
+	index.runMu.Lock()
+	defer index.runMu.Unlock()
+	if index.started {
+		return errors.New(ERR_ALREADY_STARTED)
+	}
+	if err := index.Refresh(); err != nil {
+		return err
+	}
+	index.started = true
+	if index.interval <= 0 {
+		return nil
+	}
+
+	index.stop = make(chan struct{})
+	index.done = make(chan struct{})
+	go func() {
+		defer close(index.done)
+		ticker := time.NewTicker(index.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := index.Refresh(); err != nil {
+					log.Println(err)
+				}
+			case <-index.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (index *CompanyIndex) Stop() {
+	index.runMu.Lock()
+	defer index.runMu.Unlock()
+	index.started = false
+	if index.stop == nil {
+		return
+	}
+	close(index.stop)
+	<-index.done
+	index.stop = nil
+}
+
+// RefreshedAt returns the time of the last successful refresh.
+func (index *CompanyIndex) RefreshedAt() time.Time {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return index.refreshed
+}
+
+// Resolve returns the company owning an e-mail address or domain. A
+// subdomain resolves to the company of its closest registered parent, so
+// "mail.eu.example.com" matches a company with the domain "example.com"
+// unless another company registered "eu.example.com".
+func (index *CompanyIndex) Resolve(emailOrDomain string) (*Company, error) {
+	companies := index.ResolveAll(emailOrDomain)
+	switch len(companies) {
+	case 0:
+		return nil, errors.New(ERR_COMPANY_NOT_FOUND)
+	case 1:
+		return &companies[0], nil
+	default:
+		return nil, errors.New(ERR_DOMAIN_CONFLICT)
+	}
+}
+
+// ResolveAll returns every company registered for the closest matching
+// domain, more than one when the domain is in conflict.
+func (index *CompanyIndex) ResolveAll(emailOrDomain string) []Company {
+	domain := normalizeDomain(emailOrDomain)
+
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	for domain != "" {
+		if companies, ok := index.domains[domain]; ok {
+			return append([]Company(nil), companies...)
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return nil
+}
+
+// Conflicts returns the domains registered for more than one company.
+func (index *CompanyIndex) Conflicts() map[string][]Company {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	conflicts := map[string][]Company{}
+	for domain, companies := range index.domains {
+		if len(companies) > 1 {
+			conflicts[domain] = append([]Company(nil), companies...)
+		}
+	}
+	return conflicts
+}
+
+// ConflictingDomains returns the domains of Conflicts in sorted order.
+func (index *CompanyIndex) ConflictingDomains() []string {
+	var domains []string
+	for domain := range index.Conflicts() {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// normalizeDomain reduces "John <john@Mail.Example.com.>" or
+// "mail.example.com" to "mail.example.com".
+func normalizeDomain(value string) string {
+	value = strings.TrimSpace(value)
+	if open := strings.LastIndexByte(value, '<'); open >= 0 {
+		value = strings.TrimSuffix(value[open+1:], ">")
+	}
+	if at := strings.LastIndexByte(value, '@'); at >= 0 {
+		value = value[at+1:]
+	}
+	return strings.Trim(strings.ToLower(strings.TrimSpace(value)), ".")
+}
@@ -0,0 +1,240 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetAllContactsFollowsLink(t *testing.T) {
+	stub := newStubServer(t)
+	// The link points at the account domain, only its query is reused.
+	stub.on("GET", "/api/v2/contacts",
+		stubResponse{Status: 200, Body: `[{"id":1},{"id":2}]`, Header: http.Header{"Link": {`<https://example.freshdesk.com/api/v2/contacts?page=2>; rel="next"`}}},
+		stubResponse{Status: 200, Body: `[{"id":3}]`, Header: http.Header{"Link": {`<https://example.freshdesk.com/api/v2/contacts?page=3>; rel="next"`}}},
+		stubResponse{Status: 200, Body: `[{"id":4}]`},
+	)
+
+	contacts, err := stub.client().GetAllContacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 4 || contacts[3].ID != 4 {
+		t.Errorf("got %+v", contacts)
+	}
+
+	requests := stub.received()
+	if len(requests) != 3 {
+		t.Fatalf("sent %d requests", len(requests))
+	}
+	for i, want := range []string{"", "2", "3"} {
+		if got := requests[i].Query.Get("page"); got != want {
+			t.Errorf("request %d: page %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestGetAllContactsIgnoresForeignLink(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/contacts",
+		stubResponse{Status: 200, Body: `[{"id":1}]`, Header: http.Header{"Link": {`<https://example.freshdesk.com/api/v2/companies?page=2>; rel="next"`}}},
+	)
+
+	contacts, err := stub.client().GetAllContacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 1 || len(stub.received()) != 1 {
+		t.Errorf("got %d contacts in %d requests", len(contacts), len(stub.received()))
+	}
+}
+
+func TestGetAllContactsStopsOnError(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/contacts",
+		stubResponse{Status: 200, Body: `[{"id":1}]`, Header: http.Header{"Link": {`<https://example.freshdesk.com/api/v2/contacts?page=2>; rel="next"`}}},
+		stubResponse{Status: 500, Body: `{"message":"boom"}`},
+	)
+
+	if contacts, err := stub.client().GetAllContacts(); err == nil || contacts != nil {
+		t.Errorf("got %v, %v", contacts, err)
+	}
+}
+
+func TestFindContactByEmailQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/search/contacts", 200, `{"total":2,"results":[{"id":7,"email":"jane+fd@example.com"},{"id":8}]}`)
+
+	contact, err := stub.client().FindContactByEmail("jane+fd@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.ID != 7 {
+		t.Errorf("got contact %d, want the first result", contact.ID)
+	}
+	if got := stub.received()[0].Query.Get("query"); got != `"email:'jane+fd@example.com'"` {
+		t.Errorf("query = %q", got)
+	}
+}
+
+func TestFindContactByEmailNotFound(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/search/contacts", 200, `{"total":0,"results":[]}`)
+
+	_, err := stub.client().FindContactByEmail("nobody@example.com")
+	if err == nil || err.Error() != ERR_CONTACT_NOT_FOUND {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestFindContactByFilter(t *testing.T) {
+	cases := []struct {
+		filter string
+		find   func(service *freshDeskService, value string) (Contact, error)
+	}{
+		{"unique_external_id", (*freshDeskService).FindContactByExternalID},
+		{"phone", (*freshDeskService).FindContactByPhone},
+	}
+	for _, tc := range cases {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/contacts", 200, `[{"id":7}]`)
+		contact, err := tc.find(stub.client(), "+1 555 0100")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contact.ID != 7 {
+			t.Errorf("%s: got contact %d", tc.filter, contact.ID)
+		}
+		if got := stub.received()[0].Query.Get(tc.filter); got != "+1 555 0100" {
+			t.Errorf("%s = %q", tc.filter, got)
+		}
+
+		stub = newStubServer(t)
+		stub.reply("GET", "/api/v2/contacts", 200, `[]`)
+		if _, err := tc.find(stub.client(), "missing"); err == nil || err.Error() != ERR_CONTACT_NOT_FOUND {
+			t.Errorf("%s: got %v", tc.filter, err)
+		}
+	}
+}
+
+func TestListContactsQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/contacts", stubResponse{Status: 200, Body: `[{"id":1}]`, Header: stub.link("/api/v2/contacts?page=2")})
+	since := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	contacts, err, hasMore := stub.client().ListContacts(&since, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 1 || !hasMore {
+		t.Errorf("got %d contacts, hasMore %v", len(contacts), hasMore)
+	}
+	query := stub.received()[0].Query
+	if query.Get("_updated_since") != "2023-05-01T10:00:00Z" || query.Get("per_page") != "100" || query.Has("state") {
+		t.Errorf("query %v", query)
+	}
+}
+
+func TestListDeletedContactsQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/contacts", 200, `[{"id":1}]`)
+
+	_, err, hasMore := stub.client().ListDeletedContacts(nil, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasMore {
+		t.Error("hasMore set without a Link header")
+	}
+	query := stub.received()[0].Query
+	if query.Get("state") != "deleted" || query.Has("_updated_since") {
+		t.Errorf("query %v", query)
+	}
+}
+
+func TestPermanentlyDeleteContactForces(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("DELETE", "/api/v2/contacts/7/hard_delete", 204, "")
+
+	if _, err := stub.client().PermanentlyDeleteContact(7); err != nil {
+		t.Fatal(err)
+	}
+	if got := stub.received()[0].Query.Get("force"); got != "true" {
+		t.Errorf("force = %q", got)
+	}
+}
+
+func TestCreateContactConflict(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/contacts", 409, `{"description":"Validation failed","errors":[{"field":"email","message":"It should be a unique value","code":"duplicate_value"}]}`)
+
+	_, err := stub.client().CreateContact(ContactCreatePayload{Email: "jane@example.com"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var body map[string]interface{}
+	if json.Unmarshal([]byte(err.Error()), &body) != nil {
+		t.Errorf("error %q is not the response body", err)
+	}
+}
+
+func TestAddOtherCompanyForContact(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/contacts/7", 200, `{"id":7}`)
+	contact := &Contact{
+		ID:             7,
+		Name:           "Jane",
+		Email:          "jane@example.com",
+		CompanyID:      1,
+		OtherCompanies: []CompanyContactOther{{ID: 2, ViewAllTickets: true, Name: "Second"}},
+	}
+
+	ok, err := stub.client().AddOtherCompanyForContact(contact, 3, false)
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v", ok, err)
+	}
+
+	var sent ContactUpdatePayload
+	if err := json.Unmarshal(stub.received()[0].Body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.CompanyID != 1 || sent.Name != "Jane" {
+		t.Errorf("sent %+v", sent)
+	}
+	want := []CompanyContactOtherUpdatePayload{{ID: 2, ViewAllTickets: true}, {ID: 3}}
+	if len(sent.OtherCompanies) != 2 || sent.OtherCompanies[0] != want[0] || sent.OtherCompanies[1] != want[1] {
+		t.Errorf("other companies %+v, want %+v", sent.OtherCompanies, want)
+	}
+}
+
+func TestAddMainCompanyForContact(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/contacts/7", 200, `{"id":7}`)
+	contact := &Contact{
+		ID:             7,
+		CompanyID:      1,
+		OtherCompanies: []CompanyContactOther{{ID: 2}},
+	}
+
+	if ok, err := stub.client().AddMainCompanyForContact(contact, 3); err != nil || !ok {
+		t.Fatalf("got %v, %v", ok, err)
+	}
+
+	var sent ContactUpdatePayload
+	if err := json.Unmarshal(stub.received()[0].Body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.CompanyID != 3 || len(sent.OtherCompanies) != 1 || sent.OtherCompanies[0].ID != 2 {
+		t.Errorf("sent %+v", sent)
+	}
+}
+
+func TestAddCompanyForContactFails(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/contacts/7", 400, invalid)
+
+	if ok, err := stub.client().AddMainCompanyForContact(&Contact{ID: 7}, 3); ok || err == nil {
+		t.Errorf("got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,71 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Fields returns the custom fields of the company, nil when it has none.
+func (company *Company) Fields() CustomFields {
+	fields, _ := company.CustomFields.(map[string]interface{})
+	return fields
+}
+
+// String returns the value of a text, paragraph or dropdown field.
+func (fields CustomFields) String(name string) (string, bool) {
+	value, ok := fields[name].(string)
+	return value, ok
+}
+
+// Int returns the value of a number field. Freshdesk sends numbers as JSON
+// numbers, but values copied from other records may be strings.
+func (fields CustomFields) Int(name string) (int64, bool) {
+	switch value := fields[name].(type) {
+	case float64:
+		return int64(value), true
+	case json.Number:
+		i, err := value.Int64()
+		return i, err == nil
+	case string:
+		i, err := strconv.ParseInt(value, 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// Float returns the value of a decimal field.
+func (fields CustomFields) Float(name string) (float64, bool) {
+	switch value := fields[name].(type) {
+	case float64:
+		return value, true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(value, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Bool returns the value of a checkbox field.
+func (fields CustomFields) Bool(name string) (bool, bool) {
+	value, ok := fields[name].(bool)
+	return value, ok
+}
+
+// Time returns the value of a date field, sent either as a plain date or
+// as an RFC 3339 timestamp.
+func (fields CustomFields) Time(name string) (*time.Time, bool) {
+	value, ok := fields[name].(string)
+	if !ok {
+		return nil, false
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
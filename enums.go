@@ -0,0 +1,343 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Source, Status, Priority and MessageSource print as their lower-case name
+// and parse from it, case-insensitively, or from their number. Text
+// marshaling, used for map keys and text formats, goes by name. JSON
+// marshaling writes the number on purpose: the same types are fields of
+// the payloads sent to the API, which rejects names. JSON holding names is
+// read as well. Values without a name print as their number.
+type Source int64
+type Status int64
+type Priority int64
+
+// MessageSource tells how a conversation was added to a ticket, with values
+// of its own rather than the source of the ticket.
+type MessageSource int64
+
+const (
+	SourceEmail          Source = 1
+	SourcePortal         Source = 2
+	SourcePhone          Source = 3
+	SourceForum          Source = 4
+	SourceTwitter        Source = 5
+	SourceFacebook       Source = 6
+	SourceChat           Source = 7
+	SourceMobihelp       Source = 8
+	SourceFeedbackWidget Source = 9
+	SourceOutboundEmail  Source = 10
+	SourceEcommerce      Source = 11
+	SourceBot            Source = 12
+	SourceWhatsApp       Source = 13
+)
+
+const (
+	StatusOpen     Status = 2
+	StatusPending  Status = 3
+	StatusResolved Status = 4
+	StatusClosed   Status = 5
+)
+
+const (
+	PriorityLow    Priority = 1
+	PriorityMedium Priority = 2
+	PriorityHigh   Priority = 3
+	PriorityUrgent Priority = 4
+)
+
+const (
+	MessageSourceReply          MessageSource = 0
+	MessageSourceNote           MessageSource = 2
+	MessageSourceTweet          MessageSource = 5
+	MessageSourceSurvey         MessageSource = 6
+	MessageSourceFacebook       MessageSource = 7
+	MessageSourceForwardedEmail MessageSource = 8
+	MessageSourcePhone          MessageSource = 9
+	MessageSourceMobihelp       MessageSource = 10
+	MessageSourceEcommerce      MessageSource = 11
+)
+
+var sourceNames = map[Source]string{
+	SourceEmail:          "email",
+	SourcePortal:         "portal",
+	SourcePhone:          "phone",
+	SourceForum:          "forum",
+	SourceTwitter:        "twitter",
+	SourceFacebook:       "facebook",
+	SourceChat:           "chat",
+	SourceMobihelp:       "mobihelp",
+	SourceFeedbackWidget: "feedback widget",
+	SourceOutboundEmail:  "outbound email",
+	SourceEcommerce:      "ecommerce",
+	SourceBot:            "bot",
+	SourceWhatsApp:       "whatsapp",
+}
+
+var priorityNames = map[Priority]string{
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+var messageSourceNames = map[MessageSource]string{
+	MessageSourceReply:          "reply",
+	MessageSourceNote:           "note",
+	MessageSourceTweet:          "tweet",
+	MessageSourceSurvey:         "survey",
+	MessageSourceFacebook:       "facebook",
+	MessageSourceForwardedEmail: "forwarded email",
+	MessageSourcePhone:          "phone",
+	MessageSourceMobihelp:       "mobihelp",
+	MessageSourceEcommerce:      "ecommerce",
+}
+
+// statusNames also holds the custom statuses of the account, registered at
+// runtime with RegisterStatus or LoadCustomStatuses. The registry is
+// shared by the whole process: with clients of several accounts, a custom
+// status number takes the name last registered for it. Only printing and
+// parsing names are affected, requests carry numbers.
+var (
+	statusMu    sync.RWMutex
+	statusNames = map[Status]string{
+		StatusOpen:     "open",
+		StatusPending:  "pending",
+		StatusResolved: "resolved",
+		StatusClosed:   "closed",
+	}
+)
+
+// RegisterStatus names a custom status of the account, so that it prints
+// and parses like the built-in ones, for every client of the process.
+func RegisterStatus(status Status, name string) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statusNames[status] = strings.ToLower(name)
+}
+
+// RegisterCustomStatuses registers the custom statuses listed in the
+// choices of the status ticket field. The built-in statuses keep their
+// names even when renamed in the account.
+func RegisterCustomStatuses(fields []TicketField) error {
+	for _, field := range fields {
+		if field.Name != "status" {
+			continue
+		}
+		choices, err := statusChoices(field.Choices)
+		if err != nil {
+			return err
+		}
+		for status, name := range choices {
+			if status > StatusClosed {
+				RegisterStatus(status, name)
+			}
+		}
+		return nil
+	}
+	return errors.New(ERR_STATUS_FIELD_NOT_FOUND)
+}
+
+// LoadCustomStatuses registers the custom statuses of the account the
+// client is connected to.
+func LoadCustomStatuses(client Client) error {
+	fields, err := client.ListTicketFields()
+	if err != nil {
+		return err
+	}
+	return RegisterCustomStatuses(fields)
+}
+
+// statusChoices reads the choices of the status field, sent either as an
+// object mapping each status to its agent and customer labels or as a
+// list of objects.
+func statusChoices(choices interface{}) (map[Status]string, error) {
+	statuses := map[Status]string{}
+	switch choices := choices.(type) {
+	case map[string]interface{}:
+		for key, labels := range choices {
+			number, err := strconv.ParseInt(key, 10, 64)
+			list, ok := labels.([]interface{})
+			if err != nil || !ok || len(list) == 0 {
+				return nil, fmt.Errorf("unexpected status choice %q", key)
+			}
+			name, ok := list[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected status choice %q", key)
+			}
+			statuses[Status(number)] = name
+		}
+	case []interface{}:
+		for _, choice := range choices {
+			object, _ := choice.(map[string]interface{})
+			number, ok := object["id"].(float64)
+			if !ok {
+				number, ok = object["value"].(float64)
+			}
+			name, _ := object["label"].(string)
+			if name == "" {
+				name, _ = object["value"].(string)
+			}
+			if !ok || name == "" {
+				return nil, fmt.Errorf("unexpected status choice %v", choice)
+			}
+			statuses[Status(number)] = name
+		}
+	default:
+		return nil, fmt.Errorf("unexpected status choices %v", choices)
+	}
+	return statuses, nil
+}
+
+func (source Source) String() string {
+	return enumString(source, sourceNames)
+}
+
+func (status Status) String() string {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	return enumString(status, statusNames)
+}
+
+func (priority Priority) String() string {
+	return enumString(priority, priorityNames)
+}
+
+func (source MessageSource) String() string {
+	return enumString(source, messageSourceNames)
+}
+
+func ParseSource(text string) (Source, error) {
+	return parseEnum("source", text, sourceNames)
+}
+
+func ParseStatus(text string) (Status, error) {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	return parseEnum("status", text, statusNames)
+}
+
+func ParsePriority(text string) (Priority, error) {
+	return parseEnum("priority", text, priorityNames)
+}
+
+func ParseMessageSource(text string) (MessageSource, error) {
+	return parseEnum("message source", text, messageSourceNames)
+}
+
+func (source Source) MarshalText() ([]byte, error) {
+	return []byte(source.String()), nil
+}
+
+func (source *Source) UnmarshalText(text []byte) (err error) {
+	*source, err = ParseSource(string(text))
+	return err
+}
+
+func (source Source) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(source), 10), nil
+}
+
+func (source *Source) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, source, ParseSource)
+}
+
+func (status Status) MarshalText() ([]byte, error) {
+	return []byte(status.String()), nil
+}
+
+func (status *Status) UnmarshalText(text []byte) (err error) {
+	*status, err = ParseStatus(string(text))
+	return err
+}
+
+func (status Status) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(status), 10), nil
+}
+
+func (status *Status) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, status, ParseStatus)
+}
+
+func (priority Priority) MarshalText() ([]byte, error) {
+	return []byte(priority.String()), nil
+}
+
+func (priority *Priority) UnmarshalText(text []byte) (err error) {
+	*priority, err = ParsePriority(string(text))
+	return err
+}
+
+func (priority Priority) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(priority), 10), nil
+}
+
+func (priority *Priority) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, priority, ParsePriority)
+}
+
+func (source MessageSource) MarshalText() ([]byte, error) {
+	return []byte(source.String()), nil
+}
+
+func (source *MessageSource) UnmarshalText(text []byte) (err error) {
+	*source, err = ParseMessageSource(string(text))
+	return err
+}
+
+func (source MessageSource) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(source), 10), nil
+}
+
+func (source *MessageSource) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, source, ParseMessageSource)
+}
+
+func enumString[E ~int64](value E, names map[E]string) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
+
+func parseEnum[E ~int64](kind string, text string, names map[E]string) (E, error) {
+	text = strings.TrimSpace(text)
+	if number, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return E(number), nil
+	}
+	for value, name := range names {
+		if strings.EqualFold(name, text) {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q", kind, text)
+}
+
+// unmarshalEnum reads a number, as the API sends it, or a name.
+func unmarshalEnum[E ~int64](data []byte, value *E, parse func(string) (E, error)) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var number int64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*value = E(number)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	parsed, err := parse(text)
+	if err != nil {
+		return err
+	}
+	*value = parsed
+	return nil
+}
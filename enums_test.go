@@ -0,0 +1,133 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumNames(t *testing.T) {
+	if got := SourceFeedbackWidget.String(); got != "feedback widget" {
+		t.Errorf("source = %q", got)
+	}
+	if got := Status(42).String(); got != "42" {
+		t.Errorf("unknown status = %q", got)
+	}
+	if got := MessageSourceNote.String(); got != "note" {
+		t.Errorf("message source = %q", got)
+	}
+
+	if source, err := ParseSource("Outbound Email"); err != nil || source != SourceOutboundEmail {
+		t.Errorf("ParseSource = %v, %v", source, err)
+	}
+	if priority, err := ParsePriority(" 3 "); err != nil || priority != PriorityHigh {
+		t.Errorf("ParsePriority = %v, %v", priority, err)
+	}
+	if _, err := ParseStatus("snoozed"); err == nil || err.Error() != `unknown status "snoozed"` {
+		t.Errorf("ParseStatus = %v", err)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	data, err := json.Marshal(TicketCreatePayload{Status: StatusPending, Priority: PriorityUrgent, Source: SourcePhone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"status":3,"priority":4,"source":3}` {
+		t.Errorf("sent %s", got)
+	}
+
+	var ticket Ticket
+	if err := json.Unmarshal([]byte(`{"status":"resolved","priority":2,"source":"chat","conversations":[{"source":2}]}`), &ticket); err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Status != StatusResolved || ticket.Priority != PriorityMedium || ticket.Source != SourceChat || ticket.Conversations[0].Source != MessageSourceNote {
+		t.Errorf("got %+v", ticket)
+	}
+	if err := json.Unmarshal([]byte(`{"priority":"whenever"}`), &ticket); err == nil {
+		t.Error("expected an unknown priority error")
+	}
+
+	data, _ = json.Marshal(map[Priority]int{PriorityLow: 2})
+	if got := string(data); got != `{"low":2}` {
+		t.Errorf("map keys = %s", got)
+	}
+}
+
+// keepStatusNames restores the status registry when the test ends.
+func keepStatusNames(t *testing.T) {
+	statusMu.RLock()
+	saved := make(map[Status]string, len(statusNames))
+	for status, name := range statusNames {
+		saved[status] = name
+	}
+	statusMu.RUnlock()
+
+	t.Cleanup(func() {
+		statusMu.Lock()
+		defer statusMu.Unlock()
+		statusNames = saved
+	})
+}
+
+func TestRegisterCustomStatuses(t *testing.T) {
+	keepStatusNames(t)
+	fields := []TicketField{
+		{Name: "priority"},
+		{Name: "status", Choices: map[string]interface{}{
+			"2":  []interface{}{"Opened", "Being processed"},
+			"12": []interface{}{"Waiting on Customer", "Awaiting your reply"},
+		}},
+	}
+	if err := RegisterCustomStatuses(fields); err != nil {
+		t.Fatal(err)
+	}
+	if got := Status(12).String(); got != "waiting on customer" {
+		t.Errorf("custom status = %q", got)
+	}
+	if got := StatusOpen.String(); got != "open" {
+		t.Errorf("renamed built-in status = %q", got)
+	}
+	if status, err := ParseStatus("Waiting on customer"); err != nil || status != 12 {
+		t.Errorf("ParseStatus = %v, %v", status, err)
+	}
+
+	if err := RegisterCustomStatuses(fields[:1]); err == nil || err.Error() != ERR_STATUS_FIELD_NOT_FOUND {
+		t.Errorf("got %v", err)
+	}
+
+	// Custom statuses are still sent to the API as numbers.
+	data, err := json.Marshal(TicketUpdatePayload{Status: 12})
+	if err != nil || string(data) != `{"status":12}` {
+		t.Errorf("sent %s, %v", data, err)
+	}
+}
+
+func TestCustomStatusesRestored(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		keepStatusNames(t)
+		RegisterStatus(14, "Snoozed")
+	})
+	if got := Status(14).String(); got != "14" {
+		t.Errorf("registered status leaked as %q", got)
+	}
+}
+
+func TestLoadCustomStatuses(t *testing.T) {
+	keepStatusNames(t)
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/admin/ticket_fields", 200, `[{"id":1,"name":"status","choices":[{"id":2,"label":"Open"},{"id":13,"label":"On Hold"}]}]`)
+
+	if err := LoadCustomStatuses(stub.client()); err != nil {
+		t.Fatal(err)
+	}
+	var ticket Ticket
+	if err := json.Unmarshal([]byte(`{"status":"on hold"}`), &ticket); err != nil || ticket.Status != 13 {
+		t.Errorf("got %v, %v", ticket.Status, err)
+	}
+
+	stub = newStubServer(t)
+	stub.reply("GET", "/api/v2/admin/ticket_fields", 200, `[{"id":1,"name":"status","choices":"open"}]`)
+	if err := LoadCustomStatuses(stub.client()); err == nil {
+		t.Error("expected an unexpected choices error")
+	}
+}
@@ -0,0 +1,232 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type columnType int
+
+const (
+	typeString columnType = iota
+	typeInt
+	typeFloat
+	typeBool
+	typeTime
+)
+
+// column extracts one value from a record: nil, string, int64, float64,
+// bool or time.Time depending on its type. Lists and objects are written
+// as JSON strings.
+type column struct {
+	name  string
+	typ   columnType
+	value func(record reflect.Value) interface{}
+}
+
+const customFieldsPrefix = "custom_fields."
+
+// Fields left out of the default columns.
+var skippedFields = map[string]bool{
+	"conversations": true,
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// recordValue dereferences a record and checks it is a struct.
+func recordValue(record interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(record)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return value, fmt.Errorf("export: cannot write %T", record)
+	}
+	return value, nil
+}
+
+// recordColumns builds the columns selected by options for records of the
+// type of first.
+func recordColumns(first reflect.Value, options Options) ([]column, error) {
+	fields := map[string]column{}
+	var names []string
+	customIndex := -1
+
+	recordType := first.Type()
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "" || name == "-" {
+			continue
+		}
+		if name == "custom_fields" {
+			customIndex = i
+			continue
+		}
+		fields[name] = fieldColumn(name, i, field.Type)
+		names = append(names, name)
+	}
+
+	if options.Columns == nil {
+		var columns []column
+		for _, name := range names {
+			if !skippedFields[name] {
+				columns = append(columns, fields[name])
+			}
+		}
+		if customIndex >= 0 {
+			custom := customFields(first.Field(customIndex))
+			keys := make([]string, 0, len(custom))
+			for key := range custom {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				columns = append(columns, customColumn(customIndex, key))
+			}
+		}
+		return columns, nil
+	}
+
+	columns := make([]column, 0, len(options.Columns))
+	for _, name := range options.Columns {
+		if customIndex >= 0 && strings.HasPrefix(name, customFieldsPrefix) {
+			columns = append(columns, customColumn(customIndex, strings.TrimPrefix(name, customFieldsPrefix)))
+			continue
+		}
+		column, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("export: %v has no column %q", recordType.Name(), name)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func fieldColumn(name string, index int, fieldType reflect.Type) column {
+	typ := typeString
+	elemType := fieldType
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	switch {
+	case elemType == timeType:
+		typ = typeTime
+	case elemType.Kind() == reflect.Bool:
+		typ = typeBool
+	case elemType.Kind() >= reflect.Int && elemType.Kind() <= reflect.Uint64:
+		typ = typeInt
+	case elemType.Kind() == reflect.Float32 || elemType.Kind() == reflect.Float64:
+		typ = typeFloat
+	}
+
+	value := func(record reflect.Value) interface{} {
+		return scalarValue(record.Field(index))
+	}
+	// Fields of type interface{} hold whatever the API sent.
+	if typ == typeString {
+		value = func(record reflect.Value) interface{} {
+			return stringValue(scalarValue(record.Field(index)))
+		}
+	}
+	return column{name: name, typ: typ, value: value}
+}
+
+// Custom fields are typed by the account configuration, not by the
+// record type, so they are written as strings.
+func customColumn(index int, key string) column {
+	return column{
+		name: customFieldsPrefix + key,
+		typ:  typeString,
+		value: func(record reflect.Value) interface{} {
+			value, ok := customFields(record.Field(index))[key]
+			if !ok || value == nil {
+				return nil
+			}
+			switch value := value.(type) {
+			case string:
+				return value
+			case float64:
+				return strconv.FormatFloat(value, 'f', -1, 64)
+			case bool, json.Number:
+				return fmt.Sprint(value)
+			}
+			return jsonString(value)
+		},
+	}
+}
+
+// customFields returns the custom fields held by a field of type
+// CustomFields or interface{}.
+func customFields(field reflect.Value) map[string]interface{} {
+	for field.Kind() == reflect.Interface || field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+	fields := make(map[string]interface{}, field.Len())
+	iter := field.MapRange()
+	for iter.Next() {
+		fields[iter.Key().String()] = iter.Value().Interface()
+	}
+	return fields
+}
+
+func scalarValue(field reflect.Value) interface{} {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if field.Type() == timeType {
+		return field.Interface().(time.Time)
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String()
+	case reflect.Bool:
+		return field.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		return field.Float()
+	case reflect.Slice, reflect.Map:
+		if field.IsNil() {
+			return nil
+		}
+	}
+	return jsonString(field.Interface())
+}
+
+// stringValue formats a scalar for a string column, nil stays nil.
+func stringValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil, string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+type csvWriter struct {
+	out     *csv.Writer
+	options Options
+	columns []column
+}
+
+// NewCSVWriter writes a header row followed by one row per record.
+func NewCSVWriter(w io.Writer, options Options) Writer {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	if options.TimeFormat == "" {
+		options.TimeFormat = time.RFC3339
+	}
+	return &csvWriter{out: csv.NewWriter(w), options: options}
+}
+
+func (writer *csvWriter) Write(record interface{}) error {
+	value, err := recordValue(record)
+	if err != nil {
+		return err
+	}
+
+	if writer.columns == nil {
+		writer.columns, err = recordColumns(value, writer.options)
+		if err != nil {
+			return err
+		}
+		header := make([]string, len(writer.columns))
+		for i, column := range writer.columns {
+			header[i] = column.name
+		}
+		if err := writer.out.Write(header); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, len(writer.columns))
+	for i, column := range writer.columns {
+		row[i] = writer.format(column.value(value))
+	}
+	return writer.out.Write(row)
+}
+
+func (writer *csvWriter) format(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case time.Time:
+		return value.In(writer.options.Location).Format(writer.options.TimeFormat)
+	}
+	return jsonString(value)
+}
+
+func (writer *csvWriter) Close() error {
+	writer.out.Flush()
+	return writer.out.Error()
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func decodeTickets(t *testing.T, data string) []freshdesk.Ticket {
+	t.Helper()
+	var tickets []freshdesk.Ticket
+	if err := json.Unmarshal([]byte(data), &tickets); err != nil {
+		t.Fatal(err)
+	}
+	return tickets
+}
+
+// readCSV writes the records and parses the output back.
+func readCSV(t *testing.T, options Options, records ...interface{}) [][]string {
+	t.Helper()
+	var out bytes.Buffer
+	writer := NewCSVWriter(&out, options)
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	tickets := decodeTickets(t, `[
+		{"id": 1, "subject": "Printer, again", "priority": 3, "spam": true, "created_at": "2024-01-08T10:00:00Z",
+		 "tags": ["printer", "vip"], "custom_fields": {"cf_region": "EMEA", "cf_seats": 12000000000, "cf_rush": true, "cf_parts": ["a"]}},
+		{"id": 2, "subject": "Line\nbreak", "custom_fields": {"cf_region": null}}
+	]`)
+
+	columns := []string{"id", "subject", "priority", "spam", "created_at", "tags",
+		"custom_fields.cf_region", "custom_fields.cf_seats", "custom_fields.cf_rush", "custom_fields.cf_parts", "custom_fields.cf_missing"}
+	rows := readCSV(t, Options{Columns: columns}, &tickets[0], tickets[1])
+
+	want := [][]string{
+		columns,
+		{"1", "Printer, again", "3", "true", "2024-01-08T10:00:00Z", `["printer","vip"]`, "EMEA", "12000000000", "true", `["a"]`, ""},
+		{"2", "Line\nbreak", "0", "false", "", "", "", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %q\nwant %q", rows, want)
+	}
+}
+
+func TestCSVTimeFormat(t *testing.T) {
+	tickets := decodeTickets(t, `[{"id": 1, "created_at": "2024-01-08T10:00:00Z", "due_by": "2024-07-01T23:30:00Z"}]`)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		options Options
+		want    []string
+	}{
+		{"defaults", Options{}, []string{"2024-01-08T10:00:00Z", "2024-07-01T23:30:00Z"}},
+		{"location", Options{Location: paris}, []string{"2024-01-08T11:00:00+01:00", "2024-07-02T01:30:00+02:00"}},
+		{"format", Options{Location: paris, TimeFormat: "2006-01-02 15:04"}, []string{"2024-01-08 11:00", "2024-07-02 01:30"}},
+	}
+	for _, tc := range cases {
+		tc.options.Columns = []string{"created_at", "due_by"}
+		rows := readCSV(t, tc.options, &tickets[0])
+		if len(rows) != 2 || !reflect.DeepEqual(rows[1], tc.want) {
+			t.Errorf("%s: got %q, want %q", tc.name, rows, tc.want)
+		}
+	}
+}
+
+func TestCSVDefaultColumns(t *testing.T) {
+	tickets := decodeTickets(t, `[
+		{"id": 1, "custom_fields": {"cf_b": "x", "cf_a": 1}, "conversations": [{"id": 9}]},
+		{"id": 2, "custom_fields": {"cf_a": 2, "cf_c": "ignored"}}
+	]`)
+	rows := readCSV(t, Options{}, &tickets[0], &tickets[1])
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows", len(rows))
+	}
+
+	header := rows[0]
+	index := map[string]int{}
+	for i, name := range header {
+		index[name] = i
+	}
+	for _, name := range []string{"conversations", "custom_fields", "custom_fields.cf_c"} {
+		if _, ok := index[name]; ok {
+			t.Errorf("column %q written", name)
+		}
+	}
+	// Custom fields follow the record fields, sorted.
+	if n := len(header); header[n-2] != "custom_fields.cf_a" || header[n-1] != "custom_fields.cf_b" {
+		t.Errorf("header ends with %q", header[n-2:])
+	}
+	if _, ok := index["subject"]; !ok {
+		t.Errorf("no subject column in %q", header)
+	}
+	if rows[1][index["id"]] != "1" || rows[2][index["custom_fields.cf_a"]] != "2" || rows[2][index["custom_fields.cf_b"]] != "" {
+		t.Errorf("got rows %q", rows[1:])
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	var out bytes.Buffer
+	writer := NewCSVWriter(&out, Options{Columns: []string{"id", "colour"}})
+	if err := writer.Write(&freshdesk.Ticket{}); err == nil || !strings.Contains(err.Error(), `Ticket has no column "colour"`) {
+		t.Errorf("got error %v", err)
+	}
+
+	writer = NewCSVWriter(&out, Options{})
+	if err := writer.Write("not a record"); err == nil {
+		t.Error("string accepted as a record")
+	}
+	var ticket *freshdesk.Ticket
+	if err := writer.Write(ticket); err == nil {
+		t.Error("nil record accepted")
+	}
+}
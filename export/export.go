@@ -0,0 +1,121 @@
+// Package export streams tickets, contacts and companies to CSV, JSONL and
+// Parquet files.
+package export
+
+import (
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// Writer writes records of one type, Ticket, Contact or Company or
+// pointers to them, to an output format. Close flushes the output but does
+// not close the underlying io.Writer.
+type Writer interface {
+	Write(record interface{}) error
+	Close() error
+}
+
+// Options select the columns of the tabular formats.
+//
+// Columns are the JSON names of the record fields; custom fields are
+// flattened to one column each, named custom_fields.<name>. Without
+// Columns every field is written, except ticket conversations, along with
+// the custom fields present in the first record.
+//
+// Location and TimeFormat apply to the CSV output, UTC and RFC 3339 by
+// default. Parquet stores timestamps, which do not have a time zone.
+type Options struct {
+	Columns    []string
+	Location   *time.Location
+	TimeFormat string
+}
+
+// Pager returns one page of records and whether more pages follow, like
+// the List methods of freshdesk.Client.
+type Pager func(page int) ([]interface{}, error, bool)
+
+// TicketPager pages through the tickets returned by list, usually
+// Client.ListTickets or Client.ListDeletedTickets.
+func TicketPager(list func(*time.Time, int, int) ([]freshdesk.Ticket, error, bool), updatedSince *time.Time) Pager {
+	return func(page int) ([]interface{}, error, bool) {
+		tickets, err, more := list(updatedSince, pageSize, page)
+		records := make([]interface{}, len(tickets))
+		for i := range tickets {
+			records[i] = &tickets[i]
+		}
+		return records, err, more
+	}
+}
+
+func ContactPager(list func(*time.Time, int, int) ([]freshdesk.Contact, error, bool), updatedSince *time.Time) Pager {
+	return func(page int) ([]interface{}, error, bool) {
+		contacts, err, more := list(updatedSince, pageSize, page)
+		records := make([]interface{}, len(contacts))
+		for i := range contacts {
+			records[i] = &contacts[i]
+		}
+		return records, err, more
+	}
+}
+
+func CompanyPager(list func(*time.Time, int, int) ([]freshdesk.Company, error, bool), updatedSince *time.Time) Pager {
+	return func(page int) ([]interface{}, error, bool) {
+		companies, err, more := list(updatedSince, pageSize, page)
+		records := make([]interface{}, len(companies))
+		for i := range companies {
+			records[i] = &companies[i]
+		}
+		return records, err, more
+	}
+}
+
+const pageSize = 100
+
+// WriteAll writes every page of the pager and returns the number of
+// records written. It does not close the writer.
+func WriteAll(writer Writer, pager Pager) (int, error) {
+	count := 0
+	for page := 1; ; page++ {
+		records, err, more := pager(page)
+		if err != nil {
+			return count, err
+		}
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if !more {
+			return count, nil
+		}
+	}
+}
+
+func WriteTickets(writer Writer, tickets []freshdesk.Ticket) error {
+	for i := range tickets {
+		if err := writer.Write(&tickets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func WriteContacts(writer Writer, contacts []freshdesk.Contact) error {
+	for i := range contacts {
+		if err := writer.Write(&contacts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func WriteCompanies(writer Writer, companies []freshdesk.Company) error {
+	for i := range companies {
+		if err := writer.Write(&companies[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// recordingWriter keeps the records written to it.
+type recordingWriter struct {
+	records []interface{}
+	fail    int // Fail on the nth record when set
+}
+
+func (writer *recordingWriter) Write(record interface{}) error {
+	if writer.fail > 0 && len(writer.records)+1 == writer.fail {
+		return errors.New("disk full")
+	}
+	writer.records = append(writer.records, record)
+	return nil
+}
+
+func (writer *recordingWriter) Close() error {
+	return nil
+}
+
+func TestWriteAllTicketPager(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var pages []int
+	list := func(updatedSince *time.Time, perPage int, page int) ([]freshdesk.Ticket, error, bool) {
+		if updatedSince != &since || perPage != pageSize {
+			t.Errorf("listed with %v and %d per page", updatedSince, perPage)
+		}
+		pages = append(pages, page)
+		if page == 1 {
+			return []freshdesk.Ticket{{ID: 1}, {ID: 2}}, nil, true
+		}
+		return []freshdesk.Ticket{{ID: 3}}, nil, false
+	}
+
+	writer := &recordingWriter{}
+	count, err := WriteAll(writer, TicketPager(list, &since))
+	if err != nil || count != 3 {
+		t.Fatalf("WriteAll = %d, %v", count, err)
+	}
+	if !reflect.DeepEqual(pages, []int{1, 2}) {
+		t.Errorf("listed pages %v", pages)
+	}
+	var ids []uint64
+	for _, record := range writer.records {
+		ids = append(ids, record.(*freshdesk.Ticket).ID)
+	}
+	if !reflect.DeepEqual(ids, []uint64{1, 2, 3}) {
+		t.Errorf("wrote tickets %v", ids)
+	}
+}
+
+func TestWriteAllErrors(t *testing.T) {
+	list := func(updatedSince *time.Time, perPage int, page int) ([]freshdesk.Contact, error, bool) {
+		if page == 2 {
+			return nil, errors.New("rate limited"), false
+		}
+		return []freshdesk.Contact{{ID: 1}, {ID: 2}}, nil, true
+	}
+	count, err := WriteAll(&recordingWriter{}, ContactPager(list, nil))
+	if err == nil || err.Error() != "rate limited" || count != 2 {
+		t.Errorf("list error: WriteAll = %d, %v", count, err)
+	}
+
+	count, err = WriteAll(&recordingWriter{fail: 2}, ContactPager(list, nil))
+	if err == nil || err.Error() != "disk full" || count != 1 {
+		t.Errorf("write error: WriteAll = %d, %v", count, err)
+	}
+}
+
+func TestCompanyPager(t *testing.T) {
+	list := func(updatedSince *time.Time, perPage int, page int) ([]freshdesk.Company, error, bool) {
+		return []freshdesk.Company{{ID: uint64(page)}}, nil, page < 3
+	}
+	writer := &recordingWriter{}
+	if count, err := WriteAll(writer, CompanyPager(list, nil)); err != nil || count != 3 {
+		t.Fatalf("WriteAll = %d, %v", count, err)
+	}
+	if company := writer.records[2].(*freshdesk.Company); company.ID != 3 {
+		t.Errorf("last company %+v", company)
+	}
+}
+
+func TestWriteSlices(t *testing.T) {
+	writer := &recordingWriter{}
+	if err := WriteTickets(writer, []freshdesk.Ticket{{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteCompanies(writer, []freshdesk.Company{{ID: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := writer.records[0].(*freshdesk.Ticket); !ok {
+		t.Errorf("wrote %T", writer.records[0])
+	}
+	if _, ok := writer.records[1].(*freshdesk.Company); !ok {
+		t.Errorf("wrote %T", writer.records[1])
+	}
+	if err := WriteTickets(&recordingWriter{fail: 1}, []freshdesk.Ticket{{ID: 1}, {ID: 2}}); err == nil {
+		t.Error("write error ignored")
+	}
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONLWriter writes every record as one line of JSON, in the format of
+// the API. Options do not apply: all fields are kept as they are.
+func NewJSONLWriter(w io.Writer) Writer {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonlWriter{encoder: encoder}
+}
+
+func (writer *jsonlWriter) Write(record interface{}) error {
+	return writer.encoder.Encode(record)
+}
+
+func (writer *jsonlWriter) Close() error {
+	return nil
+}
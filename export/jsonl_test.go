@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	var contacts []freshdesk.Contact
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "Jane <jane@example.com>", "created_at": "2024-01-08T10:00:00Z", "custom_fields": {"plan": "gold"}},
+		{"id": 2, "name": "John"}
+	]`), &contacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	writer := NewJSONLWriter(&out)
+	if err := WriteContacts(writer, contacts); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), `\u003c`) {
+		t.Errorf("HTML escaped: %s", out.String())
+	}
+
+	var read []freshdesk.Contact
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var contact freshdesk.Contact
+		if err := json.Unmarshal(scanner.Bytes(), &contact); err != nil {
+			t.Fatalf("%q: %v", scanner.Text(), err)
+		}
+		read = append(read, contact)
+	}
+	if !reflect.DeepEqual(read, contacts) {
+		t.Errorf("got %+v\nwant %+v", read, contacts)
+	}
+}
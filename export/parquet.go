@@ -0,0 +1,108 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type parquetWriter struct {
+	w       io.Writer
+	options Options
+	columns []column
+	out     *writer.CSVWriter
+}
+
+// NewParquetWriter writes the records as a Parquet file with one optional
+// column per selected field. The schema is taken from the first record, so
+// nothing is written when there are no records.
+func NewParquetWriter(w io.Writer, options Options) Writer {
+	return &parquetWriter{w: w, options: options}
+}
+
+var parquetTypes = map[columnType]string{
+	typeString: "type=BYTE_ARRAY, convertedtype=UTF8",
+	typeInt:    "type=INT64",
+	typeFloat:  "type=DOUBLE",
+	typeBool:   "type=BOOLEAN",
+	typeTime:   "type=INT64, convertedtype=TIMESTAMP_MILLIS",
+}
+
+func (pw *parquetWriter) Write(record interface{}) error {
+	value, err := recordValue(record)
+	if err != nil {
+		return err
+	}
+
+	if pw.out == nil {
+		if err := pw.open(value); err != nil {
+			return err
+		}
+	}
+
+	row := make([]interface{}, len(pw.columns))
+	for i, column := range pw.columns {
+		if row[i], err = parquetValue(column, column.value(value)); err != nil {
+			return err
+		}
+	}
+	return pw.out.Write(row)
+}
+
+// parquetValue converts a column value to the Go type of its Parquet
+// column. The writer only converts values when it flushes, where a wrong
+// type would fail the whole file, so mismatches are refused here.
+func parquetValue(column column, value interface{}) (interface{}, error) {
+	ok := true
+	switch v := value.(type) {
+	case nil:
+	case string:
+		ok = column.typ == typeString
+	case int64:
+		ok = column.typ == typeInt
+	case float64:
+		ok = column.typ == typeFloat
+	case bool:
+		ok = column.typ == typeBool
+	case time.Time:
+		if ok = column.typ == typeTime; ok {
+			value = v.UnixMilli()
+		}
+	default:
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("export: column %v cannot hold %T", column.name, value)
+	}
+	return value, nil
+}
+
+func (pw *parquetWriter) open(first reflect.Value) error {
+	columns, err := recordColumns(first, pw.options)
+	if err != nil {
+		return err
+	}
+
+	metadata := make([]string, len(columns))
+	for i, column := range columns {
+		metadata[i] = fmt.Sprintf("name=%v, %v, repetitiontype=OPTIONAL", column.name, parquetTypes[column.typ])
+	}
+	out, err := writer.NewCSVWriterFromWriter(metadata, pw.w, 1)
+	if err != nil {
+		return err
+	}
+
+	pw.columns = columns
+	pw.out = out
+	return nil
+}
+
+func (pw *parquetWriter) Close() error {
+	if pw.out == nil {
+		return nil
+	}
+	return pw.out.WriteStop()
+}
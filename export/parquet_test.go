@@ -0,0 +1,80 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestParquetRoundTrip(t *testing.T) {
+	var contacts []freshdesk.Contact
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "Jane", "active": true, "csat_rating": 103, "created_at": "2024-01-08T10:00:00Z",
+		 "tags": ["vip"], "custom_fields": {"plan": "gold", "seats": 12.5}},
+		{"id": 2, "name": "John", "csat_rating": "n/a", "custom_fields": {"plan": null}}
+	]`), &contacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns := []string{"id", "name", "active", "csat_rating", "created_at", "tags", "custom_fields.plan", "custom_fields.seats"}
+	var out bytes.Buffer
+	writer := NewParquetWriter(&out, Options{Columns: columns})
+	for i := range contacts {
+		if err := writer.Write(&contacts[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := buffer.NewBufferFile(out.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if pr.GetNumRows() != 2 {
+		t.Fatalf("got %d rows", pr.GetNumRows())
+	}
+
+	created := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC).UnixMilli()
+	want := [][]interface{}{
+		{int64(1), int64(2)},
+		{"Jane", "John"},
+		{true, false},
+		{"103", "n/a"},
+		{created, nil},
+		{`["vip"]`, nil},
+		{"gold", nil},
+		{"12.5", nil},
+	}
+	for i, name := range columns {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(values, want[i]) {
+			t.Errorf("%s = %#v, want %#v", name, values, want[i])
+		}
+	}
+}
+
+func TestParquetValueRefusesWrongType(t *testing.T) {
+	if _, err := parquetValue(column{name: "id", typ: typeInt}, "7"); err == nil {
+		t.Error("string accepted in an INT64 column")
+	}
+	if value, err := parquetValue(column{name: "id", typ: typeInt}, nil); err != nil || value != nil {
+		t.Errorf("nil = %v, %v", value, err)
+	}
+}
@@ -0,0 +1,74 @@
+package freshdesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Several admin resources carry fields this package does not model. The
+// types holding them keep the unmodelled members in an Extra map so that
+// exporting a configuration and applying it again does not drop them.
+
+// unknownFields returns the members of the JSON object data that are not
+// declared by the json tags of the struct v points to.
+func unknownFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(all, name)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// withUnknownFields appends the extra members to the JSON object known.
+// Declared fields take precedence over extra members of the same name.
+func withUnknownFields(known []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return known, nil
+	}
+
+	var declared map[string]json.RawMessage
+	if err := json.Unmarshal(known, &declared); err != nil {
+		return nil, err
+	}
+	merged := make(map[string]json.RawMessage, len(declared)+len(extra))
+	for name, value := range extra {
+		merged[name] = value
+	}
+	for name, value := range declared {
+		merged[name] = value
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(merged); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(out.Bytes(), "\n"), nil
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
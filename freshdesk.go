@@ -1,7 +1,6 @@
 package freshdesk
 
 import (
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Peter2121/freshdesk-go/internal/rest"
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/ratelimit"
 )
@@ -22,8 +22,11 @@ type Client interface {
 
 	GetTicket(ID uint64) (*Ticket, error)
 	GetTicketWithConversations(ID uint64) (*Ticket, error)
+	GetAllTicketConversations(ID uint64) ([]TicketMessage, error)
 	GetAllTickets() ([]Ticket, error)
 	GetTicketsByCompanyID(companyID, pageSize, page int) ([]Ticket, error, bool)
+	ListTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool)
+	ListDeletedTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool)
 	CreateTicket(payload TicketCreatePayload) (*Ticket, error)
 	CreateSdTicket(payload SdTicketCreatePayload) (*Ticket, error)
 	CreateTicketWithAttachments(payload TicketCreatePayload, files []Attachment) (*Ticket, error)
@@ -34,9 +37,22 @@ type Client interface {
 	CreateSdTicketMessage(ID uint64, payload TicketMessageCreatePayload) (*TicketMessage, error)
 	DeleteTicket(ID uint64) (*interface{}, error)
 
+	AddWatcher(ID uint64, userID uint64) (*interface{}, error)
+	RemoveWatcher(ID uint64, userID uint64) (*interface{}, error)
+	ListWatchers(ID uint64) ([]uint64, error)
+	CreateChildTicket(parentID uint64, payload TicketCreatePayload) (*Ticket, error)
+	GetAssociatedTickets(ID uint64) ([]Ticket, error)
+	LinkTicket(ID uint64, trackerID uint64) (*interface{}, error)
+	UnlinkTicket(ID uint64, trackerID uint64) (*interface{}, error)
+
 	FindContactByEmail(email string) (Contact, error)
+	FindContactByExternalID(externalID string) (Contact, error)
+	FindContactByPhone(phone string) (Contact, error)
+	UpsertContact(key UpsertKey, payload ContactCreatePayload) (*Contact, UpsertResult, error)
 	GetContact(ID uint64) (*Contact, error)
 	GetAllContacts() ([]ContactShort, error)
+	ListContacts(updatedSince *time.Time, pageSize, page int) ([]Contact, error, bool)
+	ListDeletedContacts(updatedSince *time.Time, pageSize, page int) ([]Contact, error, bool)
 	CreateContact(payload ContactCreatePayload) (*Contact, error)
 	UpdateContact(ID uint64, payload ContactUpdatePayload) (*Contact, error)
 	SoftDeleteContact(ID uint64) (*interface{}, error)
@@ -45,17 +61,92 @@ type Client interface {
 	AddMainCompanyForContact(fd_contact *Contact, id_client uint64) (bool, error)
 
 	GetCompany(ID uint64) (*Company, error)
+	GetCompanyWithContactCount(ID uint64) (*Company, error)
+	GetCompanyByName(name string) (*Company, error)
+	GetCompanyByDomain(domain string) (*Company, error)
 	GetAllCompanies() ([]Company, error)
+	ListCompanies(updatedSince *time.Time, pageSize, page int) ([]Company, error, bool)
 	SearchCompanies(mask string) ([]CompanyName, error)
 	CreateCompany(payload CompanyCreatePayload) (*Company, error)
 	UpdateCompany(ID uint64, payload CompanyUpdatePayload) (*Company, error)
 	DeleteCompany(ID uint64) (*interface{}, error)
+	UpsertCompany(key UpsertKey, payload CompanyCreatePayload) (*Company, UpsertResult, error)
 
 	GetAllGroups() ([]Group, error)
+	CreateGroup(payload GroupCreatePayload) (*Group, error)
+	UpdateGroup(ID uint64, payload GroupUpdatePayload) (*Group, error)
+	DeleteGroup(ID uint64) (*interface{}, error)
+
+	ListProducts() ([]Product, error)
+	GetProduct(ID uint64) (*Product, error)
+	CreateProduct(payload ProductCreatePayload) (*Product, error)
+	UpdateProduct(ID uint64, payload ProductUpdatePayload) (*Product, error)
+	ListEmailConfigs() ([]EmailConfig, error)
+	GetEmailConfig(ID uint64) (*EmailConfig, error)
+	FindEmailConfigByEmail(email string) (*EmailConfig, error)
+	ListMailboxes() ([]Mailbox, error)
+	GetMailbox(ID uint64) (*Mailbox, error)
+	CreateMailbox(payload MailboxCreatePayload) (*Mailbox, error)
+	UpdateMailbox(ID uint64, payload MailboxUpdatePayload) (*Mailbox, error)
+	DeleteMailbox(ID uint64) (*interface{}, error)
+
+	ListBusinessHours() ([]BusinessHours, error)
+	GetBusinessHours(ID uint64) (*BusinessHours, error)
+	ListSLAPolicies() ([]SLAPolicy, error)
+	CreateSLAPolicy(payload SLAPolicyCreatePayload) (*SLAPolicy, error)
+	UpdateSLAPolicy(ID uint64, payload SLAPolicyUpdatePayload) (*SLAPolicy, error)
+
+	ListRoles() ([]Role, error)
+	GetRole(ID uint64) (*Role, error)
+	ListSkills() ([]Skill, error)
+	GetSkill(ID uint64) (*Skill, error)
+	CreateSkill(payload SkillCreatePayload) (*Skill, error)
+	UpdateSkill(ID uint64, payload SkillUpdatePayload) (*Skill, error)
+	DeleteSkill(ID uint64) (*interface{}, error)
+	GetAgentAvailability(agentID uint64) (*AgentAvailability, error)
+	UpdateAgentAvailability(agentID uint64, payload AgentAvailabilityUpdatePayload) (*AgentAvailability, error)
+	ListAgentAvailability() ([]AgentAvailability, error)
+
+	ListAutomationRules(automationType AutomationType) ([]AutomationRule, error)
+	GetAutomationRule(automationType AutomationType, ID uint64) (*AutomationRule, error)
+	CreateAutomationRule(automationType AutomationType, payload AutomationRulePayload) (*AutomationRule, error)
+	UpdateAutomationRule(automationType AutomationType, ID uint64, payload AutomationRulePayload) (*AutomationRule, error)
+	DeleteAutomationRule(automationType AutomationType, ID uint64) (*interface{}, error)
+	ListScenarioAutomations() ([]ScenarioAutomation, error)
+	ExecuteScenarioAutomation(ticketID uint64, scenarioID uint64) (*interface{}, error)
+	ListTicketForms() ([]TicketForm, error)
+	GetTicketForm(ID uint64) (*TicketForm, error)
+	CreateTicketForm(payload TicketFormPayload) (*TicketForm, error)
+	UpdateTicketForm(ID uint64, payload TicketFormPayload) (*TicketForm, error)
+	DeleteTicketForm(ID uint64) (*interface{}, error)
+
+	ListTicketFields() ([]TicketField, error)
+	CreateTicketField(payload TicketFieldCreatePayload) (*TicketField, error)
+	UpdateTicketField(ID uint64, payload TicketFieldUpdatePayload) (*TicketField, error)
+	DeleteTicketField(ID uint64) (*interface{}, error)
+	ListCannedResponseFolders() ([]CannedResponseFolder, error)
+	CreateCannedResponseFolder(payload CannedResponseFolderCreatePayload) (*CannedResponseFolder, error)
+	ListCannedResponses(folderID uint64) ([]CannedResponse, error)
+	CreateCannedResponse(payload CannedResponseCreatePayload) (*CannedResponse, error)
+	UpdateCannedResponse(ID uint64, payload CannedResponseUpdatePayload) (*CannedResponse, error)
 
 	SearchCustomObjects(SchemaID uint64, filter map[string]string) ([]CustomObject, error)
 	CreateCustomObject(schema_id uint64, data map[string]interface{}) (*CustomObjectUpdateResult, error)
 	UpdateCustomObject(schema_id uint64, payload CustomObjectUpdatePayload) (*CustomObjectUpdateResult, error)
+
+	GetAllSolutionCategories() ([]SolutionCategory, error)
+	CreateSolutionCategory(payload SolutionCategoryCreatePayload) (*SolutionCategory, error)
+	GetAllSolutionFolders(categoryID uint64) ([]SolutionFolder, error)
+	CreateSolutionFolder(categoryID uint64, payload SolutionFolderCreatePayload) (*SolutionFolder, error)
+	GetAllSolutionArticles(folderID uint64) ([]SolutionArticle, error)
+	GetSolutionArticle(ID uint64) (*SolutionArticle, error)
+	CreateSolutionArticle(folderID uint64, payload SolutionArticleCreatePayload) (*SolutionArticle, error)
+	UpdateSolutionArticle(ID uint64, payload SolutionArticleUpdatePayload) (*SolutionArticle, error)
+	DeleteSolutionArticle(ID uint64) (*interface{}, error)
+
+	ListTicketActivityExports(createdAt time.Time) ([]string, error)
+	DownloadTicketActivities(url string) ([]TicketActivity, error)
+	ExportTicketActivities(createdAt time.Time) ([]TicketActivity, error)
 }
 
 type freshDeskService struct {
@@ -64,15 +155,19 @@ type freshDeskService struct {
 }
 
 func NewClient(baseUrl string, user string, password string, maxRequestPerMinute int) Client {
+	return NewClientWithTransport(baseUrl, user, password, maxRequestPerMinute, nil)
+}
+
+// NewClientWithTransport creates a client sending its requests through the
+// given transport, or the default one when nil. Every request waits for
+// the budget of maxRequestPerMinute, and requests answered 429 are retried
+// after the delay the API asks for.
+func NewClientWithTransport(baseUrl string, user string, password string, maxRequestPerMinute int, transport http.RoundTripper) Client {
 	_freshDeskService := freshDeskService{
-		restyClient: resty.New(),
+		restyClient: rest.NewClient(baseUrl, user, password, transport),
 		rateLimiter: ratelimit.New(maxRequestPerMinute, ratelimit.Per(time.Second*60), ratelimit.WithSlack(100)),
 	}
-
-	auth := user + ":" + password
-
-	_freshDeskService.restyClient.SetBaseURL(baseUrl)
-	_freshDeskService.restyClient.SetHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(auth)))
+	rest.Limit(_freshDeskService.restyClient, _freshDeskService.rateLimiter)
 
 	return &_freshDeskService
 }
@@ -129,6 +224,32 @@ func (service *freshDeskService) GetTicketExt(ID uint64, with_conversations bool
 	return &responseSchema, nil
 }
 
+func (service *freshDeskService) GetAllTicketConversations(ID uint64) ([]TicketMessage, error) {
+	var responseAll []TicketMessage
+	next := fmt.Sprintf("/api/v2/tickets/%v/conversations?per_page=100", ID)
+
+	for next != "" {
+		var responseSchema []TicketMessage
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
 func (service *freshDeskService) GetAllTickets() ([]Ticket, error) {
 
 	var responseSchema []Ticket
@@ -148,6 +269,67 @@ func (service *freshDeskService) GetAllTickets() ([]Ticket, error) {
 	return responseSchema, nil
 }
 
+// ListTickets returns tickets updated since the given time, oldest update
+// first. Without updatedSince the API only returns tickets created in the
+// last 30 days.
+func (service *freshDeskService) ListTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	params := map[string]string{
+		"per_page":   fmt.Sprint(pageSize),
+		"page":       fmt.Sprint(page),
+		"order_by":   "updated_at",
+		"order_type": "asc",
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema []Ticket
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/tickets")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body())), false
+	}
+
+	return responseSchema, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshDeskService) ListDeletedTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	params := map[string]string{
+		"per_page":   fmt.Sprint(pageSize),
+		"page":       fmt.Sprint(page),
+		"filter":     "deleted",
+		"order_by":   "updated_at",
+		"order_type": "asc",
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema []Ticket
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/tickets")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body())), false
+	}
+
+	return responseSchema, nil, resp.Header().Get("Link") != ""
+}
+
+// Deprecated: Freshservice tickets are handled by the freshservice package.
 func (service *freshDeskService) CreateSdTicket(payload SdTicketCreatePayload) (*Ticket, error) {
 
 	var responseSchema SdTicket
@@ -163,11 +345,7 @@ func (service *freshDeskService) CreateSdTicket(payload SdTicketCreatePayload) (
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		resp_body := string(resp.Body())
-		if len(resp_body) == 0 {
-			resp_body = fmt.Sprintf("Invalid status received: %d", resp.StatusCode())
-		}
-		return nil, errors.New(resp_body)
+		return nil, rest.Error(resp)
 	}
 
 	return &responseSchema.Ticket, nil
@@ -226,6 +404,7 @@ func (service *freshDeskService) CreateTicketWithAttachments(payload TicketCreat
 	return new_ticket, nil
 }
 
+// Deprecated: Freshservice tickets are handled by the freshservice package.
 func (service *freshDeskService) CreateSdTicketWithAttachments(payload SdTicketCreatePayload, files []Attachment) (*Ticket, error) {
 
 	var responseSchema Ticket
@@ -319,6 +498,7 @@ func (service *freshDeskService) CreateTicketMessage(ID uint64, payload TicketMe
 
 }
 
+// Deprecated: Freshservice tickets are handled by the freshservice package.
 func (service *freshDeskService) CreateSdTicketMessage(ID uint64, payload TicketMessageCreatePayload) (*TicketMessage, error) {
 	var responseSchema SdTicketMessage
 	resp, err := service.restyClient.R().
@@ -356,6 +536,115 @@ func (service *freshDeskService) DeleteTicket(ID uint64) (*interface{}, error) {
 	return &responseSchema, nil
 }
 
+// AddWatcher makes the agent userID watch the ticket, or the agent owning
+// the API key when userID is 0. RemoveWatcher stops it.
+func (service *freshDeskService) AddWatcher(ID uint64, userID uint64) (*interface{}, error) {
+	return service.watch(fmt.Sprintf("/api/v2/tickets/%v/watch", ID), "POST", userID)
+}
+
+func (service *freshDeskService) RemoveWatcher(ID uint64, userID uint64) (*interface{}, error) {
+	return service.watch(fmt.Sprintf("/api/v2/tickets/%v/unwatch", ID), "PUT", userID)
+}
+
+func (service *freshDeskService) watch(path string, method string, userID uint64) (*interface{}, error) {
+	payload := map[string]interface{}{}
+	if userID != 0 {
+		payload["user_id"] = userID
+	}
+
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Execute(method, path)
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// ListWatchers returns the IDs of the agents watching the ticket.
+func (service *freshDeskService) ListWatchers(ID uint64) ([]uint64, error) {
+	var responseSchema TicketWatchers
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/tickets/%v/watchers", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return responseSchema.WatcherIDs, nil
+}
+
+// CreateChildTicket creates a ticket as a child of parentID, which becomes
+// a parent ticket if it was not one already.
+func (service *freshDeskService) CreateChildTicket(parentID uint64, payload TicketCreatePayload) (*Ticket, error) {
+	payload.ParentID = parentID
+	return service.CreateTicket(payload)
+}
+
+// GetAssociatedTickets returns the children of a parent ticket or the
+// related tickets of a tracker.
+func (service *freshDeskService) GetAssociatedTickets(ID uint64) ([]Ticket, error) {
+	var responseSchema []Ticket
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/tickets/%v/associated_tickets", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return responseSchema, nil
+}
+
+// LinkTicket relates the ticket to the tracker ticket trackerID,
+// UnlinkTicket removes it from the tracker.
+func (service *freshDeskService) LinkTicket(ID uint64, trackerID uint64) (*interface{}, error) {
+	return service.link(fmt.Sprintf("/api/v2/tickets/%v/link", ID), trackerID)
+}
+
+func (service *freshDeskService) UnlinkTicket(ID uint64, trackerID uint64) (*interface{}, error) {
+	return service.link(fmt.Sprintf("/api/v2/tickets/%v/unlink", ID), trackerID)
+}
+
+func (service *freshDeskService) link(path string, trackerID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{"tracker_id": trackerID}).SetResult(&responseSchema).
+		Put(path)
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
 // Contact
 func (service *freshDeskService) GetContact(ID uint64) (*Contact, error) {
 
@@ -398,6 +687,40 @@ func (service *freshDeskService) FindContactByEmail(email string) (Contact, erro
 	return responseSchema.Results[0], nil
 }
 
+func (service *freshDeskService) FindContactByExternalID(externalID string) (Contact, error) {
+	return service.findContactBy("unique_external_id", externalID)
+}
+
+func (service *freshDeskService) FindContactByPhone(phone string) (Contact, error) {
+	return service.findContactBy("phone", phone)
+}
+
+// findContactBy returns the first contact matching one of the filters of
+// the contact list.
+func (service *freshDeskService) findContactBy(filter string, value string) (Contact, error) {
+
+	var responseSchema []Contact
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParam(filter, value).
+		Get("/api/v2/contacts")
+
+	if err != nil {
+		log.Println(err)
+		return Contact{}, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return Contact{}, errors.New(string(resp.Body()))
+	}
+
+	if len(responseSchema) == 0 {
+		return Contact{}, errors.New(ERR_CONTACT_NOT_FOUND)
+	}
+
+	return responseSchema[0], nil
+}
+
 func (service *freshDeskService) GetAllContacts() ([]ContactShort, error) {
 
 	var responseSchema []ContactShort
@@ -449,6 +772,59 @@ func (service *freshDeskService) GetAllContacts() ([]ContactShort, error) {
 	return responseAll, nil
 }
 
+func (service *freshDeskService) ListContacts(updatedSince *time.Time, pageSize, page int) ([]Contact, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	}
+	if updatedSince != nil {
+		params["_updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema []Contact
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/contacts")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body())), false
+	}
+
+	return responseSchema, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshDeskService) ListDeletedContacts(updatedSince *time.Time, pageSize, page int) ([]Contact, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+		"state":    "deleted",
+	}
+	if updatedSince != nil {
+		params["_updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema []Contact
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/contacts")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body())), false
+	}
+
+	return responseSchema, nil, resp.Header().Get("Link") != ""
+}
+
 func (service *freshDeskService) CreateContact(payload ContactCreatePayload) (*Contact, error) {
 	var responseSchema Contact
 	resp, err := service.restyClient.R().
@@ -523,7 +899,15 @@ func (service *freshDeskService) PermanentlyDeleteContact(ID uint64) (*interface
 }
 
 // Company
+func (service *freshDeskService) GetCompanyWithContactCount(ID uint64) (*Company, error) {
+	return service.GetCompanyExt(ID, true)
+}
+
 func (service *freshDeskService) GetCompany(ID uint64) (*Company, error) {
+	return service.GetCompanyExt(ID, false)
+}
+
+func (service *freshDeskService) GetCompanyExt(ID uint64, with_contact_count bool) (*Company, error) {
 	var responseSchema Company
 	resp, err := service.restyClient.R().
 		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
@@ -538,61 +922,178 @@ func (service *freshDeskService) GetCompany(ID uint64) (*Company, error) {
 		return nil, errors.New(string(resp.Body()))
 	}
 
+	if with_contact_count {
+		count, err := service.countCompanyContacts(ID)
+		if err != nil {
+			return nil, err
+		}
+		responseSchema.ContactCount = &count
+	}
+
 	return &responseSchema, nil
 }
 
 func (service *freshDeskService) GetAllCompanies() ([]Company, error) {
+	var responseAll []Company
+	next := "/api/v2/companies?per_page=100"
+
+	for next != "" {
+		var responseSchema []Company
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+// ListCompanies returns a page of companies. The companies endpoint has no
+// updated_since parameter, so the companies updated before updatedSince are
+// dropped from the page here: a page may come back short, or empty, while
+// more pages follow.
+func (service *freshDeskService) ListCompanies(updatedSince *time.Time, pageSize, page int) ([]Company, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	}
 
 	var responseSchema []Company
 	resp, err := service.restyClient.R().
 		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
 		Get("/api/v2/companies")
 
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, err, false
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, errors.New(string(resp.Body()))
+		return nil, errors.New(string(resp.Body())), false
 	}
 
-	return responseSchema, nil
-}
-
-func (service *freshDeskService) SearchCompanies(mask string) ([]CompanyName, error) {
+	if updatedSince != nil {
+		filtered := responseSchema[:0]
+		for _, company := range responseSchema {
+			if company.UpdatedAt == nil || !company.UpdatedAt.Before(*updatedSince) {
+				filtered = append(filtered, company)
+			}
+		}
+		responseSchema = filtered
+	}
 
-	var responseSchema SrchCompanyResp
-	resp, err := service.restyClient.R().
-		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
-		Get(fmt.Sprintf("%v%v", "/api/v2/companies/autocomplete?name=", mask))
+	return responseSchema, nil, resp.Header().Get("Link") != ""
+}
 
+func (service *freshDeskService) GetCompanyByName(name string) (*Company, error) {
+	candidates, err := service.SearchCompanies(url.QueryEscape(name))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 
-	if resp.StatusCode() != http.StatusOK {
-		return nil, errors.New(string(resp.Body()))
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.Name, name) {
+			return service.GetCompany(candidate.ID)
+		}
 	}
 
-	return responseSchema.CompanyNames, nil
+	return nil, errors.New(ERR_COMPANY_NOT_FOUND)
 }
 
-func (service *freshDeskService) CreateCompany(payload CompanyCreatePayload) (*Company, error) {
-
-	var responseSchema Company
+func (service *freshDeskService) GetCompanyByDomain(domain string) (*Company, error) {
+	var responseSchema SrchCompanyFullResp
 	resp, err := service.restyClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBody(payload).SetResult(&responseSchema).
-		Post("/api/v2/companies")
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/search/companies?query=\"domain:%s\"", url.QueryEscape("'"+strings.ToLower(domain)+"'")))
 
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	if resp.StatusCode() != http.StatusCreated {
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	if responseSchema.Total == 0 || len(responseSchema.Results) == 0 {
+		return nil, errors.New(ERR_COMPANY_NOT_FOUND)
+	}
+
+	return &responseSchema.Results[0], nil
+}
+
+// countCompanyContacts pages through the contacts of a company, the API has
+// no dedicated counter.
+func (service *freshDeskService) countCompanyContacts(ID uint64) (uint64, error) {
+	var count uint64
+	next := fmt.Sprintf("/api/v2/contacts?company_id=%v&per_page=100", ID)
+
+	for next != "" {
+		var responseSchema []ContactShort
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return 0, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return 0, errors.New(string(resp.Body()))
+		}
+
+		count += uint64(len(responseSchema))
+		next = nextPageLink(resp)
+	}
+
+	return count, nil
+}
+
+func (service *freshDeskService) SearchCompanies(mask string) ([]CompanyName, error) {
+
+	var responseSchema SrchCompanyResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/companies/autocomplete?name=", mask))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return responseSchema.CompanyNames, nil
+}
+
+func (service *freshDeskService) CreateCompany(payload CompanyCreatePayload) (*Company, error) {
+
+	var responseSchema Company
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/companies")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
 		return nil, errors.New(string(resp.Body()))
 	}
 
@@ -637,8 +1138,6 @@ func (service *freshDeskService) DeleteCompany(ID uint64) (*interface{}, error)
 }
 
 func (service *freshDeskService) GetTicketsByCompanyID(companyID, pageSize, page int) ([]Ticket, error, bool) {
-	service.rateLimiter.Take()
-
 	var responseSchema []Ticket
 	resp, err := service.restyClient.R().
 		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
@@ -742,6 +1241,62 @@ func (service *freshDeskService) GetAllGroups() ([]Group, error) {
 	return responseSchema, nil
 }
 
+func (service *freshDeskService) CreateGroup(payload GroupCreatePayload) (*Group, error) {
+	var responseSchema Group
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/admin/groups")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateGroup(ID uint64, payload GroupUpdatePayload) (*Group, error) {
+	var responseSchema Group
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/admin/groups/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteGroup(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/admin/groups/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
 func (service *freshDeskService) SearchCustomObjects(schema_id uint64, filter map[string]string) ([]CustomObject, error) {
 	var responseSchema CustomObjectSearchResp
 
@@ -801,3 +1356,1186 @@ func (service *freshDeskService) UpdateCustomObject(schema_id uint64, payload Cu
 
 	return &responseSchema, nil
 }
+
+// Solutions
+func (service *freshDeskService) GetAllSolutionCategories() ([]SolutionCategory, error) {
+	var responseSchema []SolutionCategory
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get("/api/v2/solutions/categories")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return responseSchema, nil
+}
+
+func (service *freshDeskService) CreateSolutionCategory(payload SolutionCategoryCreatePayload) (*SolutionCategory, error) {
+	var responseSchema SolutionCategory
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/solutions/categories")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) GetAllSolutionFolders(categoryID uint64) ([]SolutionFolder, error) {
+	var responseSchema []SolutionFolder
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/solutions/categories/%v/folders", categoryID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return responseSchema, nil
+}
+
+func (service *freshDeskService) CreateSolutionFolder(categoryID uint64, payload SolutionFolderCreatePayload) (*SolutionFolder, error) {
+	var responseSchema SolutionFolder
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/solutions/categories/%v/folders", categoryID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) GetAllSolutionArticles(folderID uint64) ([]SolutionArticle, error) {
+	var responseAll []SolutionArticle
+	next := fmt.Sprintf("/api/v2/solutions/folders/%v/articles", folderID)
+
+	for next != "" {
+		var responseSchema []SolutionArticle
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetSolutionArticle(ID uint64) (*SolutionArticle, error) {
+	var responseSchema SolutionArticle
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/solutions/articles/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateSolutionArticle(folderID uint64, payload SolutionArticleCreatePayload) (*SolutionArticle, error) {
+	var responseSchema SolutionArticle
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/solutions/folders/%v/articles", folderID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateSolutionArticle(ID uint64, payload SolutionArticleUpdatePayload) (*SolutionArticle, error) {
+	var responseSchema SolutionArticle
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/solutions/articles/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteSolutionArticle(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/solutions/articles/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// Products
+func (service *freshDeskService) ListProducts() ([]Product, error) {
+	var responseAll []Product
+	next := "/api/v2/products?per_page=100"
+
+	for next != "" {
+		var responseSchema []Product
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetProduct(ID uint64) (*Product, error) {
+	var responseSchema Product
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/products/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateProduct(payload ProductCreatePayload) (*Product, error) {
+	var responseSchema Product
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/products")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateProduct(ID uint64, payload ProductUpdatePayload) (*Product, error) {
+	var responseSchema Product
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/products/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// Email configs
+func (service *freshDeskService) ListEmailConfigs() ([]EmailConfig, error) {
+	var responseAll []EmailConfig
+	next := "/api/v2/email_configs?per_page=100"
+
+	for next != "" {
+		var responseSchema []EmailConfig
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetEmailConfig(ID uint64) (*EmailConfig, error) {
+	var responseSchema EmailConfig
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/email_configs/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// FindEmailConfigByEmail returns the email config receiving or replying
+// from the given support address.
+func (service *freshDeskService) FindEmailConfigByEmail(email string) (*EmailConfig, error) {
+	configs, err := service.ListEmailConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range configs {
+		if strings.EqualFold(configs[i].ToEmail, email) || strings.EqualFold(configs[i].ReplyEmail, email) {
+			return &configs[i], nil
+		}
+	}
+
+	return nil, errors.New(ERR_EMAIL_CONFIG_NOT_FOUND)
+}
+
+// Mailboxes
+func (service *freshDeskService) ListMailboxes() ([]Mailbox, error) {
+	var responseAll []Mailbox
+	next := "/api/v2/email/mailboxes?per_page=100"
+
+	for next != "" {
+		var responseSchema []Mailbox
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetMailbox(ID uint64) (*Mailbox, error) {
+	var responseSchema Mailbox
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/email/mailboxes/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateMailbox(payload MailboxCreatePayload) (*Mailbox, error) {
+	var responseSchema Mailbox
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/email/mailboxes")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateMailbox(ID uint64, payload MailboxUpdatePayload) (*Mailbox, error) {
+	var responseSchema Mailbox
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/email/mailboxes/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteMailbox(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/email/mailboxes/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// Business hours and SLA
+func (service *freshDeskService) ListBusinessHours() ([]BusinessHours, error) {
+	var responseAll []BusinessHours
+	next := "/api/v2/business_hours?per_page=100"
+
+	for next != "" {
+		var responseSchema []BusinessHours
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetBusinessHours(ID uint64) (*BusinessHours, error) {
+	var responseSchema BusinessHours
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/business_hours/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListSLAPolicies() ([]SLAPolicy, error) {
+	var responseAll []SLAPolicy
+	next := "/api/v2/sla_policies?per_page=100"
+
+	for next != "" {
+		var responseSchema []SLAPolicy
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) CreateSLAPolicy(payload SLAPolicyCreatePayload) (*SLAPolicy, error) {
+	var responseSchema SLAPolicy
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/sla_policies")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateSLAPolicy(ID uint64, payload SLAPolicyUpdatePayload) (*SLAPolicy, error) {
+	var responseSchema SLAPolicy
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/sla_policies/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// Roles, skills and availability
+func (service *freshDeskService) ListRoles() ([]Role, error) {
+	var responseAll []Role
+	next := "/api/v2/roles?per_page=100"
+
+	for next != "" {
+		var responseSchema []Role
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetRole(ID uint64) (*Role, error) {
+	var responseSchema Role
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/roles/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListSkills() ([]Skill, error) {
+	var responseAll []Skill
+	next := "/api/v2/admin/skills?per_page=100"
+
+	for next != "" {
+		var responseSchema []Skill
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetSkill(ID uint64) (*Skill, error) {
+	var responseSchema Skill
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/admin/skills/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateSkill(payload SkillCreatePayload) (*Skill, error) {
+	var responseSchema Skill
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/admin/skills")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateSkill(ID uint64, payload SkillUpdatePayload) (*Skill, error) {
+	var responseSchema Skill
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/admin/skills/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteSkill(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/admin/skills/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) GetAgentAvailability(agentID uint64) (*AgentAvailability, error) {
+	var responseSchema AgentAvailability
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/agents/%v/availability", agentID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateAgentAvailability(agentID uint64, payload AgentAvailabilityUpdatePayload) (*AgentAvailability, error) {
+	var responseSchema AgentAvailability
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/agents/%v/availability", agentID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListAgentAvailability() ([]AgentAvailability, error) {
+	var responseAll []AgentAvailability
+	next := "/api/v2/agents/availability?per_page=100"
+
+	for next != "" {
+		var responseSchema []AgentAvailability
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+// Automations, scenarios and ticket forms
+func (service *freshDeskService) ListAutomationRules(automationType AutomationType) ([]AutomationRule, error) {
+	var responseAll []AutomationRule
+	next := fmt.Sprintf("/api/v2/automations/%v/rules?per_page=100", automationType)
+
+	for next != "" {
+		var responseSchema []AutomationRule
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetAutomationRule(automationType AutomationType, ID uint64) (*AutomationRule, error) {
+	var responseSchema AutomationRule
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/automations/%v/rules/%v", automationType, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateAutomationRule(automationType AutomationType, payload AutomationRulePayload) (*AutomationRule, error) {
+	var responseSchema AutomationRule
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/automations/%v/rules", automationType))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateAutomationRule(automationType AutomationType, ID uint64, payload AutomationRulePayload) (*AutomationRule, error) {
+	var responseSchema AutomationRule
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/automations/%v/rules/%v", automationType, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteAutomationRule(automationType AutomationType, ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("/api/v2/automations/%v/rules/%v", automationType, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListScenarioAutomations() ([]ScenarioAutomation, error) {
+	var responseAll []ScenarioAutomation
+	next := "/api/v2/scenario_automations?per_page=100"
+
+	for next != "" {
+		var responseSchema []ScenarioAutomation
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) ExecuteScenarioAutomation(ticketID uint64, scenarioID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]uint64{"scenario_id": scenarioID}).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/tickets/%v/execute_scenario", ticketID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if (resp.StatusCode() != http.StatusOK) && (resp.StatusCode() != http.StatusNoContent) {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListTicketForms() ([]TicketForm, error) {
+	var responseAll []TicketForm
+	next := "/api/v2/ticket-forms?per_page=100"
+
+	for next != "" {
+		var responseSchema []TicketForm
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) GetTicketForm(ID uint64) (*TicketForm, error) {
+	var responseSchema TicketForm
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/ticket-forms/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) CreateTicketForm(payload TicketFormPayload) (*TicketForm, error) {
+	var responseSchema TicketForm
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/ticket-forms")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateTicketForm(ID uint64, payload TicketFormPayload) (*TicketForm, error) {
+	var responseSchema TicketForm
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/ticket-forms/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteTicketForm(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/ticket-forms/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// Ticket fields and canned responses
+func (service *freshDeskService) ListTicketFields() ([]TicketField, error) {
+	var responseAll []TicketField
+	next := "/api/v2/admin/ticket_fields?per_page=100"
+
+	for next != "" {
+		var responseSchema []TicketField
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) CreateTicketField(payload TicketFieldCreatePayload) (*TicketField, error) {
+	var responseSchema TicketField
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/admin/ticket_fields")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateTicketField(ID uint64, payload TicketFieldUpdatePayload) (*TicketField, error) {
+	var responseSchema TicketField
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/admin/ticket_fields/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) DeleteTicketField(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/admin/ticket_fields/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListCannedResponseFolders() ([]CannedResponseFolder, error) {
+	var responseAll []CannedResponseFolder
+	next := "/api/v2/canned_response_folders?per_page=100"
+
+	for next != "" {
+		var responseSchema []CannedResponseFolder
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) CreateCannedResponseFolder(payload CannedResponseFolderCreatePayload) (*CannedResponseFolder, error) {
+	var responseSchema CannedResponseFolder
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/canned_response_folders")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) ListCannedResponses(folderID uint64) ([]CannedResponse, error) {
+	var responseAll []CannedResponse
+	next := fmt.Sprintf("/api/v2/canned_response_folders/%v/responses?per_page=100", folderID)
+
+	for next != "" {
+		var responseSchema []CannedResponse
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, errors.New(string(resp.Body()))
+		}
+
+		responseAll = append(responseAll, responseSchema...)
+		next = nextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshDeskService) CreateCannedResponse(payload CannedResponseCreatePayload) (*CannedResponse, error) {
+	var responseSchema CannedResponse
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/canned_responses")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshDeskService) UpdateCannedResponse(ID uint64, payload CannedResponseUpdatePayload) (*CannedResponse, error) {
+	var responseSchema CannedResponse
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/canned_responses/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+
+	return &responseSchema, nil
+}
+
+// nextPageLink returns the URL of the next page announced in the Link
+// response header, or an empty string on the last page.
+// link: <https://domain.freshdesk.com/api/v2/contacts?page=2>; rel="next"
+func nextPageLink(resp *resty.Response) string {
+	return rest.NextPageLink(resp)
+}
@@ -0,0 +1,422 @@
+package freshdesk
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	object  = `{"id":7,"name":"seven"}`
+	list    = `[{"id":7,"name":"seven"}]`
+	invalid = `{"description":"Validation failed","errors":[{"field":"email","message":"It should be a valid email address","code":"invalid_value"}]}`
+)
+
+// methodCase describes a client method issuing a single request.
+type methodCase struct {
+	name   string
+	method string
+	path   string
+	status int    // Status of a successful answer
+	body   string // Body of a successful answer
+	// emptyErr is the error expected when the successful answer has no
+	// body, none when empty.
+	emptyErr string
+	// before registers the answers to the requests the method sends ahead
+	// of the one tested, if any.
+	before func(stub *stubServer)
+	call   func(service *freshDeskService) (interface{}, error)
+}
+
+func paged[T any](items []T, err error, _ bool) ([]T, error) {
+	return items, err
+}
+
+var methodCases = []methodCase{
+	// Tickets
+	{name: "GetTicket", method: "GET", path: "/api/v2/tickets/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetTicket(7) }},
+	{name: "GetTicketWithConversations", method: "GET", path: "/api/v2/tickets/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetTicketWithConversations(7) }},
+	{name: "GetTicketExt", method: "GET", path: "/api/v2/tickets/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetTicketExt(7, true) }},
+	{name: "GetAllTicketConversations", method: "GET", path: "/api/v2/tickets/7/conversations", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllTicketConversations(7) }},
+	{name: "GetAllTickets", method: "GET", path: "/api/v2/tickets", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllTickets() }},
+	{name: "GetTicketsByCompanyID", method: "GET", path: "/api/v2/tickets", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.GetTicketsByCompanyID(7, 30, 1)) }},
+	{name: "ListTickets", method: "GET", path: "/api/v2/tickets", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.ListTickets(nil, 30, 1)) }},
+	{name: "ListDeletedTickets", method: "GET", path: "/api/v2/tickets", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.ListDeletedTickets(nil, 30, 1)) }},
+	{name: "CreateTicket", method: "POST", path: "/api/v2/tickets", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateTicket(TicketCreatePayload{}) }},
+	{name: "CreateSdTicket", method: "POST", path: "/api/v2/tickets", status: 201, body: `{"ticket":` + object + `}`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateSdTicket(SdTicketCreatePayload{}) }},
+	{name: "UpdateTicket", method: "PUT", path: "/api/v2/tickets/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateTicket(7, TicketUpdatePayload{}) }},
+	{name: "UpdateTicketStatus", method: "PUT", path: "/api/v2/tickets/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateTicketStatus(7, TicketStatusUpdatePayload{})
+		}},
+	{name: "CreateTicketMessage", method: "POST", path: "/api/v2/tickets/7/reply", status: 201, body: `{"id":7,"body":"hi"}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateTicketMessage(7, TicketMessageCreatePayload{})
+		}},
+	{name: "CreateSdTicketMessage", method: "POST", path: "/api/v2/tickets/7/reply", status: 201, body: `{"conversation":{"id":7,"body":"hi"}}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateSdTicketMessage(7, TicketMessageCreatePayload{})
+		}},
+	{name: "DeleteTicket", method: "DELETE", path: "/api/v2/tickets/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteTicket(7) }},
+	{name: "AddWatcher", method: "POST", path: "/api/v2/tickets/7/watch", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.AddWatcher(7, 3) }},
+	{name: "RemoveWatcher", method: "PUT", path: "/api/v2/tickets/7/unwatch", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.RemoveWatcher(7, 3) }},
+	{name: "ListWatchers", method: "GET", path: "/api/v2/tickets/7/watchers", status: 200, body: `{"watcher_ids":[3,4]}`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListWatchers(7) }},
+	{name: "CreateChildTicket", method: "POST", path: "/api/v2/tickets", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateChildTicket(3, TicketCreatePayload{}) }},
+	{name: "GetAssociatedTickets", method: "GET", path: "/api/v2/tickets/7/associated_tickets", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAssociatedTickets(7) }},
+	{name: "LinkTicket", method: "PUT", path: "/api/v2/tickets/7/link", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.LinkTicket(7, 3) }},
+	{name: "UnlinkTicket", method: "PUT", path: "/api/v2/tickets/7/unlink", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UnlinkTicket(7, 3) }},
+	{name: "ExecuteScenarioAutomation", method: "PUT", path: "/api/v2/tickets/7/execute_scenario", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ExecuteScenarioAutomation(7, 3) }},
+
+	// Contacts
+	{name: "GetContact", method: "GET", path: "/api/v2/contacts/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetContact(7) }},
+	{name: "FindContactByEmail", method: "GET", path: "/api/v2/search/contacts", status: 200, body: `{"total":1,"results":[` + object + `]}`,
+		emptyErr: ERR_CONTACT_NOT_FOUND,
+		call:     func(s *freshDeskService) (interface{}, error) { return s.FindContactByEmail("jane@example.com") }},
+	{name: "FindContactByExternalID", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		emptyErr: ERR_CONTACT_NOT_FOUND,
+		call:     func(s *freshDeskService) (interface{}, error) { return s.FindContactByExternalID("ext-7") }},
+	{name: "FindContactByPhone", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		emptyErr: ERR_CONTACT_NOT_FOUND,
+		call:     func(s *freshDeskService) (interface{}, error) { return s.FindContactByPhone("+15550100") }},
+	{name: "GetAllContacts", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllContacts() }},
+	{name: "ListContacts", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.ListContacts(nil, 30, 1)) }},
+	{name: "ListDeletedContacts", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.ListDeletedContacts(nil, 30, 1)) }},
+	{name: "CreateContact", method: "POST", path: "/api/v2/contacts", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateContact(ContactCreatePayload{}) }},
+	{name: "UpdateContact", method: "PUT", path: "/api/v2/contacts/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateContact(7, ContactUpdatePayload{}) }},
+	{name: "SoftDeleteContact", method: "DELETE", path: "/api/v2/contacts/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.SoftDeleteContact(7) }},
+	{name: "PermanentlyDeleteContact", method: "DELETE", path: "/api/v2/contacts/7/hard_delete", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.PermanentlyDeleteContact(7) }},
+	{name: "AddOtherCompanyForContact", method: "PUT", path: "/api/v2/contacts/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.AddOtherCompanyForContact(&Contact{ID: 7}, 3, true)
+		}},
+	{name: "AddMainCompanyForContact", method: "PUT", path: "/api/v2/contacts/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.AddMainCompanyForContact(&Contact{ID: 7}, 3) }},
+
+	// Companies
+	{name: "GetCompany", method: "GET", path: "/api/v2/companies/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetCompany(7) }},
+	{name: "GetCompanyExt", method: "GET", path: "/api/v2/contacts", status: 200, body: list,
+		before: func(stub *stubServer) {
+			stub.reply("GET", "/api/v2/companies/7", 200, object)
+		},
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetCompanyExt(7, true) }},
+	{name: "GetCompanyByDomain", method: "GET", path: "/api/v2/search/companies", status: 200, body: `{"total":1,"results":[` + object + `]}`,
+		emptyErr: ERR_COMPANY_NOT_FOUND,
+		call:     func(s *freshDeskService) (interface{}, error) { return s.GetCompanyByDomain("example.com") }},
+	{name: "GetCompanyByName", method: "GET", path: "/api/v2/companies/7", status: 200, body: object,
+		before: func(stub *stubServer) {
+			stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[`+object+`]}`)
+		},
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetCompanyByName("Seven") }},
+	{name: "GetAllCompanies", method: "GET", path: "/api/v2/companies", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllCompanies() }},
+	{name: "ListCompanies", method: "GET", path: "/api/v2/companies", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return paged(s.ListCompanies(nil, 30, 1)) }},
+	{name: "SearchCompanies", method: "GET", path: "/api/v2/companies/autocomplete", status: 200, body: `{"companies":[` + object + `]}`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.SearchCompanies("sev") }},
+	{name: "CreateCompany", method: "POST", path: "/api/v2/companies", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateCompany(CompanyCreatePayload{}) }},
+	{name: "UpdateCompany", method: "PUT", path: "/api/v2/companies/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateCompany(7, CompanyUpdatePayload{}) }},
+	{name: "DeleteCompany", method: "DELETE", path: "/api/v2/companies/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteCompany(7) }},
+
+	// Groups
+	{name: "GetAllGroups", method: "GET", path: "/api/v2/admin/groups", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllGroups() }},
+	{name: "CreateGroup", method: "POST", path: "/api/v2/admin/groups", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateGroup(GroupCreatePayload{}) }},
+	{name: "UpdateGroup", method: "PUT", path: "/api/v2/admin/groups/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateGroup(7, GroupUpdatePayload{}) }},
+	{name: "DeleteGroup", method: "DELETE", path: "/api/v2/admin/groups/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteGroup(7) }},
+
+	// Custom objects
+	{name: "SearchCustomObjects", method: "GET", path: "/api/v2/custom_objects/schemas/3/records", status: 200, body: `{"records":[{"display_id":"7"}]}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.SearchCustomObjects(3, map[string]string{"name": "seven"})
+		}},
+	{name: "CreateCustomObject", method: "POST", path: "/api/v2/custom_objects/schemas/3/records", status: 201, body: `{"display_id":"7"}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateCustomObject(3, map[string]interface{}{"data": map[string]interface{}{"name": "seven"}})
+		}},
+	{name: "UpdateCustomObject", method: "PUT", path: "/api/v2/custom_objects/schemas/3/records/7", status: 200, body: `{"display_id":"7"}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateCustomObject(3, CustomObjectUpdatePayload{DisplayID: "7"})
+		}},
+
+	// Solutions
+	{name: "GetAllSolutionCategories", method: "GET", path: "/api/v2/solutions/categories", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllSolutionCategories() }},
+	{name: "CreateSolutionCategory", method: "POST", path: "/api/v2/solutions/categories", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateSolutionCategory(SolutionCategoryCreatePayload{})
+		}},
+	{name: "GetAllSolutionFolders", method: "GET", path: "/api/v2/solutions/categories/3/folders", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllSolutionFolders(3) }},
+	{name: "CreateSolutionFolder", method: "POST", path: "/api/v2/solutions/categories/3/folders", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateSolutionFolder(3, SolutionFolderCreatePayload{})
+		}},
+	{name: "GetAllSolutionArticles", method: "GET", path: "/api/v2/solutions/folders/3/articles", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAllSolutionArticles(3) }},
+	{name: "GetSolutionArticle", method: "GET", path: "/api/v2/solutions/articles/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetSolutionArticle(7) }},
+	{name: "CreateSolutionArticle", method: "POST", path: "/api/v2/solutions/folders/3/articles", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateSolutionArticle(3, SolutionArticleCreatePayload{})
+		}},
+	{name: "UpdateSolutionArticle", method: "PUT", path: "/api/v2/solutions/articles/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateSolutionArticle(7, SolutionArticleUpdatePayload{})
+		}},
+	{name: "DeleteSolutionArticle", method: "DELETE", path: "/api/v2/solutions/articles/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteSolutionArticle(7) }},
+
+	// Products, email configs and mailboxes
+	{name: "ListProducts", method: "GET", path: "/api/v2/products", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListProducts() }},
+	{name: "GetProduct", method: "GET", path: "/api/v2/products/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetProduct(7) }},
+	{name: "CreateProduct", method: "POST", path: "/api/v2/products", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateProduct(ProductCreatePayload{}) }},
+	{name: "UpdateProduct", method: "PUT", path: "/api/v2/products/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateProduct(7, ProductUpdatePayload{}) }},
+	{name: "ListEmailConfigs", method: "GET", path: "/api/v2/email_configs", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListEmailConfigs() }},
+	{name: "GetEmailConfig", method: "GET", path: "/api/v2/email_configs/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetEmailConfig(7) }},
+	{name: "FindEmailConfigByEmail", method: "GET", path: "/api/v2/email_configs", status: 200, body: `[{"id":7,"to_email":"support@example.com"}]`,
+		emptyErr: ERR_EMAIL_CONFIG_NOT_FOUND,
+		call:     func(s *freshDeskService) (interface{}, error) { return s.FindEmailConfigByEmail("Support@example.com") }},
+	{name: "ListMailboxes", method: "GET", path: "/api/v2/email/mailboxes", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListMailboxes() }},
+	{name: "GetMailbox", method: "GET", path: "/api/v2/email/mailboxes/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetMailbox(7) }},
+	{name: "CreateMailbox", method: "POST", path: "/api/v2/email/mailboxes", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateMailbox(MailboxCreatePayload{}) }},
+	{name: "UpdateMailbox", method: "PUT", path: "/api/v2/email/mailboxes/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateMailbox(7, MailboxUpdatePayload{}) }},
+	{name: "DeleteMailbox", method: "DELETE", path: "/api/v2/email/mailboxes/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteMailbox(7) }},
+
+	// Business hours and SLA policies
+	{name: "ListBusinessHours", method: "GET", path: "/api/v2/business_hours", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListBusinessHours() }},
+	{name: "GetBusinessHours", method: "GET", path: "/api/v2/business_hours/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetBusinessHours(7) }},
+	{name: "ListSLAPolicies", method: "GET", path: "/api/v2/sla_policies", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListSLAPolicies() }},
+	{name: "CreateSLAPolicy", method: "POST", path: "/api/v2/sla_policies", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateSLAPolicy(SLAPolicyCreatePayload{}) }},
+	{name: "UpdateSLAPolicy", method: "PUT", path: "/api/v2/sla_policies/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateSLAPolicy(7, SLAPolicyUpdatePayload{}) }},
+
+	// Roles, skills and availability
+	{name: "ListRoles", method: "GET", path: "/api/v2/roles", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListRoles() }},
+	{name: "GetRole", method: "GET", path: "/api/v2/roles/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetRole(7) }},
+	{name: "ListSkills", method: "GET", path: "/api/v2/admin/skills", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListSkills() }},
+	{name: "GetSkill", method: "GET", path: "/api/v2/admin/skills/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetSkill(7) }},
+	{name: "CreateSkill", method: "POST", path: "/api/v2/admin/skills", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateSkill(SkillCreatePayload{}) }},
+	{name: "UpdateSkill", method: "PUT", path: "/api/v2/admin/skills/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateSkill(7, SkillUpdatePayload{}) }},
+	{name: "DeleteSkill", method: "DELETE", path: "/api/v2/admin/skills/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteSkill(7) }},
+	{name: "GetAgentAvailability", method: "GET", path: "/api/v2/agents/7/availability", status: 200, body: `{"agent_id":7}`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAgentAvailability(7) }},
+	{name: "UpdateAgentAvailability", method: "PUT", path: "/api/v2/agents/7/availability", status: 200, body: `{"agent_id":7}`,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateAgentAvailability(7, AgentAvailabilityUpdatePayload{})
+		}},
+	{name: "ListAgentAvailability", method: "GET", path: "/api/v2/agents/availability", status: 200, body: `[{"agent_id":7}]`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListAgentAvailability() }},
+
+	// Automations and forms
+	{name: "ListAutomationRules", method: "GET", path: "/api/v2/automations/4/rules", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListAutomationRules(AutomationTicketUpdates) }},
+	{name: "GetAutomationRule", method: "GET", path: "/api/v2/automations/4/rules/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetAutomationRule(AutomationTicketUpdates, 7) }},
+	{name: "CreateAutomationRule", method: "POST", path: "/api/v2/automations/1/rules", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateAutomationRule(AutomationTicketCreation, AutomationRulePayload{})
+		}},
+	{name: "UpdateAutomationRule", method: "PUT", path: "/api/v2/automations/3/rules/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateAutomationRule(AutomationTimeTriggers, 7, AutomationRulePayload{})
+		}},
+	{name: "DeleteAutomationRule", method: "DELETE", path: "/api/v2/automations/4/rules/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.DeleteAutomationRule(AutomationTicketUpdates, 7)
+		}},
+	{name: "ListScenarioAutomations", method: "GET", path: "/api/v2/scenario_automations", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListScenarioAutomations() }},
+	{name: "ListTicketForms", method: "GET", path: "/api/v2/ticket-forms", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListTicketForms() }},
+	{name: "GetTicketForm", method: "GET", path: "/api/v2/ticket-forms/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.GetTicketForm(7) }},
+	{name: "CreateTicketForm", method: "POST", path: "/api/v2/ticket-forms", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.CreateTicketForm(TicketFormPayload{}) }},
+	{name: "UpdateTicketForm", method: "PUT", path: "/api/v2/ticket-forms/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) { return s.UpdateTicketForm(7, TicketFormPayload{}) }},
+	{name: "DeleteTicketForm", method: "DELETE", path: "/api/v2/ticket-forms/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteTicketForm(7) }},
+
+	// Ticket fields and canned responses
+	{name: "ListTicketFields", method: "GET", path: "/api/v2/admin/ticket_fields", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListTicketFields() }},
+	{name: "CreateTicketField", method: "POST", path: "/api/v2/admin/ticket_fields", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateTicketField(TicketFieldCreatePayload{})
+		}},
+	{name: "UpdateTicketField", method: "PUT", path: "/api/v2/admin/ticket_fields/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateTicketField(7, TicketFieldUpdatePayload{})
+		}},
+	{name: "DeleteTicketField", method: "DELETE", path: "/api/v2/admin/ticket_fields/7", status: 204,
+		call: func(s *freshDeskService) (interface{}, error) { return s.DeleteTicketField(7) }},
+	{name: "ListCannedResponseFolders", method: "GET", path: "/api/v2/canned_response_folders", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListCannedResponseFolders() }},
+	{name: "CreateCannedResponseFolder", method: "POST", path: "/api/v2/canned_response_folders", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateCannedResponseFolder(CannedResponseFolderCreatePayload{})
+		}},
+	{name: "ListCannedResponses", method: "GET", path: "/api/v2/canned_response_folders/3/responses", status: 200, body: list,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListCannedResponses(3) }},
+	{name: "CreateCannedResponse", method: "POST", path: "/api/v2/canned_responses", status: 201, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.CreateCannedResponse(CannedResponseCreatePayload{})
+		}},
+	{name: "UpdateCannedResponse", method: "PUT", path: "/api/v2/canned_responses/7", status: 200, body: object,
+		call: func(s *freshDeskService) (interface{}, error) {
+			return s.UpdateCannedResponse(7, CannedResponseUpdatePayload{})
+		}},
+	{name: "ListTicketActivityExports", method: "GET", path: "/api/v2/export/ticket_activities", status: 200, body: `{"export":[{"url":"https://example.com/1.json"}]}`,
+		call: func(s *freshDeskService) (interface{}, error) { return s.ListTicketActivityExports(time.Now()) }},
+}
+
+func TestMethods(t *testing.T) {
+	for _, tc := range methodCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("success", func(t *testing.T) {
+				stub := newStubServer(t)
+				if tc.before != nil {
+					tc.before(stub)
+				}
+				stub.reply(tc.method, tc.path, tc.status, tc.body)
+
+				result, err := tc.call(stub.client())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if tc.body != "" && isEmpty(result) {
+					t.Errorf("got empty result %#v", result)
+				}
+
+				requests := stub.received()
+				if len(requests) == 0 {
+					t.Fatal("no request sent")
+				}
+				last := requests[len(requests)-1]
+				if last.Method != tc.method || last.Path != tc.path {
+					t.Errorf("sent %s %s, want %s %s", last.Method, last.Path, tc.method, tc.path)
+				}
+				if got := last.Header.Get("Authorization"); !strings.HasPrefix(got, "Basic ") {
+					t.Errorf("Authorization header = %q", got)
+				}
+			})
+
+			t.Run("error body", func(t *testing.T) {
+				stub := newStubServer(t)
+				if tc.before != nil {
+					tc.before(stub)
+				}
+				stub.reply(tc.method, tc.path, http.StatusBadRequest, invalid)
+
+				_, err := tc.call(stub.client())
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if !strings.Contains(err.Error(), "Validation failed") {
+					t.Errorf("error %q does not carry the response body", err)
+				}
+			})
+
+			t.Run("empty response", func(t *testing.T) {
+				stub := newStubServer(t)
+				if tc.before != nil {
+					tc.before(stub)
+				}
+				stub.reply(tc.method, tc.path, tc.status, "")
+
+				_, err := tc.call(stub.client())
+				switch {
+				case tc.emptyErr == "" && err != nil:
+					t.Errorf("unexpected error: %v", err)
+				case tc.emptyErr != "" && (err == nil || err.Error() != tc.emptyErr):
+					t.Errorf("got error %v, want %q", err, tc.emptyErr)
+				}
+			})
+
+			t.Run("transport error", func(t *testing.T) {
+				if _, err := tc.call(closedClient(t)); err == nil {
+					t.Fatal("expected an error")
+				}
+			})
+		})
+	}
+}
+
+// isEmpty reports whether a method result holds nothing, looking through
+// pointers.
+func isEmpty(result interface{}) bool {
+	v := reflect.ValueOf(result)
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return true
+	}
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
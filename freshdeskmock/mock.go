@@ -0,0 +1,1306 @@
+// Code generated by freshdeskmock/gen from the Client interface. DO NOT EDIT.
+
+package freshdeskmock
+
+import (
+	"sync"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+var _ freshdesk.Client = (*Client)(nil)
+
+// Client is a mock of freshdesk.Client. Every method records its call and
+// then calls the field named after it with a Func suffix, or returns zero
+// values when that field is nil.
+type Client struct {
+	mu    sync.Mutex
+	calls []Call
+
+	PutCustomDataFunc                 func(header [2]string, body string, path string) (string, int, error)
+	GetTicketFunc                     func(ID uint64) (*freshdesk.Ticket, error)
+	GetTicketWithConversationsFunc    func(ID uint64) (*freshdesk.Ticket, error)
+	GetAllTicketConversationsFunc     func(ID uint64) ([]freshdesk.TicketMessage, error)
+	GetAllTicketsFunc                 func() ([]freshdesk.Ticket, error)
+	GetTicketsByCompanyIDFunc         func(companyID int, pageSize int, page int) ([]freshdesk.Ticket, error, bool)
+	ListTicketsFunc                   func(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Ticket, error, bool)
+	ListDeletedTicketsFunc            func(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Ticket, error, bool)
+	CreateTicketFunc                  func(payload freshdesk.TicketCreatePayload) (*freshdesk.Ticket, error)
+	CreateSdTicketFunc                func(payload freshdesk.SdTicketCreatePayload) (*freshdesk.Ticket, error)
+	CreateTicketWithAttachmentsFunc   func(payload freshdesk.TicketCreatePayload, files []freshdesk.Attachment) (*freshdesk.Ticket, error)
+	CreateSdTicketWithAttachmentsFunc func(payload freshdesk.SdTicketCreatePayload, files []freshdesk.Attachment) (*freshdesk.Ticket, error)
+	UpdateTicketFunc                  func(ID uint64, payload freshdesk.TicketUpdatePayload) (*freshdesk.Ticket, error)
+	UpdateTicketStatusFunc            func(ID uint64, payload freshdesk.TicketStatusUpdatePayload) (*freshdesk.Ticket, error)
+	CreateTicketMessageFunc           func(ID uint64, payload freshdesk.TicketMessageCreatePayload) (*freshdesk.TicketMessage, error)
+	CreateSdTicketMessageFunc         func(ID uint64, payload freshdesk.TicketMessageCreatePayload) (*freshdesk.TicketMessage, error)
+	DeleteTicketFunc                  func(ID uint64) (*interface{}, error)
+	AddWatcherFunc                    func(ID uint64, userID uint64) (*interface{}, error)
+	RemoveWatcherFunc                 func(ID uint64, userID uint64) (*interface{}, error)
+	ListWatchersFunc                  func(ID uint64) ([]uint64, error)
+	CreateChildTicketFunc             func(parentID uint64, payload freshdesk.TicketCreatePayload) (*freshdesk.Ticket, error)
+	GetAssociatedTicketsFunc          func(ID uint64) ([]freshdesk.Ticket, error)
+	LinkTicketFunc                    func(ID uint64, trackerID uint64) (*interface{}, error)
+	UnlinkTicketFunc                  func(ID uint64, trackerID uint64) (*interface{}, error)
+	FindContactByEmailFunc            func(email string) (freshdesk.Contact, error)
+	FindContactByExternalIDFunc       func(externalID string) (freshdesk.Contact, error)
+	FindContactByPhoneFunc            func(phone string) (freshdesk.Contact, error)
+	UpsertContactFunc                 func(key freshdesk.UpsertKey, payload freshdesk.ContactCreatePayload) (*freshdesk.Contact, freshdesk.UpsertResult, error)
+	GetContactFunc                    func(ID uint64) (*freshdesk.Contact, error)
+	GetAllContactsFunc                func() ([]freshdesk.ContactShort, error)
+	ListContactsFunc                  func(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Contact, error, bool)
+	ListDeletedContactsFunc           func(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Contact, error, bool)
+	CreateContactFunc                 func(payload freshdesk.ContactCreatePayload) (*freshdesk.Contact, error)
+	UpdateContactFunc                 func(ID uint64, payload freshdesk.ContactUpdatePayload) (*freshdesk.Contact, error)
+	SoftDeleteContactFunc             func(ID uint64) (*interface{}, error)
+	PermanentlyDeleteContactFunc      func(ID uint64) (*interface{}, error)
+	AddOtherCompanyForContactFunc     func(fd_contact *freshdesk.Contact, id_client uint64, view_all bool) (bool, error)
+	AddMainCompanyForContactFunc      func(fd_contact *freshdesk.Contact, id_client uint64) (bool, error)
+	GetCompanyFunc                    func(ID uint64) (*freshdesk.Company, error)
+	GetCompanyWithContactCountFunc    func(ID uint64) (*freshdesk.Company, error)
+	GetCompanyByNameFunc              func(name string) (*freshdesk.Company, error)
+	GetCompanyByDomainFunc            func(domain string) (*freshdesk.Company, error)
+	GetAllCompaniesFunc               func() ([]freshdesk.Company, error)
+	ListCompaniesFunc                 func(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Company, error, bool)
+	SearchCompaniesFunc               func(mask string) ([]freshdesk.CompanyName, error)
+	CreateCompanyFunc                 func(payload freshdesk.CompanyCreatePayload) (*freshdesk.Company, error)
+	UpdateCompanyFunc                 func(ID uint64, payload freshdesk.CompanyUpdatePayload) (*freshdesk.Company, error)
+	DeleteCompanyFunc                 func(ID uint64) (*interface{}, error)
+	UpsertCompanyFunc                 func(key freshdesk.UpsertKey, payload freshdesk.CompanyCreatePayload) (*freshdesk.Company, freshdesk.UpsertResult, error)
+	GetAllGroupsFunc                  func() ([]freshdesk.Group, error)
+	CreateGroupFunc                   func(payload freshdesk.GroupCreatePayload) (*freshdesk.Group, error)
+	UpdateGroupFunc                   func(ID uint64, payload freshdesk.GroupUpdatePayload) (*freshdesk.Group, error)
+	DeleteGroupFunc                   func(ID uint64) (*interface{}, error)
+	ListProductsFunc                  func() ([]freshdesk.Product, error)
+	GetProductFunc                    func(ID uint64) (*freshdesk.Product, error)
+	CreateProductFunc                 func(payload freshdesk.ProductCreatePayload) (*freshdesk.Product, error)
+	UpdateProductFunc                 func(ID uint64, payload freshdesk.ProductUpdatePayload) (*freshdesk.Product, error)
+	ListEmailConfigsFunc              func() ([]freshdesk.EmailConfig, error)
+	GetEmailConfigFunc                func(ID uint64) (*freshdesk.EmailConfig, error)
+	FindEmailConfigByEmailFunc        func(email string) (*freshdesk.EmailConfig, error)
+	ListMailboxesFunc                 func() ([]freshdesk.Mailbox, error)
+	GetMailboxFunc                    func(ID uint64) (*freshdesk.Mailbox, error)
+	CreateMailboxFunc                 func(payload freshdesk.MailboxCreatePayload) (*freshdesk.Mailbox, error)
+	UpdateMailboxFunc                 func(ID uint64, payload freshdesk.MailboxUpdatePayload) (*freshdesk.Mailbox, error)
+	DeleteMailboxFunc                 func(ID uint64) (*interface{}, error)
+	ListBusinessHoursFunc             func() ([]freshdesk.BusinessHours, error)
+	GetBusinessHoursFunc              func(ID uint64) (*freshdesk.BusinessHours, error)
+	ListSLAPoliciesFunc               func() ([]freshdesk.SLAPolicy, error)
+	CreateSLAPolicyFunc               func(payload freshdesk.SLAPolicyCreatePayload) (*freshdesk.SLAPolicy, error)
+	UpdateSLAPolicyFunc               func(ID uint64, payload freshdesk.SLAPolicyUpdatePayload) (*freshdesk.SLAPolicy, error)
+	ListRolesFunc                     func() ([]freshdesk.Role, error)
+	GetRoleFunc                       func(ID uint64) (*freshdesk.Role, error)
+	ListSkillsFunc                    func() ([]freshdesk.Skill, error)
+	GetSkillFunc                      func(ID uint64) (*freshdesk.Skill, error)
+	CreateSkillFunc                   func(payload freshdesk.SkillCreatePayload) (*freshdesk.Skill, error)
+	UpdateSkillFunc                   func(ID uint64, payload freshdesk.SkillUpdatePayload) (*freshdesk.Skill, error)
+	DeleteSkillFunc                   func(ID uint64) (*interface{}, error)
+	GetAgentAvailabilityFunc          func(agentID uint64) (*freshdesk.AgentAvailability, error)
+	UpdateAgentAvailabilityFunc       func(agentID uint64, payload freshdesk.AgentAvailabilityUpdatePayload) (*freshdesk.AgentAvailability, error)
+	ListAgentAvailabilityFunc         func() ([]freshdesk.AgentAvailability, error)
+	ListAutomationRulesFunc           func(automationType freshdesk.AutomationType) ([]freshdesk.AutomationRule, error)
+	GetAutomationRuleFunc             func(automationType freshdesk.AutomationType, ID uint64) (*freshdesk.AutomationRule, error)
+	CreateAutomationRuleFunc          func(automationType freshdesk.AutomationType, payload freshdesk.AutomationRulePayload) (*freshdesk.AutomationRule, error)
+	UpdateAutomationRuleFunc          func(automationType freshdesk.AutomationType, ID uint64, payload freshdesk.AutomationRulePayload) (*freshdesk.AutomationRule, error)
+	DeleteAutomationRuleFunc          func(automationType freshdesk.AutomationType, ID uint64) (*interface{}, error)
+	ListScenarioAutomationsFunc       func() ([]freshdesk.ScenarioAutomation, error)
+	ExecuteScenarioAutomationFunc     func(ticketID uint64, scenarioID uint64) (*interface{}, error)
+	ListTicketFormsFunc               func() ([]freshdesk.TicketForm, error)
+	GetTicketFormFunc                 func(ID uint64) (*freshdesk.TicketForm, error)
+	CreateTicketFormFunc              func(payload freshdesk.TicketFormPayload) (*freshdesk.TicketForm, error)
+	UpdateTicketFormFunc              func(ID uint64, payload freshdesk.TicketFormPayload) (*freshdesk.TicketForm, error)
+	DeleteTicketFormFunc              func(ID uint64) (*interface{}, error)
+	ListTicketFieldsFunc              func() ([]freshdesk.TicketField, error)
+	CreateTicketFieldFunc             func(payload freshdesk.TicketFieldCreatePayload) (*freshdesk.TicketField, error)
+	UpdateTicketFieldFunc             func(ID uint64, payload freshdesk.TicketFieldUpdatePayload) (*freshdesk.TicketField, error)
+	DeleteTicketFieldFunc             func(ID uint64) (*interface{}, error)
+	ListCannedResponseFoldersFunc     func() ([]freshdesk.CannedResponseFolder, error)
+	CreateCannedResponseFolderFunc    func(payload freshdesk.CannedResponseFolderCreatePayload) (*freshdesk.CannedResponseFolder, error)
+	ListCannedResponsesFunc           func(folderID uint64) ([]freshdesk.CannedResponse, error)
+	CreateCannedResponseFunc          func(payload freshdesk.CannedResponseCreatePayload) (*freshdesk.CannedResponse, error)
+	UpdateCannedResponseFunc          func(ID uint64, payload freshdesk.CannedResponseUpdatePayload) (*freshdesk.CannedResponse, error)
+	SearchCustomObjectsFunc           func(SchemaID uint64, filter map[string]string) ([]freshdesk.CustomObject, error)
+	CreateCustomObjectFunc            func(schema_id uint64, data map[string]interface{}) (*freshdesk.CustomObjectUpdateResult, error)
+	UpdateCustomObjectFunc            func(schema_id uint64, payload freshdesk.CustomObjectUpdatePayload) (*freshdesk.CustomObjectUpdateResult, error)
+	GetAllSolutionCategoriesFunc      func() ([]freshdesk.SolutionCategory, error)
+	CreateSolutionCategoryFunc        func(payload freshdesk.SolutionCategoryCreatePayload) (*freshdesk.SolutionCategory, error)
+	GetAllSolutionFoldersFunc         func(categoryID uint64) ([]freshdesk.SolutionFolder, error)
+	CreateSolutionFolderFunc          func(categoryID uint64, payload freshdesk.SolutionFolderCreatePayload) (*freshdesk.SolutionFolder, error)
+	GetAllSolutionArticlesFunc        func(folderID uint64) ([]freshdesk.SolutionArticle, error)
+	GetSolutionArticleFunc            func(ID uint64) (*freshdesk.SolutionArticle, error)
+	CreateSolutionArticleFunc         func(folderID uint64, payload freshdesk.SolutionArticleCreatePayload) (*freshdesk.SolutionArticle, error)
+	UpdateSolutionArticleFunc         func(ID uint64, payload freshdesk.SolutionArticleUpdatePayload) (*freshdesk.SolutionArticle, error)
+	DeleteSolutionArticleFunc         func(ID uint64) (*interface{}, error)
+	ListTicketActivityExportsFunc     func(createdAt time.Time) ([]string, error)
+	DownloadTicketActivitiesFunc      func(url string) ([]freshdesk.TicketActivity, error)
+	ExportTicketActivitiesFunc        func(createdAt time.Time) ([]freshdesk.TicketActivity, error)
+}
+
+func (mock *Client) PutCustomData(header [2]string, body string, path string) (string, int, error) {
+	mock.record("PutCustomData", header, body, path)
+	if mock.PutCustomDataFunc != nil {
+		return mock.PutCustomDataFunc(header, body, path)
+	}
+	var r0 string
+	var r1 int
+	var r2 error
+	return r0, r1, r2
+}
+
+func (mock *Client) GetTicket(ID uint64) (*freshdesk.Ticket, error) {
+	mock.record("GetTicket", ID)
+	if mock.GetTicketFunc != nil {
+		return mock.GetTicketFunc(ID)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetTicketWithConversations(ID uint64) (*freshdesk.Ticket, error) {
+	mock.record("GetTicketWithConversations", ID)
+	if mock.GetTicketWithConversationsFunc != nil {
+		return mock.GetTicketWithConversationsFunc(ID)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllTicketConversations(ID uint64) ([]freshdesk.TicketMessage, error) {
+	mock.record("GetAllTicketConversations", ID)
+	if mock.GetAllTicketConversationsFunc != nil {
+		return mock.GetAllTicketConversationsFunc(ID)
+	}
+	var r0 []freshdesk.TicketMessage
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllTickets() ([]freshdesk.Ticket, error) {
+	mock.record("GetAllTickets")
+	if mock.GetAllTicketsFunc != nil {
+		return mock.GetAllTicketsFunc()
+	}
+	var r0 []freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetTicketsByCompanyID(companyID int, pageSize int, page int) ([]freshdesk.Ticket, error, bool) {
+	mock.record("GetTicketsByCompanyID", companyID, pageSize, page)
+	if mock.GetTicketsByCompanyIDFunc != nil {
+		return mock.GetTicketsByCompanyIDFunc(companyID, pageSize, page)
+	}
+	var r0 []freshdesk.Ticket
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) ListTickets(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Ticket, error, bool) {
+	mock.record("ListTickets", updatedSince, pageSize, page)
+	if mock.ListTicketsFunc != nil {
+		return mock.ListTicketsFunc(updatedSince, pageSize, page)
+	}
+	var r0 []freshdesk.Ticket
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) ListDeletedTickets(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Ticket, error, bool) {
+	mock.record("ListDeletedTickets", updatedSince, pageSize, page)
+	if mock.ListDeletedTicketsFunc != nil {
+		return mock.ListDeletedTicketsFunc(updatedSince, pageSize, page)
+	}
+	var r0 []freshdesk.Ticket
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) CreateTicket(payload freshdesk.TicketCreatePayload) (*freshdesk.Ticket, error) {
+	mock.record("CreateTicket", payload)
+	if mock.CreateTicketFunc != nil {
+		return mock.CreateTicketFunc(payload)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSdTicket(payload freshdesk.SdTicketCreatePayload) (*freshdesk.Ticket, error) {
+	mock.record("CreateSdTicket", payload)
+	if mock.CreateSdTicketFunc != nil {
+		return mock.CreateSdTicketFunc(payload)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateTicketWithAttachments(payload freshdesk.TicketCreatePayload, files []freshdesk.Attachment) (*freshdesk.Ticket, error) {
+	mock.record("CreateTicketWithAttachments", payload, files)
+	if mock.CreateTicketWithAttachmentsFunc != nil {
+		return mock.CreateTicketWithAttachmentsFunc(payload, files)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSdTicketWithAttachments(payload freshdesk.SdTicketCreatePayload, files []freshdesk.Attachment) (*freshdesk.Ticket, error) {
+	mock.record("CreateSdTicketWithAttachments", payload, files)
+	if mock.CreateSdTicketWithAttachmentsFunc != nil {
+		return mock.CreateSdTicketWithAttachmentsFunc(payload, files)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateTicket(ID uint64, payload freshdesk.TicketUpdatePayload) (*freshdesk.Ticket, error) {
+	mock.record("UpdateTicket", ID, payload)
+	if mock.UpdateTicketFunc != nil {
+		return mock.UpdateTicketFunc(ID, payload)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateTicketStatus(ID uint64, payload freshdesk.TicketStatusUpdatePayload) (*freshdesk.Ticket, error) {
+	mock.record("UpdateTicketStatus", ID, payload)
+	if mock.UpdateTicketStatusFunc != nil {
+		return mock.UpdateTicketStatusFunc(ID, payload)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateTicketMessage(ID uint64, payload freshdesk.TicketMessageCreatePayload) (*freshdesk.TicketMessage, error) {
+	mock.record("CreateTicketMessage", ID, payload)
+	if mock.CreateTicketMessageFunc != nil {
+		return mock.CreateTicketMessageFunc(ID, payload)
+	}
+	var r0 *freshdesk.TicketMessage
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSdTicketMessage(ID uint64, payload freshdesk.TicketMessageCreatePayload) (*freshdesk.TicketMessage, error) {
+	mock.record("CreateSdTicketMessage", ID, payload)
+	if mock.CreateSdTicketMessageFunc != nil {
+		return mock.CreateSdTicketMessageFunc(ID, payload)
+	}
+	var r0 *freshdesk.TicketMessage
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteTicket(ID uint64) (*interface{}, error) {
+	mock.record("DeleteTicket", ID)
+	if mock.DeleteTicketFunc != nil {
+		return mock.DeleteTicketFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) AddWatcher(ID uint64, userID uint64) (*interface{}, error) {
+	mock.record("AddWatcher", ID, userID)
+	if mock.AddWatcherFunc != nil {
+		return mock.AddWatcherFunc(ID, userID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) RemoveWatcher(ID uint64, userID uint64) (*interface{}, error) {
+	mock.record("RemoveWatcher", ID, userID)
+	if mock.RemoveWatcherFunc != nil {
+		return mock.RemoveWatcherFunc(ID, userID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListWatchers(ID uint64) ([]uint64, error) {
+	mock.record("ListWatchers", ID)
+	if mock.ListWatchersFunc != nil {
+		return mock.ListWatchersFunc(ID)
+	}
+	var r0 []uint64
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateChildTicket(parentID uint64, payload freshdesk.TicketCreatePayload) (*freshdesk.Ticket, error) {
+	mock.record("CreateChildTicket", parentID, payload)
+	if mock.CreateChildTicketFunc != nil {
+		return mock.CreateChildTicketFunc(parentID, payload)
+	}
+	var r0 *freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAssociatedTickets(ID uint64) ([]freshdesk.Ticket, error) {
+	mock.record("GetAssociatedTickets", ID)
+	if mock.GetAssociatedTicketsFunc != nil {
+		return mock.GetAssociatedTicketsFunc(ID)
+	}
+	var r0 []freshdesk.Ticket
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) LinkTicket(ID uint64, trackerID uint64) (*interface{}, error) {
+	mock.record("LinkTicket", ID, trackerID)
+	if mock.LinkTicketFunc != nil {
+		return mock.LinkTicketFunc(ID, trackerID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UnlinkTicket(ID uint64, trackerID uint64) (*interface{}, error) {
+	mock.record("UnlinkTicket", ID, trackerID)
+	if mock.UnlinkTicketFunc != nil {
+		return mock.UnlinkTicketFunc(ID, trackerID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) FindContactByEmail(email string) (freshdesk.Contact, error) {
+	mock.record("FindContactByEmail", email)
+	if mock.FindContactByEmailFunc != nil {
+		return mock.FindContactByEmailFunc(email)
+	}
+	var r0 freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) FindContactByExternalID(externalID string) (freshdesk.Contact, error) {
+	mock.record("FindContactByExternalID", externalID)
+	if mock.FindContactByExternalIDFunc != nil {
+		return mock.FindContactByExternalIDFunc(externalID)
+	}
+	var r0 freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) FindContactByPhone(phone string) (freshdesk.Contact, error) {
+	mock.record("FindContactByPhone", phone)
+	if mock.FindContactByPhoneFunc != nil {
+		return mock.FindContactByPhoneFunc(phone)
+	}
+	var r0 freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpsertContact(key freshdesk.UpsertKey, payload freshdesk.ContactCreatePayload) (*freshdesk.Contact, freshdesk.UpsertResult, error) {
+	mock.record("UpsertContact", key, payload)
+	if mock.UpsertContactFunc != nil {
+		return mock.UpsertContactFunc(key, payload)
+	}
+	var r0 *freshdesk.Contact
+	var r1 freshdesk.UpsertResult
+	var r2 error
+	return r0, r1, r2
+}
+
+func (mock *Client) GetContact(ID uint64) (*freshdesk.Contact, error) {
+	mock.record("GetContact", ID)
+	if mock.GetContactFunc != nil {
+		return mock.GetContactFunc(ID)
+	}
+	var r0 *freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllContacts() ([]freshdesk.ContactShort, error) {
+	mock.record("GetAllContacts")
+	if mock.GetAllContactsFunc != nil {
+		return mock.GetAllContactsFunc()
+	}
+	var r0 []freshdesk.ContactShort
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListContacts(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Contact, error, bool) {
+	mock.record("ListContacts", updatedSince, pageSize, page)
+	if mock.ListContactsFunc != nil {
+		return mock.ListContactsFunc(updatedSince, pageSize, page)
+	}
+	var r0 []freshdesk.Contact
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) ListDeletedContacts(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Contact, error, bool) {
+	mock.record("ListDeletedContacts", updatedSince, pageSize, page)
+	if mock.ListDeletedContactsFunc != nil {
+		return mock.ListDeletedContactsFunc(updatedSince, pageSize, page)
+	}
+	var r0 []freshdesk.Contact
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) CreateContact(payload freshdesk.ContactCreatePayload) (*freshdesk.Contact, error) {
+	mock.record("CreateContact", payload)
+	if mock.CreateContactFunc != nil {
+		return mock.CreateContactFunc(payload)
+	}
+	var r0 *freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateContact(ID uint64, payload freshdesk.ContactUpdatePayload) (*freshdesk.Contact, error) {
+	mock.record("UpdateContact", ID, payload)
+	if mock.UpdateContactFunc != nil {
+		return mock.UpdateContactFunc(ID, payload)
+	}
+	var r0 *freshdesk.Contact
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) SoftDeleteContact(ID uint64) (*interface{}, error) {
+	mock.record("SoftDeleteContact", ID)
+	if mock.SoftDeleteContactFunc != nil {
+		return mock.SoftDeleteContactFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) PermanentlyDeleteContact(ID uint64) (*interface{}, error) {
+	mock.record("PermanentlyDeleteContact", ID)
+	if mock.PermanentlyDeleteContactFunc != nil {
+		return mock.PermanentlyDeleteContactFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) AddOtherCompanyForContact(fd_contact *freshdesk.Contact, id_client uint64, view_all bool) (bool, error) {
+	mock.record("AddOtherCompanyForContact", fd_contact, id_client, view_all)
+	if mock.AddOtherCompanyForContactFunc != nil {
+		return mock.AddOtherCompanyForContactFunc(fd_contact, id_client, view_all)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) AddMainCompanyForContact(fd_contact *freshdesk.Contact, id_client uint64) (bool, error) {
+	mock.record("AddMainCompanyForContact", fd_contact, id_client)
+	if mock.AddMainCompanyForContactFunc != nil {
+		return mock.AddMainCompanyForContactFunc(fd_contact, id_client)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetCompany(ID uint64) (*freshdesk.Company, error) {
+	mock.record("GetCompany", ID)
+	if mock.GetCompanyFunc != nil {
+		return mock.GetCompanyFunc(ID)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetCompanyWithContactCount(ID uint64) (*freshdesk.Company, error) {
+	mock.record("GetCompanyWithContactCount", ID)
+	if mock.GetCompanyWithContactCountFunc != nil {
+		return mock.GetCompanyWithContactCountFunc(ID)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetCompanyByName(name string) (*freshdesk.Company, error) {
+	mock.record("GetCompanyByName", name)
+	if mock.GetCompanyByNameFunc != nil {
+		return mock.GetCompanyByNameFunc(name)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetCompanyByDomain(domain string) (*freshdesk.Company, error) {
+	mock.record("GetCompanyByDomain", domain)
+	if mock.GetCompanyByDomainFunc != nil {
+		return mock.GetCompanyByDomainFunc(domain)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllCompanies() ([]freshdesk.Company, error) {
+	mock.record("GetAllCompanies")
+	if mock.GetAllCompaniesFunc != nil {
+		return mock.GetAllCompaniesFunc()
+	}
+	var r0 []freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListCompanies(updatedSince *time.Time, pageSize int, page int) ([]freshdesk.Company, error, bool) {
+	mock.record("ListCompanies", updatedSince, pageSize, page)
+	if mock.ListCompaniesFunc != nil {
+		return mock.ListCompaniesFunc(updatedSince, pageSize, page)
+	}
+	var r0 []freshdesk.Company
+	var r1 error
+	var r2 bool
+	return r0, r1, r2
+}
+
+func (mock *Client) SearchCompanies(mask string) ([]freshdesk.CompanyName, error) {
+	mock.record("SearchCompanies", mask)
+	if mock.SearchCompaniesFunc != nil {
+		return mock.SearchCompaniesFunc(mask)
+	}
+	var r0 []freshdesk.CompanyName
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateCompany(payload freshdesk.CompanyCreatePayload) (*freshdesk.Company, error) {
+	mock.record("CreateCompany", payload)
+	if mock.CreateCompanyFunc != nil {
+		return mock.CreateCompanyFunc(payload)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateCompany(ID uint64, payload freshdesk.CompanyUpdatePayload) (*freshdesk.Company, error) {
+	mock.record("UpdateCompany", ID, payload)
+	if mock.UpdateCompanyFunc != nil {
+		return mock.UpdateCompanyFunc(ID, payload)
+	}
+	var r0 *freshdesk.Company
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteCompany(ID uint64) (*interface{}, error) {
+	mock.record("DeleteCompany", ID)
+	if mock.DeleteCompanyFunc != nil {
+		return mock.DeleteCompanyFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpsertCompany(key freshdesk.UpsertKey, payload freshdesk.CompanyCreatePayload) (*freshdesk.Company, freshdesk.UpsertResult, error) {
+	mock.record("UpsertCompany", key, payload)
+	if mock.UpsertCompanyFunc != nil {
+		return mock.UpsertCompanyFunc(key, payload)
+	}
+	var r0 *freshdesk.Company
+	var r1 freshdesk.UpsertResult
+	var r2 error
+	return r0, r1, r2
+}
+
+func (mock *Client) GetAllGroups() ([]freshdesk.Group, error) {
+	mock.record("GetAllGroups")
+	if mock.GetAllGroupsFunc != nil {
+		return mock.GetAllGroupsFunc()
+	}
+	var r0 []freshdesk.Group
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateGroup(payload freshdesk.GroupCreatePayload) (*freshdesk.Group, error) {
+	mock.record("CreateGroup", payload)
+	if mock.CreateGroupFunc != nil {
+		return mock.CreateGroupFunc(payload)
+	}
+	var r0 *freshdesk.Group
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateGroup(ID uint64, payload freshdesk.GroupUpdatePayload) (*freshdesk.Group, error) {
+	mock.record("UpdateGroup", ID, payload)
+	if mock.UpdateGroupFunc != nil {
+		return mock.UpdateGroupFunc(ID, payload)
+	}
+	var r0 *freshdesk.Group
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteGroup(ID uint64) (*interface{}, error) {
+	mock.record("DeleteGroup", ID)
+	if mock.DeleteGroupFunc != nil {
+		return mock.DeleteGroupFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListProducts() ([]freshdesk.Product, error) {
+	mock.record("ListProducts")
+	if mock.ListProductsFunc != nil {
+		return mock.ListProductsFunc()
+	}
+	var r0 []freshdesk.Product
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetProduct(ID uint64) (*freshdesk.Product, error) {
+	mock.record("GetProduct", ID)
+	if mock.GetProductFunc != nil {
+		return mock.GetProductFunc(ID)
+	}
+	var r0 *freshdesk.Product
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateProduct(payload freshdesk.ProductCreatePayload) (*freshdesk.Product, error) {
+	mock.record("CreateProduct", payload)
+	if mock.CreateProductFunc != nil {
+		return mock.CreateProductFunc(payload)
+	}
+	var r0 *freshdesk.Product
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateProduct(ID uint64, payload freshdesk.ProductUpdatePayload) (*freshdesk.Product, error) {
+	mock.record("UpdateProduct", ID, payload)
+	if mock.UpdateProductFunc != nil {
+		return mock.UpdateProductFunc(ID, payload)
+	}
+	var r0 *freshdesk.Product
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListEmailConfigs() ([]freshdesk.EmailConfig, error) {
+	mock.record("ListEmailConfigs")
+	if mock.ListEmailConfigsFunc != nil {
+		return mock.ListEmailConfigsFunc()
+	}
+	var r0 []freshdesk.EmailConfig
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetEmailConfig(ID uint64) (*freshdesk.EmailConfig, error) {
+	mock.record("GetEmailConfig", ID)
+	if mock.GetEmailConfigFunc != nil {
+		return mock.GetEmailConfigFunc(ID)
+	}
+	var r0 *freshdesk.EmailConfig
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) FindEmailConfigByEmail(email string) (*freshdesk.EmailConfig, error) {
+	mock.record("FindEmailConfigByEmail", email)
+	if mock.FindEmailConfigByEmailFunc != nil {
+		return mock.FindEmailConfigByEmailFunc(email)
+	}
+	var r0 *freshdesk.EmailConfig
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListMailboxes() ([]freshdesk.Mailbox, error) {
+	mock.record("ListMailboxes")
+	if mock.ListMailboxesFunc != nil {
+		return mock.ListMailboxesFunc()
+	}
+	var r0 []freshdesk.Mailbox
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetMailbox(ID uint64) (*freshdesk.Mailbox, error) {
+	mock.record("GetMailbox", ID)
+	if mock.GetMailboxFunc != nil {
+		return mock.GetMailboxFunc(ID)
+	}
+	var r0 *freshdesk.Mailbox
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateMailbox(payload freshdesk.MailboxCreatePayload) (*freshdesk.Mailbox, error) {
+	mock.record("CreateMailbox", payload)
+	if mock.CreateMailboxFunc != nil {
+		return mock.CreateMailboxFunc(payload)
+	}
+	var r0 *freshdesk.Mailbox
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateMailbox(ID uint64, payload freshdesk.MailboxUpdatePayload) (*freshdesk.Mailbox, error) {
+	mock.record("UpdateMailbox", ID, payload)
+	if mock.UpdateMailboxFunc != nil {
+		return mock.UpdateMailboxFunc(ID, payload)
+	}
+	var r0 *freshdesk.Mailbox
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteMailbox(ID uint64) (*interface{}, error) {
+	mock.record("DeleteMailbox", ID)
+	if mock.DeleteMailboxFunc != nil {
+		return mock.DeleteMailboxFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListBusinessHours() ([]freshdesk.BusinessHours, error) {
+	mock.record("ListBusinessHours")
+	if mock.ListBusinessHoursFunc != nil {
+		return mock.ListBusinessHoursFunc()
+	}
+	var r0 []freshdesk.BusinessHours
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetBusinessHours(ID uint64) (*freshdesk.BusinessHours, error) {
+	mock.record("GetBusinessHours", ID)
+	if mock.GetBusinessHoursFunc != nil {
+		return mock.GetBusinessHoursFunc(ID)
+	}
+	var r0 *freshdesk.BusinessHours
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListSLAPolicies() ([]freshdesk.SLAPolicy, error) {
+	mock.record("ListSLAPolicies")
+	if mock.ListSLAPoliciesFunc != nil {
+		return mock.ListSLAPoliciesFunc()
+	}
+	var r0 []freshdesk.SLAPolicy
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSLAPolicy(payload freshdesk.SLAPolicyCreatePayload) (*freshdesk.SLAPolicy, error) {
+	mock.record("CreateSLAPolicy", payload)
+	if mock.CreateSLAPolicyFunc != nil {
+		return mock.CreateSLAPolicyFunc(payload)
+	}
+	var r0 *freshdesk.SLAPolicy
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateSLAPolicy(ID uint64, payload freshdesk.SLAPolicyUpdatePayload) (*freshdesk.SLAPolicy, error) {
+	mock.record("UpdateSLAPolicy", ID, payload)
+	if mock.UpdateSLAPolicyFunc != nil {
+		return mock.UpdateSLAPolicyFunc(ID, payload)
+	}
+	var r0 *freshdesk.SLAPolicy
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListRoles() ([]freshdesk.Role, error) {
+	mock.record("ListRoles")
+	if mock.ListRolesFunc != nil {
+		return mock.ListRolesFunc()
+	}
+	var r0 []freshdesk.Role
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetRole(ID uint64) (*freshdesk.Role, error) {
+	mock.record("GetRole", ID)
+	if mock.GetRoleFunc != nil {
+		return mock.GetRoleFunc(ID)
+	}
+	var r0 *freshdesk.Role
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListSkills() ([]freshdesk.Skill, error) {
+	mock.record("ListSkills")
+	if mock.ListSkillsFunc != nil {
+		return mock.ListSkillsFunc()
+	}
+	var r0 []freshdesk.Skill
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetSkill(ID uint64) (*freshdesk.Skill, error) {
+	mock.record("GetSkill", ID)
+	if mock.GetSkillFunc != nil {
+		return mock.GetSkillFunc(ID)
+	}
+	var r0 *freshdesk.Skill
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSkill(payload freshdesk.SkillCreatePayload) (*freshdesk.Skill, error) {
+	mock.record("CreateSkill", payload)
+	if mock.CreateSkillFunc != nil {
+		return mock.CreateSkillFunc(payload)
+	}
+	var r0 *freshdesk.Skill
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateSkill(ID uint64, payload freshdesk.SkillUpdatePayload) (*freshdesk.Skill, error) {
+	mock.record("UpdateSkill", ID, payload)
+	if mock.UpdateSkillFunc != nil {
+		return mock.UpdateSkillFunc(ID, payload)
+	}
+	var r0 *freshdesk.Skill
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteSkill(ID uint64) (*interface{}, error) {
+	mock.record("DeleteSkill", ID)
+	if mock.DeleteSkillFunc != nil {
+		return mock.DeleteSkillFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAgentAvailability(agentID uint64) (*freshdesk.AgentAvailability, error) {
+	mock.record("GetAgentAvailability", agentID)
+	if mock.GetAgentAvailabilityFunc != nil {
+		return mock.GetAgentAvailabilityFunc(agentID)
+	}
+	var r0 *freshdesk.AgentAvailability
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateAgentAvailability(agentID uint64, payload freshdesk.AgentAvailabilityUpdatePayload) (*freshdesk.AgentAvailability, error) {
+	mock.record("UpdateAgentAvailability", agentID, payload)
+	if mock.UpdateAgentAvailabilityFunc != nil {
+		return mock.UpdateAgentAvailabilityFunc(agentID, payload)
+	}
+	var r0 *freshdesk.AgentAvailability
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListAgentAvailability() ([]freshdesk.AgentAvailability, error) {
+	mock.record("ListAgentAvailability")
+	if mock.ListAgentAvailabilityFunc != nil {
+		return mock.ListAgentAvailabilityFunc()
+	}
+	var r0 []freshdesk.AgentAvailability
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListAutomationRules(automationType freshdesk.AutomationType) ([]freshdesk.AutomationRule, error) {
+	mock.record("ListAutomationRules", automationType)
+	if mock.ListAutomationRulesFunc != nil {
+		return mock.ListAutomationRulesFunc(automationType)
+	}
+	var r0 []freshdesk.AutomationRule
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAutomationRule(automationType freshdesk.AutomationType, ID uint64) (*freshdesk.AutomationRule, error) {
+	mock.record("GetAutomationRule", automationType, ID)
+	if mock.GetAutomationRuleFunc != nil {
+		return mock.GetAutomationRuleFunc(automationType, ID)
+	}
+	var r0 *freshdesk.AutomationRule
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateAutomationRule(automationType freshdesk.AutomationType, payload freshdesk.AutomationRulePayload) (*freshdesk.AutomationRule, error) {
+	mock.record("CreateAutomationRule", automationType, payload)
+	if mock.CreateAutomationRuleFunc != nil {
+		return mock.CreateAutomationRuleFunc(automationType, payload)
+	}
+	var r0 *freshdesk.AutomationRule
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateAutomationRule(automationType freshdesk.AutomationType, ID uint64, payload freshdesk.AutomationRulePayload) (*freshdesk.AutomationRule, error) {
+	mock.record("UpdateAutomationRule", automationType, ID, payload)
+	if mock.UpdateAutomationRuleFunc != nil {
+		return mock.UpdateAutomationRuleFunc(automationType, ID, payload)
+	}
+	var r0 *freshdesk.AutomationRule
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteAutomationRule(automationType freshdesk.AutomationType, ID uint64) (*interface{}, error) {
+	mock.record("DeleteAutomationRule", automationType, ID)
+	if mock.DeleteAutomationRuleFunc != nil {
+		return mock.DeleteAutomationRuleFunc(automationType, ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListScenarioAutomations() ([]freshdesk.ScenarioAutomation, error) {
+	mock.record("ListScenarioAutomations")
+	if mock.ListScenarioAutomationsFunc != nil {
+		return mock.ListScenarioAutomationsFunc()
+	}
+	var r0 []freshdesk.ScenarioAutomation
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ExecuteScenarioAutomation(ticketID uint64, scenarioID uint64) (*interface{}, error) {
+	mock.record("ExecuteScenarioAutomation", ticketID, scenarioID)
+	if mock.ExecuteScenarioAutomationFunc != nil {
+		return mock.ExecuteScenarioAutomationFunc(ticketID, scenarioID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListTicketForms() ([]freshdesk.TicketForm, error) {
+	mock.record("ListTicketForms")
+	if mock.ListTicketFormsFunc != nil {
+		return mock.ListTicketFormsFunc()
+	}
+	var r0 []freshdesk.TicketForm
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetTicketForm(ID uint64) (*freshdesk.TicketForm, error) {
+	mock.record("GetTicketForm", ID)
+	if mock.GetTicketFormFunc != nil {
+		return mock.GetTicketFormFunc(ID)
+	}
+	var r0 *freshdesk.TicketForm
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateTicketForm(payload freshdesk.TicketFormPayload) (*freshdesk.TicketForm, error) {
+	mock.record("CreateTicketForm", payload)
+	if mock.CreateTicketFormFunc != nil {
+		return mock.CreateTicketFormFunc(payload)
+	}
+	var r0 *freshdesk.TicketForm
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateTicketForm(ID uint64, payload freshdesk.TicketFormPayload) (*freshdesk.TicketForm, error) {
+	mock.record("UpdateTicketForm", ID, payload)
+	if mock.UpdateTicketFormFunc != nil {
+		return mock.UpdateTicketFormFunc(ID, payload)
+	}
+	var r0 *freshdesk.TicketForm
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteTicketForm(ID uint64) (*interface{}, error) {
+	mock.record("DeleteTicketForm", ID)
+	if mock.DeleteTicketFormFunc != nil {
+		return mock.DeleteTicketFormFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListTicketFields() ([]freshdesk.TicketField, error) {
+	mock.record("ListTicketFields")
+	if mock.ListTicketFieldsFunc != nil {
+		return mock.ListTicketFieldsFunc()
+	}
+	var r0 []freshdesk.TicketField
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateTicketField(payload freshdesk.TicketFieldCreatePayload) (*freshdesk.TicketField, error) {
+	mock.record("CreateTicketField", payload)
+	if mock.CreateTicketFieldFunc != nil {
+		return mock.CreateTicketFieldFunc(payload)
+	}
+	var r0 *freshdesk.TicketField
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateTicketField(ID uint64, payload freshdesk.TicketFieldUpdatePayload) (*freshdesk.TicketField, error) {
+	mock.record("UpdateTicketField", ID, payload)
+	if mock.UpdateTicketFieldFunc != nil {
+		return mock.UpdateTicketFieldFunc(ID, payload)
+	}
+	var r0 *freshdesk.TicketField
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteTicketField(ID uint64) (*interface{}, error) {
+	mock.record("DeleteTicketField", ID)
+	if mock.DeleteTicketFieldFunc != nil {
+		return mock.DeleteTicketFieldFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListCannedResponseFolders() ([]freshdesk.CannedResponseFolder, error) {
+	mock.record("ListCannedResponseFolders")
+	if mock.ListCannedResponseFoldersFunc != nil {
+		return mock.ListCannedResponseFoldersFunc()
+	}
+	var r0 []freshdesk.CannedResponseFolder
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateCannedResponseFolder(payload freshdesk.CannedResponseFolderCreatePayload) (*freshdesk.CannedResponseFolder, error) {
+	mock.record("CreateCannedResponseFolder", payload)
+	if mock.CreateCannedResponseFolderFunc != nil {
+		return mock.CreateCannedResponseFolderFunc(payload)
+	}
+	var r0 *freshdesk.CannedResponseFolder
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListCannedResponses(folderID uint64) ([]freshdesk.CannedResponse, error) {
+	mock.record("ListCannedResponses", folderID)
+	if mock.ListCannedResponsesFunc != nil {
+		return mock.ListCannedResponsesFunc(folderID)
+	}
+	var r0 []freshdesk.CannedResponse
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateCannedResponse(payload freshdesk.CannedResponseCreatePayload) (*freshdesk.CannedResponse, error) {
+	mock.record("CreateCannedResponse", payload)
+	if mock.CreateCannedResponseFunc != nil {
+		return mock.CreateCannedResponseFunc(payload)
+	}
+	var r0 *freshdesk.CannedResponse
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateCannedResponse(ID uint64, payload freshdesk.CannedResponseUpdatePayload) (*freshdesk.CannedResponse, error) {
+	mock.record("UpdateCannedResponse", ID, payload)
+	if mock.UpdateCannedResponseFunc != nil {
+		return mock.UpdateCannedResponseFunc(ID, payload)
+	}
+	var r0 *freshdesk.CannedResponse
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) SearchCustomObjects(SchemaID uint64, filter map[string]string) ([]freshdesk.CustomObject, error) {
+	mock.record("SearchCustomObjects", SchemaID, filter)
+	if mock.SearchCustomObjectsFunc != nil {
+		return mock.SearchCustomObjectsFunc(SchemaID, filter)
+	}
+	var r0 []freshdesk.CustomObject
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateCustomObject(schema_id uint64, data map[string]interface{}) (*freshdesk.CustomObjectUpdateResult, error) {
+	mock.record("CreateCustomObject", schema_id, data)
+	if mock.CreateCustomObjectFunc != nil {
+		return mock.CreateCustomObjectFunc(schema_id, data)
+	}
+	var r0 *freshdesk.CustomObjectUpdateResult
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateCustomObject(schema_id uint64, payload freshdesk.CustomObjectUpdatePayload) (*freshdesk.CustomObjectUpdateResult, error) {
+	mock.record("UpdateCustomObject", schema_id, payload)
+	if mock.UpdateCustomObjectFunc != nil {
+		return mock.UpdateCustomObjectFunc(schema_id, payload)
+	}
+	var r0 *freshdesk.CustomObjectUpdateResult
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllSolutionCategories() ([]freshdesk.SolutionCategory, error) {
+	mock.record("GetAllSolutionCategories")
+	if mock.GetAllSolutionCategoriesFunc != nil {
+		return mock.GetAllSolutionCategoriesFunc()
+	}
+	var r0 []freshdesk.SolutionCategory
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSolutionCategory(payload freshdesk.SolutionCategoryCreatePayload) (*freshdesk.SolutionCategory, error) {
+	mock.record("CreateSolutionCategory", payload)
+	if mock.CreateSolutionCategoryFunc != nil {
+		return mock.CreateSolutionCategoryFunc(payload)
+	}
+	var r0 *freshdesk.SolutionCategory
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllSolutionFolders(categoryID uint64) ([]freshdesk.SolutionFolder, error) {
+	mock.record("GetAllSolutionFolders", categoryID)
+	if mock.GetAllSolutionFoldersFunc != nil {
+		return mock.GetAllSolutionFoldersFunc(categoryID)
+	}
+	var r0 []freshdesk.SolutionFolder
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSolutionFolder(categoryID uint64, payload freshdesk.SolutionFolderCreatePayload) (*freshdesk.SolutionFolder, error) {
+	mock.record("CreateSolutionFolder", categoryID, payload)
+	if mock.CreateSolutionFolderFunc != nil {
+		return mock.CreateSolutionFolderFunc(categoryID, payload)
+	}
+	var r0 *freshdesk.SolutionFolder
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetAllSolutionArticles(folderID uint64) ([]freshdesk.SolutionArticle, error) {
+	mock.record("GetAllSolutionArticles", folderID)
+	if mock.GetAllSolutionArticlesFunc != nil {
+		return mock.GetAllSolutionArticlesFunc(folderID)
+	}
+	var r0 []freshdesk.SolutionArticle
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) GetSolutionArticle(ID uint64) (*freshdesk.SolutionArticle, error) {
+	mock.record("GetSolutionArticle", ID)
+	if mock.GetSolutionArticleFunc != nil {
+		return mock.GetSolutionArticleFunc(ID)
+	}
+	var r0 *freshdesk.SolutionArticle
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) CreateSolutionArticle(folderID uint64, payload freshdesk.SolutionArticleCreatePayload) (*freshdesk.SolutionArticle, error) {
+	mock.record("CreateSolutionArticle", folderID, payload)
+	if mock.CreateSolutionArticleFunc != nil {
+		return mock.CreateSolutionArticleFunc(folderID, payload)
+	}
+	var r0 *freshdesk.SolutionArticle
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) UpdateSolutionArticle(ID uint64, payload freshdesk.SolutionArticleUpdatePayload) (*freshdesk.SolutionArticle, error) {
+	mock.record("UpdateSolutionArticle", ID, payload)
+	if mock.UpdateSolutionArticleFunc != nil {
+		return mock.UpdateSolutionArticleFunc(ID, payload)
+	}
+	var r0 *freshdesk.SolutionArticle
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DeleteSolutionArticle(ID uint64) (*interface{}, error) {
+	mock.record("DeleteSolutionArticle", ID)
+	if mock.DeleteSolutionArticleFunc != nil {
+		return mock.DeleteSolutionArticleFunc(ID)
+	}
+	var r0 *interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ListTicketActivityExports(createdAt time.Time) ([]string, error) {
+	mock.record("ListTicketActivityExports", createdAt)
+	if mock.ListTicketActivityExportsFunc != nil {
+		return mock.ListTicketActivityExportsFunc(createdAt)
+	}
+	var r0 []string
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) DownloadTicketActivities(url string) ([]freshdesk.TicketActivity, error) {
+	mock.record("DownloadTicketActivities", url)
+	if mock.DownloadTicketActivitiesFunc != nil {
+		return mock.DownloadTicketActivitiesFunc(url)
+	}
+	var r0 []freshdesk.TicketActivity
+	var r1 error
+	return r0, r1
+}
+
+func (mock *Client) ExportTicketActivities(createdAt time.Time) ([]freshdesk.TicketActivity, error) {
+	mock.record("ExportTicketActivities", createdAt)
+	if mock.ExportTicketActivitiesFunc != nil {
+		return mock.ExportTicketActivitiesFunc(createdAt)
+	}
+	var r0 []freshdesk.TicketActivity
+	var r1 error
+	return r0, r1
+}
@@ -0,0 +1,127 @@
+package freshdesktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (server *Server) companyDefaults(now string) record {
+	return record{
+		"custom_fields": map[string]interface{}{},
+		"description":   nil,
+		"domains":       []interface{}{},
+		"note":          nil,
+		"health_score":  nil,
+		"account_tier":  nil,
+		"renewal_date":  nil,
+		"industry":      nil,
+		"created_at":    now,
+		"updated_at":    now,
+	}
+}
+
+func (server *Server) serveCompanies(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			// As in the API, the listing has no updated_since filter.
+			server.page(w, req, server.companies.list(nil))
+		case http.MethodPost:
+			server.createCompany(w, req)
+		default:
+			return false
+		}
+		return true
+	}
+
+	if path[0] == "autocomplete" && len(path) == 1 && req.Method == http.MethodGet {
+		name := strings.ToLower(req.URL.Query().Get("name"))
+		matches := []map[string]interface{}{}
+		for _, company := range server.companies.list(nil) {
+			if strings.HasPrefix(strings.ToLower(company.string("name")), name) {
+				matches = append(matches, map[string]interface{}{"id": company["id"], "name": company["name"]})
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"companies": matches})
+		return true
+	}
+
+	ID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil || len(path) > 1 {
+		return false
+	}
+	company, ok := server.companies.get(ID)
+	if !ok {
+		writeNotFound(w)
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, company)
+	case http.MethodPut:
+		body, ok := readBody(w, req)
+		if !ok {
+			return true
+		}
+		updated := company.clone()
+		updated.merge(body)
+		if server.companyConflict(w, updated, ID) {
+			return true
+		}
+		company.merge(body)
+		company["updated_at"] = server.timestamp()
+		writeJSON(w, http.StatusOK, company)
+	case http.MethodDelete:
+		// Deleting a company unlinks its contacts.
+		for _, contact := range server.contacts.list(nil) {
+			if contact.uint("company_id") == ID {
+				contact["company_id"] = nil
+			}
+		}
+		delete(server.companies.records, ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+func (server *Server) companyConflict(w http.ResponseWriter, company record, ID uint64) bool {
+	for _, other := range server.companies.list(nil) {
+		if other.uint("id") != ID && strings.EqualFold(other.string("name"), company.string("name")) {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{
+				"description": "Validation failed",
+				"errors": []map[string]interface{}{{
+					"field":           "name",
+					"message":         "It should be a unique value",
+					"code":            "duplicate_value",
+					"additional_info": map[string]interface{}{"company_id": other["id"]},
+				}},
+			})
+			return true
+		}
+	}
+	return false
+}
+
+func (server *Server) createCompany(w http.ResponseWriter, req *http.Request) {
+	body, ok := readBody(w, req)
+	if !ok {
+		return
+	}
+	if body.string("name") == "" {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "name", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"})
+		return
+	}
+	if server.companyConflict(w, body, 0) {
+		return
+	}
+
+	company := body
+	delete(company, "id")
+	setDefaults(company, server.companyDefaults(server.timestamp()))
+	server.companies.insert(company)
+	writeJSON(w, http.StatusCreated, company)
+}
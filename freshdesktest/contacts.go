@@ -0,0 +1,239 @@
+package freshdesktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (server *Server) contactDefaults(now string) record {
+	return record{
+		"active":              false,
+		"address":             nil,
+		"avatar":              nil,
+		"company_id":          nil,
+		"custom_fields":       map[string]interface{}{},
+		"deleted":             false,
+		"description":         nil,
+		"email":               nil,
+		"job_title":           nil,
+		"language":            "en",
+		"mobile":              nil,
+		"phone":               nil,
+		"other_emails":        []interface{}{},
+		"other_companies":     []interface{}{},
+		"other_phone_numbers": []interface{}{},
+		"tags":                []interface{}{},
+		"time_zone":           "Eastern Time (US & Canada)",
+		"twitter_id":          nil,
+		"unique_external_id":  nil,
+		"view_all_tickets":    false,
+		"created_at":          now,
+		"updated_at":          now,
+	}
+}
+
+// Fields that identify a contact; at least one is required and email and
+// unique_external_id must be unique.
+var contactIdentifiers = []string{"email", "phone", "mobile", "twitter_id", "unique_external_id"}
+
+func (server *Server) serveContacts(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			server.listContacts(w, req)
+		case http.MethodPost:
+			server.createContact(w, req)
+		default:
+			return false
+		}
+		return true
+	}
+
+	ID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	contact, ok := server.contacts.get(ID)
+	if !ok {
+		writeNotFound(w)
+		return true
+	}
+
+	if len(path) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, contact)
+		case http.MethodPut:
+			server.updateContact(w, req, contact)
+		case http.MethodDelete:
+			if contact.bool("deleted") {
+				writeNotFound(w)
+				return true
+			}
+			contact["deleted"] = true
+			contact["updated_at"] = server.timestamp()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			return false
+		}
+		return true
+	}
+
+	switch {
+	case path[1] == "hard_delete" && req.Method == http.MethodDelete:
+		if !contact.bool("deleted") && req.URL.Query().Get("force") != "true" {
+			writeValidationError(w, http.StatusBadRequest, fieldError{Field: "contact", Message: "The contact has to be soft deleted first, or force=true used", Code: "invalid_value"})
+			return true
+		}
+		delete(server.contacts.records, ID)
+		w.WriteHeader(http.StatusNoContent)
+	case path[1] == "restore" && req.Method == http.MethodPut:
+		contact["deleted"] = false
+		contact["updated_at"] = server.timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+// listContacts supports the filters of the contact list; soft deleted
+// contacts are only listed with state=deleted.
+func (server *Server) listContacts(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	since, ok := updatedSince(req, "_updated_since")
+	if !ok {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "_updated_since", Message: "It should be in the 'valid date time' format", Code: "invalid_value"})
+		return
+	}
+
+	contacts := server.contacts.list(func(contact record) bool {
+		if (query.Get("state") == "deleted") != contact.bool("deleted") {
+			return false
+		}
+		switch query.Get("state") {
+		case "verified":
+			if !contact.bool("active") {
+				return false
+			}
+		case "unverified":
+			if contact.bool("active") {
+				return false
+			}
+		}
+		for _, filter := range []string{"email", "phone", "mobile", "unique_external_id", "company_id"} {
+			if value := query.Get(filter); value != "" {
+				if filter == "email" && !contactHasEmail(contact, value) {
+					return false
+				}
+				if filter != "email" && !contact.matches(filter, value) {
+					return false
+				}
+			}
+		}
+		return since(contact)
+	})
+	server.page(w, req, contacts)
+}
+
+func contactHasEmail(contact record, email string) bool {
+	return strings.EqualFold(contact.string("email"), email) || contact.matches("other_emails", email)
+}
+
+// findContact returns the contact with the given identifier.
+func (server *Server) findContact(field string, value string) record {
+	for _, contact := range server.contacts.list(nil) {
+		if field == "email" && contactHasEmail(contact, value) {
+			return contact
+		}
+		if field != "email" && contact.matches(field, value) {
+			return contact
+		}
+	}
+	return nil
+}
+
+// contactConflict checks that the email and external ID of a contact are
+// not used by another one.
+func (server *Server) contactConflict(w http.ResponseWriter, contact record, ID uint64) bool {
+	for _, field := range []string{"email", "unique_external_id"} {
+		value := contact.string(field)
+		if value == "" {
+			continue
+		}
+		if other := server.findContact(field, value); other != nil && other.uint("id") != ID {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{
+				"description": "Validation failed",
+				"errors": []map[string]interface{}{{
+					"field":           field,
+					"message":         "It should be a unique value",
+					"code":            "duplicate_value",
+					"additional_info": map[string]interface{}{"user_id": other["id"]},
+				}},
+			})
+			return true
+		}
+	}
+	return false
+}
+
+func (server *Server) createContact(w http.ResponseWriter, req *http.Request) {
+	body, ok := readBody(w, req)
+	if !ok {
+		return
+	}
+
+	if body.string("name") == "" {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "name", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"})
+		return
+	}
+	identified := false
+	for _, field := range contactIdentifiers {
+		if body.string(field) != "" {
+			identified = true
+		}
+	}
+	if !identified {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "email", Message: "Please fill at least 1 of email, mobile, phone, twitter_id, unique_external_id fields", Code: "missing_field"})
+		return
+	}
+	if server.contactConflict(w, body, 0) || !server.validCompany(w, body) {
+		return
+	}
+
+	contact := body
+	delete(contact, "id")
+	setDefaults(contact, server.contactDefaults(server.timestamp()))
+	server.contacts.insert(contact)
+	writeJSON(w, http.StatusCreated, contact)
+}
+
+func (server *Server) updateContact(w http.ResponseWriter, req *http.Request, contact record) {
+	body, ok := readBody(w, req)
+	if !ok {
+		return
+	}
+
+	updated := contact.clone()
+	updated.merge(body)
+	if server.contactConflict(w, updated, contact.uint("id")) || !server.validCompany(w, body) {
+		return
+	}
+
+	contact.merge(body)
+	contact["updated_at"] = server.timestamp()
+	writeJSON(w, http.StatusOK, contact)
+}
+
+func (server *Server) validCompany(w http.ResponseWriter, body record) bool {
+	ID := body.uint("company_id")
+	if ID == 0 {
+		return true
+	}
+	if _, ok := server.companies.get(ID); ok {
+		return true
+	}
+	writeValidationError(w, http.StatusBadRequest, fieldError{Field: "company_id", Message: "There is no company matching the given company_id", Code: "invalid_value"})
+	return false
+}
@@ -0,0 +1,133 @@
+package freshdesktest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+func (server *Server) serveCustomObjects(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) < 3 || path[0] != "schemas" || path[2] != "records" || len(path) > 4 {
+		return false
+	}
+	schemaID, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return false
+	}
+	records := server.schemaRecords(schemaID)
+
+	if len(path) == 3 {
+		switch req.Method {
+		case http.MethodGet:
+			server.searchCustomObjects(w, req, records)
+		case http.MethodPost:
+			body, ok := readBody(w, req)
+			if !ok {
+				return true
+			}
+			data, ok := body["data"].(map[string]interface{})
+			if !ok {
+				data = body
+			}
+			writeJSON(w, http.StatusCreated, server.insertCustomObject(schemaID, data))
+		default:
+			return false
+		}
+		return true
+	}
+
+	object, ok := records[path[3]]
+	if !ok {
+		writeNotFound(w)
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodPut:
+		body, ok := readBody(w, req)
+		if !ok {
+			return true
+		}
+		if version, ok := body["version"]; ok && version != object["version"] {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{
+				"code":    "version_conflict",
+				"message": "The record has been updated since it was read",
+			})
+			return true
+		}
+		if data, ok := body["data"].(map[string]interface{}); ok {
+			existing, _ := object["data"].(map[string]interface{})
+			if existing == nil {
+				existing = map[string]interface{}{}
+				object["data"] = existing
+			}
+			record(existing).merge(record(data))
+		}
+		object["version"] = object["version"].(float64) + 1
+		object["updated_time"] = float64(server.Now().UnixMilli())
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodDelete:
+		delete(records, path[3])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+func (server *Server) schemaRecords(schemaID uint64) map[string]record {
+	records, ok := server.customObjects[schemaID]
+	if !ok {
+		records = map[string]record{}
+		server.customObjects[schemaID] = records
+	}
+	return records
+}
+
+func (server *Server) insertCustomObject(schemaID uint64, data map[string]interface{}) record {
+	records := server.schemaRecords(schemaID)
+	server.customObjectIDs[schemaID]++
+	now := float64(server.Now().UnixMilli())
+	object := record{
+		"display_id":   fmt.Sprintf("R-%d", server.customObjectIDs[schemaID]),
+		"created_time": now,
+		"updated_time": now,
+		"data":         data,
+		"version":      float64(1),
+		"metadata":     map[string]interface{}{"created_by": "system", "updated_by": "system"},
+	}
+	records[object.string("display_id")] = object
+	return object
+}
+
+// searchCustomObjects keeps the records whose data fields equal every
+// query parameter other than the paging ones.
+func (server *Server) searchCustomObjects(w http.ResponseWriter, req *http.Request, records map[string]record) {
+	var results []record
+	for _, object := range records {
+		data, _ := object["data"].(map[string]interface{})
+		matched := true
+		for name, values := range req.URL.Query() {
+			if name == "page_size" || name == "next_token" || name == "sort_by" || name == "order_by" {
+				continue
+			}
+			if !record(data).matches(name, values[0]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			results = append(results, object)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].uint("created_time") < results[j].uint("created_time") ||
+			(results[i].uint("created_time") == results[j].uint("created_time") && results[i].string("display_id") < results[j].string("display_id"))
+	})
+	if results == nil {
+		results = []record{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"records": results, "_links": map[string]interface{}{}})
+}
@@ -0,0 +1,93 @@
+package freshdesktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (server *Server) groupDefaults(now string) record {
+	return record{
+		"description":    nil,
+		"escalate_to":    nil,
+		"unassigned_for": nil,
+		"agent_ids":      []interface{}{},
+		"type":           "support_agent_group",
+		"created_at":     now,
+		"updated_at":     now,
+	}
+}
+
+// serveGroups serves /groups and /admin/groups alike.
+func (server *Server) serveGroups(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			server.page(w, req, server.groups.list(nil))
+		case http.MethodPost:
+			body, ok := readBody(w, req)
+			if !ok {
+				return true
+			}
+			if body.string("name") == "" {
+				writeValidationError(w, http.StatusBadRequest, fieldError{Field: "name", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"})
+				return true
+			}
+			if server.groupConflict(w, body, 0) {
+				return true
+			}
+			group := body
+			delete(group, "id")
+			setDefaults(group, server.groupDefaults(server.timestamp()))
+			server.groups.insert(group)
+			writeJSON(w, http.StatusCreated, group)
+		default:
+			return false
+		}
+		return true
+	}
+
+	ID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil || len(path) > 1 {
+		return false
+	}
+	group, ok := server.groups.get(ID)
+	if !ok {
+		writeNotFound(w)
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, group)
+	case http.MethodPut:
+		body, ok := readBody(w, req)
+		if !ok {
+			return true
+		}
+		updated := group.clone()
+		updated.merge(body)
+		if server.groupConflict(w, updated, ID) {
+			return true
+		}
+		group.merge(body)
+		group["updated_at"] = server.timestamp()
+		writeJSON(w, http.StatusOK, group)
+	case http.MethodDelete:
+		delete(server.groups.records, ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+func (server *Server) groupConflict(w http.ResponseWriter, group record, ID uint64) bool {
+	for _, other := range server.groups.list(nil) {
+		if other.uint("id") != ID && strings.EqualFold(other.string("name"), group.string("name")) {
+			writeValidationError(w, http.StatusConflict, fieldError{Field: "name", Message: "It should be a unique value", Code: "duplicate_value"})
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package freshdesktest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// term is one condition of a search query: field:value, field:>value or
+// field:<value.
+type term struct {
+	field string
+	op    byte
+	value string
+}
+
+// Search returns this many results per page, for at most 10 pages.
+const (
+	searchPageSize = 30
+	searchMaxPages = 10
+)
+
+func (server *Server) serveSearch(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) != 1 || req.Method != http.MethodGet {
+		return false
+	}
+
+	var candidates []record
+	aliases := map[string]string{}
+	switch path[0] {
+	case "tickets":
+		candidates = server.tickets.list(func(ticket record) bool { return !ticket.bool("deleted") && !ticket.bool("spam") })
+		aliases = map[string]string{"tag": "tags", "agent_id": "responder_id", "type": "type"}
+	case "contacts":
+		candidates = server.contacts.list(func(contact record) bool { return !contact.bool("deleted") })
+		aliases = map[string]string{"tag": "tags"}
+	case "companies":
+		candidates = server.companies.list(nil)
+		aliases = map[string]string{"domain": "domains"}
+	default:
+		return false
+	}
+
+	query, err := parseQuery(req.URL.Query().Get("query"))
+	if err != nil {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "query", Message: err.Error(), Code: "invalid_value"})
+		return true
+	}
+	page := 1
+	if value, err := strconv.Atoi(req.URL.Query().Get("page")); err == nil && value > 0 {
+		page = value
+	}
+	if page > searchMaxPages {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "page", Message: "Has to be less than or equal to 10", Code: "invalid_value"})
+		return true
+	}
+
+	results := []record{}
+	for _, candidate := range candidates {
+		if query.matches(candidate, aliases) {
+			results = append(results, candidate)
+		}
+	}
+	total := len(results)
+	start := (page - 1) * searchPageSize
+	if start > total {
+		start = total
+	}
+	end := start + searchPageSize
+	if end > total {
+		end = total
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results[start:end], "total": total})
+	return true
+}
+
+// searchQuery is a disjunction of conjunctions of terms. Parentheses are
+// accepted but only the usual precedence of AND over OR is applied.
+type searchQuery [][]term
+
+func parseQuery(query string) (searchQuery, error) {
+	query = strings.TrimSpace(query)
+	if len(query) < 2 || query[0] != '"' || query[len(query)-1] != '"' {
+		return nil, fmt.Errorf("query should be enclosed in double quotes")
+	}
+	if len(query) > 512 {
+		return nil, fmt.Errorf("query is longer than 512 characters")
+	}
+
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, char := range query[1 : len(query)-1] {
+		switch {
+		case char == '\'':
+			quoted = !quoted
+			token.WriteRune(char)
+		case (char == ' ' || char == '(' || char == ')') && !quoted:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(char)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unbalanced quotes")
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+
+	parsed := searchQuery{nil}
+	expectTerm := true
+	for _, token := range tokens {
+		if !expectTerm {
+			switch token {
+			case "AND":
+			case "OR":
+				parsed = append(parsed, nil)
+			default:
+				return nil, fmt.Errorf("expected AND or OR before %q", token)
+			}
+			expectTerm = true
+			continue
+		}
+
+		field, value, ok := strings.Cut(token, ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid condition %q", token)
+		}
+		condition := term{field: field, op: ':'}
+		if value != "" && (value[0] == '>' || value[0] == '<') {
+			condition.op = value[0]
+			value = value[1:]
+		}
+		condition.value = strings.Trim(value, "'")
+		parsed[len(parsed)-1] = append(parsed[len(parsed)-1], condition)
+		expectTerm = false
+	}
+	if expectTerm {
+		return nil, fmt.Errorf("incomplete query")
+	}
+	return parsed, nil
+}
+
+func (query searchQuery) matches(r record, aliases map[string]string) bool {
+	for _, conjunction := range query {
+		all := true
+		for _, condition := range conjunction {
+			if !condition.matches(r, aliases) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func (condition term) matches(r record, aliases map[string]string) bool {
+	field := condition.field
+	if alias, ok := aliases[field]; ok {
+		field = alias
+	}
+	if strings.HasPrefix(field, "cf_") {
+		custom, _ := r["custom_fields"].(map[string]interface{})
+		r = record(custom)
+	}
+
+	if condition.op == ':' {
+		if condition.value == "null" {
+			return r[field] == nil
+		}
+		if field == "email" && r["other_emails"] != nil {
+			return contactHasEmail(r, condition.value)
+		}
+		return r.matches(field, condition.value)
+	}
+
+	// Range conditions are inclusive, on dates or numbers.
+	if limit, err := time.Parse("2006-01-02", condition.value); err == nil {
+		value, err := time.Parse(time.RFC3339, r.string(field))
+		if err != nil {
+			return false
+		}
+		day := value.UTC().Truncate(24 * time.Hour)
+		if condition.op == '>' {
+			return !day.Before(limit)
+		}
+		return !day.After(limit)
+	}
+	limit, err := strconv.ParseFloat(condition.value, 64)
+	value, ok := r[field].(float64)
+	if err != nil || !ok {
+		return false
+	}
+	if condition.op == '>' {
+		return value >= limit
+	}
+	return value <= limit
+}
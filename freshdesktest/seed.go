@@ -0,0 +1,145 @@
+package freshdesktest
+
+import (
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// The Add methods store records directly, without validation, and return
+// their ID. Fields left to their zero value get the defaults of the API;
+// an ID of zero assigns the next free one.
+
+// AddTicket stores a ticket. Its Conversations are stored as conversations
+// of the ticket.
+func (server *Server) AddTicket(ticket freshdesk.Ticket) uint64 {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	conversations := ticket.Conversations
+	ticket.Conversations = nil
+	r := toRecord(ticket)
+	delete(r, "conversations")
+	dropZero(r)
+	now := server.timestamp()
+	if created := r.string("created_at"); created != "" {
+		now = created
+	}
+	setDefaults(r, server.ticketDefaults(now))
+	ID := server.tickets.insert(r)
+
+	for _, conversation := range conversations {
+		server.addConversation(ID, conversation)
+	}
+	return ID
+}
+
+func (server *Server) AddConversation(ticketID uint64, conversation freshdesk.TicketMessage) uint64 {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.addConversation(ticketID, conversation)
+}
+
+func (server *Server) addConversation(ticketID uint64, conversation freshdesk.TicketMessage) uint64 {
+	r := toRecord(conversation)
+	dropZero(r)
+	r["ticket_id"] = float64(ticketID)
+	setDefaults(r, server.conversationDefaults(server.timestamp()))
+	return server.conversations.insert(r)
+}
+
+func (server *Server) AddContact(contact freshdesk.Contact) uint64 {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r := toRecord(contact)
+	dropZero(r)
+	setDefaults(r, server.contactDefaults(server.timestamp()))
+	return server.contacts.insert(r)
+}
+
+func (server *Server) AddCompany(company freshdesk.Company) uint64 {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r := toRecord(company)
+	dropZero(r)
+	setDefaults(r, server.companyDefaults(server.timestamp()))
+	return server.companies.insert(r)
+}
+
+func (server *Server) AddGroup(group freshdesk.Group) uint64 {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r := toRecord(group)
+	dropZero(r)
+	setDefaults(r, server.groupDefaults(server.timestamp()))
+	return server.groups.insert(r)
+}
+
+// AddCustomObject stores a record of a custom object schema and returns
+// its display ID.
+func (server *Server) AddCustomObject(schemaID uint64, data map[string]interface{}) string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.insertCustomObject(schemaID, toRecord(data)).string("display_id")
+}
+
+// The getters return the current state of a record, including soft
+// deleted ones, for assertions.
+
+func (server *Server) Ticket(ID uint64) (*freshdesk.Ticket, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r, ok := server.tickets.get(ID)
+	if !ok {
+		return nil, false
+	}
+	var ticket freshdesk.Ticket
+	fromRecord(r, &ticket)
+	for _, conversation := range server.ticketConversations(ID) {
+		var message freshdesk.TicketMessage
+		fromRecord(conversation, &message)
+		ticket.Conversations = append(ticket.Conversations, message)
+	}
+	return &ticket, true
+}
+
+func (server *Server) Contact(ID uint64) (*freshdesk.Contact, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r, ok := server.contacts.get(ID)
+	if !ok {
+		return nil, false
+	}
+	var contact freshdesk.Contact
+	fromRecord(r, &contact)
+	return &contact, true
+}
+
+func (server *Server) Company(ID uint64) (*freshdesk.Company, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r, ok := server.companies.get(ID)
+	if !ok {
+		return nil, false
+	}
+	var company freshdesk.Company
+	fromRecord(r, &company)
+	return &company, true
+}
+
+func (server *Server) Group(ID uint64) (*freshdesk.Group, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	r, ok := server.groups.get(ID)
+	if !ok {
+		return nil, false
+	}
+	var group freshdesk.Group
+	fromRecord(r, &group)
+	return &group, true
+}
@@ -0,0 +1,301 @@
+// Package freshdesktest provides an in-memory Freshdesk server for tests.
+//
+// The server emulates the v2 endpoints used by freshdesk.Client for
+// tickets, conversations, contacts, companies, groups, custom objects and
+// search, with the status codes, Link pagination and rate-limit headers
+// of the real API. Faults can be injected to test error handling.
+//
+//	server := freshdesktest.NewServer()
+//	defer server.Close()
+//	server.AddContact(freshdesk.Contact{Name: "Ann", Email: "ann@example.com"})
+//	client := server.Client()
+package freshdesktest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// APIKey is the key accepted by the server unless SetAPIKey changes it.
+const APIKey = "test-api-key"
+
+// Server is a fake Freshdesk helpdesk. Its methods are safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	// Now returns the time used for created_at and updated_at, time.Now by
+	// default. Replace it before sending requests for fixed timestamps.
+	Now func() time.Time
+
+	apiKey   string
+	pageSize int
+
+	tickets       *collection
+	conversations *collection
+	contacts      *collection
+	companies     *collection
+	groups        *collection
+	customObjects map[uint64]map[string]record
+
+	customObjectIDs map[uint64]int
+
+	rateLimit   int
+	windowStart time.Time
+	windowUsed  int
+
+	faults   []*Fault
+	requests []string
+}
+
+// NewServer starts a server with no data.
+func NewServer() *Server {
+	server := &Server{
+		Now:             time.Now,
+		apiKey:          APIKey,
+		pageSize:        30,
+		tickets:         newCollection(),
+		conversations:   newCollection(),
+		contacts:        newCollection(),
+		companies:       newCollection(),
+		groups:          newCollection(),
+		customObjects:   map[uint64]map[string]record{},
+		customObjectIDs: map[uint64]int{},
+		rateLimit:       10000,
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// Client returns a client of the server using APIKey.
+func (server *Server) Client() freshdesk.Client {
+	return freshdesk.NewClient(server.URL, server.apiKey, "X", 100000)
+}
+
+// SetAPIKey changes the key accepted by the server; an empty key disables
+// authentication.
+func (server *Server) SetAPIKey(key string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.apiKey = key
+}
+
+// SetRateLimit sets the requests allowed per minute, 10000 by default.
+// Requests beyond the limit get 429 Too Many Requests.
+func (server *Server) SetRateLimit(perMinute int) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.rateLimit = perMinute
+	server.windowStart = time.Time{}
+	server.windowUsed = 0
+}
+
+// Requests returns the requests served so far as "METHOD /path?query".
+func (server *Server) Requests() []string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]string(nil), server.requests...)
+}
+
+// Fault makes the server misbehave for the requests it matches.
+type Fault struct {
+	Method string // any method when empty
+	Path   string // path prefix, any path when empty
+
+	Status int    // response status; zero serves the request normally after Delay
+	Body   string // response body of the injected status
+	Delay  time.Duration
+	Drop   bool // close the connection without a response
+
+	Times int // number of requests affected, all when zero
+}
+
+// AddFault registers a fault. Faults are checked in the order they were
+// added and the first matching one applies.
+func (server *Server) AddFault(fault Fault) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+func (server *Server) ClearFaults() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = nil
+}
+
+func (server *Server) takeFault(r *http.Request) *Fault {
+	for i, fault := range server.faults {
+		if fault.Method != "" && !strings.EqualFold(fault.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		matched := *fault
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				server.faults = append(server.faults[:i:i], server.faults[i+1:]...)
+			}
+		}
+		return &matched
+	}
+	return nil
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mu.Lock()
+	server.requests = append(server.requests, r.Method+" "+r.URL.RequestURI())
+	fault := server.takeFault(r)
+	server.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Drop {
+			if hijacker, ok := w.(http.Hijacker); ok {
+				if conn, _, err := hijacker.Hijack(); err == nil {
+					conn.Close()
+					return
+				}
+			}
+			panic(http.ErrAbortHandler)
+		}
+		if fault.Status != 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(fault.Status)
+			fmt.Fprint(w, fault.Body)
+			return
+		}
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if !server.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"code":    "invalid_credentials",
+			"message": "You have to be logged in to perform this action.",
+		})
+		return
+	}
+	if !server.allowRequest(w) {
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2"), "/"), "/")
+	if !strings.HasPrefix(r.URL.Path, "/api/v2/") {
+		segments = nil
+	}
+	var served bool
+	if len(segments) > 0 {
+		switch segments[0] {
+		case "tickets":
+			served = server.serveTickets(w, r, segments[1:])
+		case "contacts":
+			served = server.serveContacts(w, r, segments[1:])
+		case "companies":
+			served = server.serveCompanies(w, r, segments[1:])
+		case "groups":
+			served = server.serveGroups(w, r, segments[1:])
+		case "admin":
+			if len(segments) > 1 && segments[1] == "groups" {
+				served = server.serveGroups(w, r, segments[2:])
+			}
+		case "search":
+			served = server.serveSearch(w, r, segments[1:])
+		case "custom_objects":
+			served = server.serveCustomObjects(w, r, segments[1:])
+		}
+	}
+	if !served {
+		writeJSON(w, http.StatusNotFound, map[string]string{
+			"code":    "invalid_url",
+			"message": "There is no such url",
+		})
+	}
+}
+
+func (server *Server) authorized(r *http.Request) bool {
+	if server.apiKey == "" {
+		return true
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Basic ")
+	decoded, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return false
+	}
+	user, _, _ := strings.Cut(string(decoded), ":")
+	return user == server.apiKey
+}
+
+// allowRequest counts the request in the current one-minute window and
+// sets the rate-limit headers of the API.
+func (server *Server) allowRequest(w http.ResponseWriter) bool {
+	now := time.Now()
+	if now.Sub(server.windowStart) >= time.Minute {
+		server.windowStart = now
+		server.windowUsed = 0
+	}
+
+	w.Header().Set("X-Ratelimit-Total", strconv.Itoa(server.rateLimit))
+	if server.windowUsed >= server.rateLimit {
+		retry := time.Minute - now.Sub(server.windowStart)
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{
+			"message": "You have exceeded the limit of requests per minute",
+		})
+		return false
+	}
+
+	server.windowUsed++
+	w.Header().Set("X-Ratelimit-Remaining", strconv.Itoa(server.rateLimit-server.windowUsed))
+	w.Header().Set("X-Ratelimit-Used-Currentrequest", "1")
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// fieldError is one entry of the errors of a 400 or 409 response.
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func writeValidationError(w http.ResponseWriter, status int, errors ...fieldError) {
+	writeJSON(w, status, map[string]interface{}{
+		"description": "Validation failed",
+		"errors":      errors,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (server *Server) timestamp() string {
+	return server.Now().UTC().Truncate(time.Second).Format(time.RFC3339)
+}
@@ -0,0 +1,231 @@
+package freshdesktest
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func TestMain(m *testing.M) {
+	// The client logs the failed requests of the fault tests.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// send makes a raw request with the API key of the server.
+func send(t *testing.T, server *Server, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(APIKey, "X")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp
+}
+
+func TestPagination(t *testing.T) {
+	server := newServer(t)
+	for i := 0; i < 65; i++ {
+		server.AddContact(freshdesk.Contact{Name: fmt.Sprint("Contact ", i), Email: fmt.Sprintf("c%d@example.com", i)})
+	}
+	client := server.Client()
+
+	var ids []uint64
+	for page, wantMore := range []bool{true, true, false} {
+		contacts, err, more := client.ListContacts(nil, 30, page+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if more != wantMore {
+			t.Errorf("page %d: more = %v", page+1, more)
+		}
+		for _, contact := range contacts {
+			ids = append(ids, contact.ID)
+		}
+	}
+	if len(ids) != 65 || ids[0] == ids[30] {
+		t.Errorf("listed %d contacts", len(ids))
+	}
+
+	// Clients following the Link header get every page.
+	all, err := client.GetAllContacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 65 {
+		t.Errorf("GetAllContacts returned %d contacts", len(all))
+	}
+
+	resp := send(t, server, "GET", "/api/v2/contacts?per_page=30&page=3", "")
+	if resp.Header.Get("Link") != "" {
+		t.Errorf("last page links to %q", resp.Header.Get("Link"))
+	}
+	if resp := send(t, server, "GET", "/api/v2/contacts?per_page=101", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("per_page over 100 answered %d", resp.StatusCode)
+	}
+}
+
+func TestStatusCodes(t *testing.T) {
+	server := newServer(t)
+	client := server.Client()
+
+	contact, err := client.CreateContact(freshdesk.ContactCreatePayload{Name: "Ann", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored, ok := server.Contact(contact.ID); !ok || stored.Email != "ann@example.com" {
+		t.Errorf("stored %+v", stored)
+	}
+	if _, err := client.SoftDeleteContact(contact.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := server.Contact(contact.ID); !stored.Deleted {
+		t.Error("contact not soft deleted")
+	}
+
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/api/v2/contacts", `{"name": "Bob", "email": "bob@example.com"}`, http.StatusCreated},
+		{"POST", "/api/v2/contacts", `{"name": "Bob again", "email": "bob@example.com"}`, http.StatusConflict},
+		{"POST", "/api/v2/contacts", `{"name": "Nobody"}`, http.StatusBadRequest},
+		{"DELETE", fmt.Sprintf("/api/v2/contacts/%d", contact.ID), "", http.StatusNotFound},
+		{"DELETE", fmt.Sprintf("/api/v2/contacts/%d/hard_delete", contact.ID), "", http.StatusNoContent},
+		{"GET", fmt.Sprintf("/api/v2/contacts/%d", contact.ID), "", http.StatusNotFound},
+		{"GET", "/api/v2/unknown", "", http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		if resp := send(t, server, tc.method, tc.path, tc.body); resp.StatusCode != tc.want {
+			t.Errorf("%s %s answered %d, want %d", tc.method, tc.path, resp.StatusCode, tc.want)
+		}
+	}
+
+	server.SetAPIKey("other")
+	if resp := send(t, server, "GET", "/api/v2/contacts", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("wrong key answered %d", resp.StatusCode)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	server := newServer(t)
+	server.SetRateLimit(2)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		resp := send(t, server, "GET", "/api/v2/contacts", "")
+		if resp.StatusCode != want {
+			t.Fatalf("request %d answered %d", i+1, resp.StatusCode)
+		}
+		if resp.Header.Get("X-Ratelimit-Total") != "2" {
+			t.Errorf("request %d: total %q", i+1, resp.Header.Get("X-Ratelimit-Total"))
+		}
+		if want == http.StatusTooManyRequests {
+			if resp.Header.Get("X-Ratelimit-Remaining") != "0" || resp.Header.Get("Retry-After") == "" {
+				t.Errorf("429 headers = %v", resp.Header)
+			}
+		} else if remaining := resp.Header.Get("X-Ratelimit-Remaining"); remaining != fmt.Sprint(1-i) {
+			t.Errorf("request %d: remaining %q", i+1, remaining)
+		}
+	}
+
+	server.SetRateLimit(10)
+	if resp := send(t, server, "GET", "/api/v2/contacts", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("after raising the limit answered %d", resp.StatusCode)
+	}
+}
+
+func TestFaultTimes(t *testing.T) {
+	server := newServer(t)
+	ID := server.AddContact(freshdesk.Contact{Name: "Ann", Email: "ann@example.com"})
+	server.AddFault(Fault{Method: "GET", Path: "/api/v2/contacts", Status: http.StatusTooManyRequests, Body: `{"message":"slow down"}`, Times: 2})
+
+	// The client retries 429 responses, the fault is used up on the third
+	// attempt.
+	contact, err := server.Client().GetContact(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.Name != "Ann" || len(server.Requests()) != 3 {
+		t.Errorf("got %+v after %d requests", contact, len(server.Requests()))
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.faults) != 0 {
+		t.Errorf("faults left: %d", len(server.faults))
+	}
+}
+
+func TestFaultMatching(t *testing.T) {
+	server := newServer(t)
+	server.AddFault(Fault{Method: "POST", Path: "/api/v2/contacts", Status: http.StatusInternalServerError, Body: `{"message":"boom"}`})
+	server.AddFault(Fault{Path: "/api/v2/groups", Status: http.StatusForbidden})
+
+	if resp := send(t, server, "GET", "/api/v2/contacts", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET answered %d", resp.StatusCode)
+	}
+	for i := 0; i < 2; i++ {
+		// Faults without Times stay.
+		if _, err := server.Client().CreateContact(freshdesk.ContactCreatePayload{Name: "Ann", Email: "ann@example.com"}); err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("create %d: %v", i+1, err)
+		}
+	}
+	if resp := send(t, server, "DELETE", "/api/v2/groups/1", ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("DELETE answered %d", resp.StatusCode)
+	}
+
+	server.ClearFaults()
+	if _, err := server.Client().CreateContact(freshdesk.ContactCreatePayload{Name: "Ann", Email: "ann@example.com"}); err != nil {
+		t.Errorf("after ClearFaults: %v", err)
+	}
+}
+
+func TestFaultDelayAndDrop(t *testing.T) {
+	server := newServer(t)
+	ID := server.AddContact(freshdesk.Contact{Name: "Ann", Email: "ann@example.com"})
+	server.AddFault(Fault{Delay: 50 * time.Millisecond, Times: 1})
+	// net/http resends idempotent requests on a dropped keep-alive
+	// connection, only the drop of a POST reaches the client.
+	server.AddFault(Fault{Method: "POST", Drop: true, Times: 1})
+	client := server.Client()
+
+	start := time.Now()
+	if _, err := client.GetContact(ID); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("delayed request served in %v", elapsed)
+	}
+	payload := freshdesk.ContactCreatePayload{Name: "Bob", Email: "bob@example.com"}
+	if _, err := client.CreateContact(payload); err == nil {
+		t.Error("dropped request succeeded")
+	}
+	if _, err := client.CreateContact(payload); err != nil {
+		t.Errorf("after the faults: %v", err)
+	}
+
+	want := []string{"GET /api/v2/contacts/1", "POST /api/v2/contacts", "POST /api/v2/contacts"}
+	if got := server.Requests(); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("requests = %v", got)
+	}
+}
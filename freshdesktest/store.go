@@ -0,0 +1,254 @@
+package freshdesktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// record is a resource as the API returns it, decoded from JSON.
+type record map[string]interface{}
+
+type collection struct {
+	nextID  uint64
+	records map[uint64]record
+}
+
+func newCollection() *collection {
+	return &collection{nextID: 1, records: map[uint64]record{}}
+}
+
+// insert stores the record under its id, or a new one when it has none.
+func (c *collection) insert(r record) uint64 {
+	ID := r.uint("id")
+	if ID == 0 {
+		ID = c.nextID
+		r["id"] = float64(ID)
+	}
+	if ID >= c.nextID {
+		c.nextID = ID + 1
+	}
+	c.records[ID] = r
+	return ID
+}
+
+func (c *collection) get(ID uint64) (record, bool) {
+	r, ok := c.records[ID]
+	return r, ok
+}
+
+// list returns the records kept by the filter, by id.
+func (c *collection) list(keep func(record) bool) []record {
+	var records []record
+	for _, r := range c.records {
+		if keep == nil || keep(r) {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].uint("id") < records[j].uint("id") })
+	return records
+}
+
+func (r record) uint(field string) uint64 {
+	switch value := r[field].(type) {
+	case float64:
+		return uint64(value)
+	case string:
+		ID, _ := strconv.ParseUint(value, 10, 64)
+		return ID
+	}
+	return 0
+}
+
+func (r record) string(field string) string {
+	value, _ := r[field].(string)
+	return value
+}
+
+func (r record) bool(field string) bool {
+	value, _ := r[field].(bool)
+	return value
+}
+
+func (r record) time(field string) time.Time {
+	t, _ := time.Parse(time.RFC3339, r.string(field))
+	return t
+}
+
+// matches reports whether the field equals the value, or contains it
+// when the field is a list. Strings are compared case-insensitively.
+func (r record) matches(field string, value string) bool {
+	switch fieldValue := r[field].(type) {
+	case []interface{}:
+		for _, item := range fieldValue {
+			if strings.EqualFold(formatValue(item), value) {
+				return true
+			}
+		}
+		return false
+	case nil:
+		return value == "" || value == "null"
+	default:
+		return strings.EqualFold(formatValue(fieldValue), value)
+	}
+}
+
+// merge sets the fields of the patch, as a PUT does.
+func (r record) merge(patch record) {
+	for name, value := range patch {
+		if name == "id" {
+			continue
+		}
+		if object, ok := value.(map[string]interface{}); ok {
+			if existing, ok := r[name].(map[string]interface{}); ok {
+				for key, v := range object {
+					existing[key] = v
+				}
+				continue
+			}
+		}
+		r[name] = value
+	}
+}
+
+func (r record) clone() record {
+	data, _ := json.Marshal(r)
+	var copied record
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		return value
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// toRecord converts an API type to a record.
+func toRecord(v interface{}) record {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// fromRecord converts a record to an API type.
+func fromRecord(r record, v interface{}) {
+	data, _ := json.Marshal(r)
+	json.Unmarshal(data, v)
+}
+
+// dropZero removes the fields the API types send with their zero value
+// when they are not set, so that seeded records get the server defaults.
+func dropZero(r record) {
+	for name, value := range r {
+		switch value := value.(type) {
+		case nil:
+			delete(r, name)
+		case float64:
+			if value == 0 {
+				delete(r, name)
+			}
+		case string:
+			if value == "" {
+				delete(r, name)
+			}
+		case bool:
+			if !value {
+				delete(r, name)
+			}
+		}
+	}
+}
+
+// setDefaults sets the fields missing from the record.
+func setDefaults(r record, defaults record) {
+	for name, value := range defaults {
+		if _, ok := r[name]; !ok {
+			r[name] = value
+		}
+	}
+}
+
+// readBody decodes the JSON request body.
+func readBody(w http.ResponseWriter, req *http.Request) (record, bool) {
+	var body record
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"code":    "invalid_json",
+			"message": "Request body has invalid json format",
+		})
+		return nil, false
+	}
+	return body, true
+}
+
+// page writes the records of the requested page with a Link header to
+// the next one, as the list endpoints do.
+func (server *Server) page(w http.ResponseWriter, req *http.Request, records []record) {
+	query := req.URL.Query()
+	perPage := server.pageSize
+	if value, err := strconv.Atoi(query.Get("per_page")); err == nil && value > 0 {
+		perPage = value
+	}
+	if perPage > 100 {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "per_page", Message: "Has to be less than or equal to 100", Code: "invalid_value"})
+		return
+	}
+	page := 1
+	if value, err := strconv.Atoi(query.Get("page")); err == nil && value > 0 {
+		page = value
+	}
+
+	start := (page - 1) * perPage
+	if start > len(records) {
+		start = len(records)
+	}
+	end := start + perPage
+	if end > len(records) {
+		end = len(records)
+	}
+
+	if end < len(records) {
+		query.Set("page", strconv.Itoa(page+1))
+		query.Set("per_page", strconv.Itoa(perPage))
+		next := url.URL{Path: req.URL.Path, RawQuery: query.Encode()}
+		w.Header().Set("Link", fmt.Sprintf(`<%v%v>; rel="next"`, server.URL, next.String()))
+	}
+
+	result := records[start:end]
+	if result == nil {
+		result = []record{}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// updatedSince keeps the records updated at or after the time given in
+// the named query parameter.
+func updatedSince(req *http.Request, param string) (func(record) bool, bool) {
+	value := req.URL.Query().Get(param)
+	if value == "" {
+		return func(record) bool { return true }, true
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, false
+	}
+	return func(r record) bool { return !r.time("updated_at").Before(since) }, true
+}
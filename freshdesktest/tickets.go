@@ -0,0 +1,348 @@
+package freshdesktest
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tickets include at most this many conversations.
+const includedConversations = 10
+
+func (server *Server) ticketDefaults(now string) record {
+	created, _ := time.Parse(time.RFC3339, now)
+	return record{
+		"attachments":     []interface{}{},
+		"cc_emails":       []interface{}{},
+		"fwd_emails":      []interface{}{},
+		"reply_cc_emails": []interface{}{},
+		"to_emails":       nil,
+		"tags":            []interface{}{},
+		"custom_fields":   map[string]interface{}{},
+		"deleted":         false,
+		"spam":            false,
+		"is_escalated":    false,
+		"fr_escalated":    false,
+		"status":          float64(2),
+		"priority":        float64(1),
+		"source":          float64(2),
+		"group_id":        nil,
+		"responder_id":    nil,
+		"product_id":      nil,
+		"email_config_id": nil,
+		"type":            nil,
+		"due_by":          created.Add(72 * time.Hour).Format(time.RFC3339),
+		"fr_due_by":       created.Add(24 * time.Hour).Format(time.RFC3339),
+		"created_at":      now,
+		"updated_at":      now,
+	}
+}
+
+func (server *Server) serveTickets(w http.ResponseWriter, req *http.Request, path []string) bool {
+	if len(path) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			server.listTickets(w, req)
+		case http.MethodPost:
+			server.createTicket(w, req)
+		default:
+			return false
+		}
+		return true
+	}
+
+	ID, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	ticket, ok := server.tickets.get(ID)
+	if !ok {
+		writeNotFound(w)
+		return true
+	}
+
+	if len(path) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			response := ticket.clone()
+			if req.URL.Query().Get("include") == "conversations" {
+				conversations := server.ticketConversations(ID)
+				if len(conversations) > includedConversations {
+					conversations = conversations[:includedConversations]
+				}
+				response["conversations"] = conversations
+			}
+			writeJSON(w, http.StatusOK, response)
+		case http.MethodPut:
+			server.updateTicket(w, req, ticket)
+		case http.MethodDelete:
+			if ticket.bool("deleted") {
+				writeNotFound(w)
+				return true
+			}
+			ticket["deleted"] = true
+			ticket["updated_at"] = server.timestamp()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			return false
+		}
+		return true
+	}
+
+	switch {
+	case path[1] == "conversations" && req.Method == http.MethodGet:
+		server.page(w, req, server.ticketConversations(ID))
+	case path[1] == "reply" && req.Method == http.MethodPost:
+		server.createConversation(w, req, ticket, false)
+	case path[1] == "notes" && req.Method == http.MethodPost:
+		server.createConversation(w, req, ticket, true)
+	case path[1] == "restore" && req.Method == http.MethodPut:
+		ticket["deleted"] = false
+		ticket["updated_at"] = server.timestamp()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+// listTickets filters like the ticket list: deleted and spam tickets only
+// with the matching filter, and without updated_since only the tickets
+// created in the last 30 days.
+func (server *Server) listTickets(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	since, ok := updatedSince(req, "updated_since")
+	if !ok {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "updated_since", Message: "It should be in the 'valid date time' format", Code: "invalid_value"})
+		return
+	}
+	recent := server.Now().Add(-30 * 24 * time.Hour)
+
+	requesterID := query.Get("requester_id")
+	if email := query.Get("email"); email != "" {
+		requesterID = "0"
+		if contact := server.findContact("email", email); contact != nil {
+			requesterID = formatValue(contact["id"])
+		}
+	}
+
+	tickets := server.tickets.list(func(ticket record) bool {
+		switch query.Get("filter") {
+		case "deleted":
+			if !ticket.bool("deleted") {
+				return false
+			}
+		case "spam":
+			if !ticket.bool("spam") || ticket.bool("deleted") {
+				return false
+			}
+		default:
+			if ticket.bool("deleted") || ticket.bool("spam") {
+				return false
+			}
+		}
+		if query.Get("updated_since") == "" && ticket.time("created_at").Before(recent) {
+			return false
+		}
+		if companyID := query.Get("company_id"); companyID != "" && !ticket.matches("company_id", companyID) {
+			return false
+		}
+		if requesterID != "" && !ticket.matches("requester_id", requesterID) {
+			return false
+		}
+		return since(ticket)
+	})
+
+	orderBy := query.Get("order_by")
+	if orderBy == "" {
+		orderBy = "created_at"
+	}
+	descending := query.Get("order_type") != "asc"
+	sort.SliceStable(tickets, func(i, j int) bool {
+		a, b := formatValue(tickets[i][orderBy]), formatValue(tickets[j][orderBy])
+		if orderBy == "status" || orderBy == "priority" {
+			a, b = padNumber(a), padNumber(b)
+		}
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+
+	server.page(w, req, tickets)
+}
+
+func padNumber(number string) string {
+	return strings.Repeat("0", 20-len(number)) + number
+}
+
+func (server *Server) createTicket(w http.ResponseWriter, req *http.Request) {
+	body, ok := readBody(w, req)
+	if !ok {
+		return
+	}
+
+	requesterFields := []string{"requester_id", "email", "phone", "twitter_id", "facebook_id", "unique_external_id"}
+	hasRequester := false
+	for _, field := range requesterFields {
+		if body[field] != nil && body[field] != "" {
+			hasRequester = true
+		}
+	}
+	if !hasRequester {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "requester_id", Message: "Please fill at least 1 of requester_id, phone, email, twitter_id, facebook_id, unique_external_id fields", Code: "missing_field"})
+		return
+	}
+
+	if body.uint("requester_id") == 0 {
+		body["requester_id"] = float64(server.requesterFor(body))
+	} else if _, ok := server.contacts.get(body.uint("requester_id")); !ok {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "requester_id", Message: "There is no contact matching the given requester_id", Code: "invalid_value"})
+		return
+	}
+	if body.uint("company_id") == 0 {
+		if contact, ok := server.contacts.get(body.uint("requester_id")); ok && contact.uint("company_id") != 0 {
+			body["company_id"] = contact["company_id"]
+		}
+	}
+
+	ticket := body
+	delete(ticket, "id")
+	setDefaults(ticket, server.ticketDefaults(server.timestamp()))
+	ticket["description_text"] = htmlToText(ticket.string("description"))
+	server.tickets.insert(ticket)
+	writeJSON(w, http.StatusCreated, ticket)
+}
+
+// requesterFor returns the contact with the email or phone of a new ticket,
+// creating it when missing as the API does.
+func (server *Server) requesterFor(body record) uint64 {
+	for _, field := range []string{"email", "phone", "twitter_id", "facebook_id", "unique_external_id"} {
+		if value := body.string(field); value != "" {
+			if contact := server.findContact(field, value); contact != nil {
+				return contact.uint("id")
+			}
+		}
+	}
+
+	contact := record{}
+	for _, field := range []string{"email", "phone", "twitter_id", "facebook_id", "unique_external_id"} {
+		if value := body.string(field); value != "" {
+			contact[field] = value
+		}
+	}
+	contact["name"] = body.string("name")
+	if contact["name"] == "" {
+		contact["name"] = strings.Split(body.string("email")+body.string("phone"), "@")[0]
+	}
+	setDefaults(contact, server.contactDefaults(server.timestamp()))
+	return server.contacts.insert(contact)
+}
+
+func (server *Server) updateTicket(w http.ResponseWriter, req *http.Request, ticket record) {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := req.ParseMultipartForm(32 << 20); err != nil {
+			writeValidationError(w, http.StatusBadRequest, fieldError{Field: "attachments", Message: err.Error(), Code: "invalid_value"})
+			return
+		}
+		attachments, _ := ticket["attachments"].([]interface{})
+		for _, header := range req.MultipartForm.File["attachments[]"] {
+			file, err := header.Open()
+			if err != nil {
+				continue
+			}
+			size, _ := io.Copy(io.Discard, file)
+			file.Close()
+			attachments = append(attachments, map[string]interface{}{
+				"id":           float64(len(attachments) + 1),
+				"name":         header.Filename,
+				"size":         float64(size),
+				"content_type": header.Header.Get("Content-Type"),
+				"created_at":   server.timestamp(),
+				"updated_at":   server.timestamp(),
+			})
+		}
+		ticket["attachments"] = attachments
+	} else {
+		body, ok := readBody(w, req)
+		if !ok {
+			return
+		}
+		ticket.merge(body)
+		if _, ok := body["description"]; ok {
+			ticket["description_text"] = htmlToText(ticket.string("description"))
+		}
+	}
+	ticket["updated_at"] = server.timestamp()
+	writeJSON(w, http.StatusOK, ticket)
+}
+
+func (server *Server) ticketConversations(ticketID uint64) []record {
+	conversations := server.conversations.list(func(conversation record) bool {
+		return conversation.uint("ticket_id") == ticketID
+	})
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[i].string("created_at") < conversations[j].string("created_at")
+	})
+	return conversations
+}
+
+func (server *Server) conversationDefaults(now string) record {
+	return record{
+		"body":           "",
+		"body_text":      "",
+		"incoming":       false,
+		"private":        false,
+		"user_id":        float64(1),
+		"support_email":  nil,
+		"source":         float64(0),
+		"category":       float64(3),
+		"to_emails":      []interface{}{},
+		"from_email":     nil,
+		"cc_emails":      []interface{}{},
+		"bcc_emails":     []interface{}{},
+		"attachments":    []interface{}{},
+		"last_edited_at": nil,
+		"created_at":     now,
+		"updated_at":     now,
+	}
+}
+
+func (server *Server) createConversation(w http.ResponseWriter, req *http.Request, ticket record, note bool) {
+	body, ok := readBody(w, req)
+	if !ok {
+		return
+	}
+	if body.string("body") == "" {
+		writeValidationError(w, http.StatusBadRequest, fieldError{Field: "body", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"})
+		return
+	}
+
+	conversation := body
+	delete(conversation, "id")
+	conversation["ticket_id"] = ticket["id"]
+	if note {
+		conversation["source"] = float64(2)
+		if _, ok := conversation["private"]; !ok {
+			conversation["private"] = true
+		}
+	} else if requester, ok := server.contacts.get(ticket.uint("requester_id")); ok && requester.string("email") != "" {
+		conversation["to_emails"] = []interface{}{requester.string("email")}
+	}
+	setDefaults(conversation, server.conversationDefaults(server.timestamp()))
+	conversation["body_text"] = htmlToText(conversation.string("body"))
+	server.conversations.insert(conversation)
+
+	ticket["updated_at"] = server.timestamp()
+	writeJSON(w, http.StatusCreated, conversation)
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func htmlToText(html string) string {
+	return strings.TrimSpace(htmlTag.ReplaceAllString(html, ""))
+}
@@ -0,0 +1,398 @@
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// TypeField returns a type-specific field of the asset by name, without
+// the suffix of the asset type ID the API appends to its key.
+func (asset *Asset) TypeField(name string) (interface{}, bool) {
+	if value, ok := asset.TypeFields[name]; ok {
+		return value, true
+	}
+	for key, value := range asset.TypeFields {
+		if typeFieldSuffix.ReplaceAllString(key, "") == name {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+var typeFieldSuffix = regexp.MustCompile(`_\d+$`)
+
+// Asset types
+func (service *freshServiceService) ListAssetTypes() ([]AssetType, error) {
+	var responseAll []AssetType
+	next := "/api/v2/asset_types?per_page=100"
+
+	for next != "" {
+		var responseSchema assetTypesResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.AssetTypes...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) GetAssetType(ID uint64) (*AssetType, error) {
+	var responseSchema assetTypeResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/asset_types/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.AssetType, nil
+}
+
+// ListAssetTypeFields returns the fields of an asset type, grouped by the
+// type, itself or one of its parents, defining them.
+func (service *freshServiceService) ListAssetTypeFields(ID uint64) ([]AssetTypeFieldGroup, error) {
+	var responseSchema assetTypeFieldsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/asset_types/%v/fields", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return responseSchema.AssetTypeFields, nil
+}
+
+// Asset, addressed by its display ID
+
+// GetAsset returns the asset with its type-specific fields.
+func (service *freshServiceService) GetAsset(displayID uint64) (*Asset, error) {
+	var responseSchema assetResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParam("include", "type_fields").
+		Get(fmt.Sprintf("%v%v", "/api/v2/assets/", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Asset, nil
+}
+
+// ListAssets returns a page of assets, without their type-specific fields
+// which cost an extra API call each.
+func (service *freshServiceService) ListAssets(pageSize, page int) ([]Asset, error, bool) {
+	return service.listAssets(map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	})
+}
+
+// SearchAssets returns a page of assets matching a search query on name,
+// asset_tag or serial_number, such as "name:'dell'".
+func (service *freshServiceService) SearchAssets(query string, page int) ([]Asset, error, bool) {
+	return service.listAssets(map[string]string{
+		"search": `"` + query + `"`,
+		"page":   fmt.Sprint(page),
+	})
+}
+
+// FilterAssets returns a page of assets matching a filter query such as
+// "asset_type_id:26000303 AND location_id:2".
+func (service *freshServiceService) FilterAssets(query string, page int) ([]Asset, error, bool) {
+	return service.listAssets(map[string]string{
+		"filter": `"` + query + `"`,
+		"page":   fmt.Sprint(page),
+	})
+}
+
+func (service *freshServiceService) listAssets(params map[string]string) ([]Asset, error, bool) {
+	var responseSchema assetsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/assets")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Assets, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshServiceService) CreateAsset(payload AssetPayload) (*Asset, error) {
+	var responseSchema assetResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/assets")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Asset, nil
+}
+
+func (service *freshServiceService) UpdateAsset(displayID uint64, payload AssetPayload) (*Asset, error) {
+	var responseSchema assetResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/assets/%v", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Asset, nil
+}
+
+// DeleteAsset moves the asset to the trash, RestoreAsset brings it back and
+// PermanentlyDeleteAsset erases a trashed asset.
+func (service *freshServiceService) DeleteAsset(displayID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/assets/", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) RestoreAsset(displayID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/assets/%v/restore", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) PermanentlyDeleteAsset(displayID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/assets/%v/delete_forever", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) ListAssetComponents(displayID uint64) ([]AssetComponent, error) {
+	var responseSchema componentsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/assets/%v/components", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return responseSchema.Components, nil
+}
+
+// Relationships
+func (service *freshServiceService) ListAssetRelationships(displayID uint64) ([]Relationship, error) {
+	var responseSchema relationshipsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("/api/v2/assets/%v/relationships", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return responseSchema.Relationships, nil
+}
+
+func (service *freshServiceService) ListRelationshipTypes() ([]RelationshipType, error) {
+	var responseSchema relationshipTypesResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get("/api/v2/relationship_types")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return responseSchema.RelationshipTypes, nil
+}
+
+// CreateRelationships queues the creation of the relationships and returns
+// the ID of the job to follow with GetJob.
+func (service *freshServiceService) CreateRelationships(relationships []RelationshipPayload) (string, error) {
+	var responseSchema jobResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{"relationships": relationships}).SetResult(&responseSchema).
+		Post("/api/v2/relationships/bulk-create")
+
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	if resp.StatusCode() != http.StatusAccepted {
+		return "", rest.Error(resp)
+	}
+
+	return responseSchema.JobID, nil
+}
+
+func (service *freshServiceService) GetJob(jobID string) (*Job, error) {
+	var responseSchema Job
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/jobs/", jobID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) DeleteRelationships(IDs []uint64) (*interface{}, error) {
+	ids := make([]string, len(IDs))
+	for i, ID := range IDs {
+		ids[i] = fmt.Sprint(ID)
+	}
+
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParam("ids", strings.Join(ids, ",")).
+		Delete("/api/v2/relationships")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// AttachAssetsToTicket links assets, by display ID, to a ticket. The
+// ticket may also come from the CreateSdTicket method of the Freshdesk
+// client, its ID being the same.
+func (service *freshServiceService) AttachAssetsToTicket(ticketID uint64, displayIDs []uint64) (*Ticket, error) {
+	assets := make([]AssetRef, len(displayIDs))
+	for i, displayID := range displayIDs {
+		assets[i] = AssetRef{DisplayID: displayID}
+	}
+
+	var responseSchema ticketResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{"assets": assets}).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/tickets/%v", ticketID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Ticket, nil
+}
@@ -0,0 +1,118 @@
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// Service catalog
+func (service *freshServiceService) ListServiceCategories() ([]ServiceCategory, error) {
+	var responseAll []ServiceCategory
+	next := "/api/v2/service_catalog/categories?per_page=100"
+
+	for next != "" {
+		var responseSchema serviceCategoriesResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.ServiceCategories...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+// ListServiceItems returns the items of a category, or of the whole
+// catalog when categoryID is 0.
+func (service *freshServiceService) ListServiceItems(categoryID uint64) ([]ServiceItem, error) {
+	var responseAll []ServiceItem
+	next := "/api/v2/service_catalog/items?per_page=100"
+	if categoryID != 0 {
+		next = fmt.Sprintf("%v&category_id=%v", next, categoryID)
+	}
+
+	for next != "" {
+		var responseSchema serviceItemsResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.ServiceItems...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) GetServiceItem(displayID uint64) (*ServiceItem, error) {
+	var responseSchema serviceItemResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/service_catalog/items/", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.ServiceItem, nil
+}
+
+// ListServiceItemFields returns the custom fields to fill in when placing a
+// request for the item, the API only listing them with the item itself.
+func (service *freshServiceService) ListServiceItemFields(displayID uint64) ([]ServiceItemField, error) {
+	item, err := service.GetServiceItem(displayID)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.CustomFields, nil
+}
+
+// PlaceServiceRequest requests a service item and returns the created
+// service request as a Ticket, as CreateTicket and the CreateSdTicket
+// method of the Freshdesk client do.
+func (service *freshServiceService) PlaceServiceRequest(displayID uint64, payload ServiceRequestPayload) (*Ticket, error) {
+	var responseSchema serviceRequestResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/service_catalog/items/%v/place_request", displayID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.ServiceRequest, nil
+}
@@ -0,0 +1,211 @@
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// Department
+func (service *freshServiceService) ListDepartments() ([]Department, error) {
+	var responseAll []Department
+	next := "/api/v2/departments?per_page=100"
+
+	for next != "" {
+		var responseSchema departmentsResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Departments...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) GetDepartment(ID uint64) (*Department, error) {
+	var responseSchema departmentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/departments/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Department, nil
+}
+
+func (service *freshServiceService) CreateDepartment(payload DepartmentPayload) (*Department, error) {
+	var responseSchema departmentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/departments")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Department, nil
+}
+
+func (service *freshServiceService) UpdateDepartment(ID uint64, payload DepartmentPayload) (*Department, error) {
+	var responseSchema departmentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/departments/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Department, nil
+}
+
+func (service *freshServiceService) DeleteDepartment(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/departments/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// Location
+func (service *freshServiceService) ListLocations() ([]Location, error) {
+	var responseAll []Location
+	next := "/api/v2/locations?per_page=100"
+
+	for next != "" {
+		var responseSchema locationsResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Locations...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) GetLocation(ID uint64) (*Location, error) {
+	var responseSchema locationResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/locations/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Location, nil
+}
+
+func (service *freshServiceService) CreateLocation(payload LocationPayload) (*Location, error) {
+	var responseSchema locationResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/locations")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Location, nil
+}
+
+func (service *freshServiceService) UpdateLocation(ID uint64, payload LocationPayload) (*Location, error) {
+	var responseSchema locationResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/locations/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Location, nil
+}
+
+func (service *freshServiceService) DeleteLocation(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/locations/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
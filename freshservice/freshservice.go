@@ -0,0 +1,354 @@
+// Package freshservice is a client for the Freshservice API v2. Freshservice
+// shares its authentication, errors and pagination with Freshdesk but has
+// its own ticket model, with impact, urgency and categories, and wraps
+// every record in an object named after its type.
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/ratelimit"
+)
+
+type Client interface {
+	GetTicket(ID uint64) (*Ticket, error)
+	GetAllTicketConversations(ID uint64) ([]Conversation, error)
+	ListTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool)
+	FilterTickets(query string, page int) ([]Ticket, error, bool)
+	CreateTicket(payload TicketCreatePayload) (*Ticket, error)
+	UpdateTicket(ID uint64, payload TicketUpdatePayload) (*Ticket, error)
+	DeleteTicket(ID uint64) (*interface{}, error)
+	RestoreTicket(ID uint64) (*interface{}, error)
+	CreateTicketReply(ID uint64, payload ReplyCreatePayload) (*Conversation, error)
+	CreateTicketNote(ID uint64, payload NoteCreatePayload) (*Conversation, error)
+
+	GetRequester(ID uint64) (*Requester, error)
+	FindRequesterByEmail(email string) (*Requester, error)
+	ListRequesters(pageSize, page int) ([]Requester, error, bool)
+	CreateRequester(payload RequesterPayload) (*Requester, error)
+	UpdateRequester(ID uint64, payload RequesterPayload) (*Requester, error)
+	DeactivateRequester(ID uint64) (*interface{}, error)
+	PermanentlyDeleteRequester(ID uint64) (*interface{}, error)
+
+	GetAgent(ID uint64) (*Agent, error)
+	ListAgents() ([]Agent, error)
+	CreateAgent(payload AgentPayload) (*Agent, error)
+	UpdateAgent(ID uint64, payload AgentPayload) (*Agent, error)
+	DeactivateAgent(ID uint64) (*interface{}, error)
+
+	ListDepartments() ([]Department, error)
+	GetDepartment(ID uint64) (*Department, error)
+	CreateDepartment(payload DepartmentPayload) (*Department, error)
+	UpdateDepartment(ID uint64, payload DepartmentPayload) (*Department, error)
+	DeleteDepartment(ID uint64) (*interface{}, error)
+
+	ListLocations() ([]Location, error)
+	GetLocation(ID uint64) (*Location, error)
+	CreateLocation(payload LocationPayload) (*Location, error)
+	UpdateLocation(ID uint64, payload LocationPayload) (*Location, error)
+	DeleteLocation(ID uint64) (*interface{}, error)
+
+	GetChange(ID uint64) (*Change, error)
+	ListChanges(updatedSince *time.Time, pageSize, page int) ([]Change, error, bool)
+	CreateChange(payload ChangePayload) (*Change, error)
+	UpdateChange(ID uint64, payload ChangePayload) (*Change, error)
+	DeleteChange(ID uint64) (*interface{}, error)
+
+	GetProblem(ID uint64) (*Problem, error)
+	ListProblems(updatedSince *time.Time, pageSize, page int) ([]Problem, error, bool)
+	CreateProblem(payload ProblemPayload) (*Problem, error)
+	UpdateProblem(ID uint64, payload ProblemPayload) (*Problem, error)
+	DeleteProblem(ID uint64) (*interface{}, error)
+
+	GetRelease(ID uint64) (*Release, error)
+	ListReleases(updatedSince *time.Time, pageSize, page int) ([]Release, error, bool)
+	CreateRelease(payload ReleasePayload) (*Release, error)
+	UpdateRelease(ID uint64, payload ReleasePayload) (*Release, error)
+	DeleteRelease(ID uint64) (*interface{}, error)
+
+	ListNotes(module Module, ID uint64) ([]Note, error)
+	CreateNote(module Module, ID uint64, payload NotePayload) (*Note, error)
+	UpdateNote(module Module, ID uint64, noteID uint64, payload NotePayload) (*Note, error)
+	DeleteNote(module Module, ID uint64, noteID uint64) (*interface{}, error)
+	ListTasks(module Module, ID uint64) ([]Task, error)
+	CreateTask(module Module, ID uint64, payload TaskPayload) (*Task, error)
+	UpdateTask(module Module, ID uint64, taskID uint64, payload TaskPayload) (*Task, error)
+	DeleteTask(module Module, ID uint64, taskID uint64) (*interface{}, error)
+	ListTimeEntries(module Module, ID uint64) ([]TimeEntry, error)
+	CreateTimeEntry(module Module, ID uint64, payload TimeEntryPayload) (*TimeEntry, error)
+	UpdateTimeEntry(module Module, ID uint64, timeEntryID uint64, payload TimeEntryPayload) (*TimeEntry, error)
+	DeleteTimeEntry(module Module, ID uint64, timeEntryID uint64) (*interface{}, error)
+
+	AssociateTicketWithProblem(ticketID uint64, problemID uint64) (*Ticket, error)
+	AssociateTicketWithChange(ticketID uint64, changeID uint64, relation TicketChangeRelation) (*Ticket, error)
+
+	ListAssetTypes() ([]AssetType, error)
+	GetAssetType(ID uint64) (*AssetType, error)
+	ListAssetTypeFields(ID uint64) ([]AssetTypeFieldGroup, error)
+
+	GetAsset(displayID uint64) (*Asset, error)
+	ListAssets(pageSize, page int) ([]Asset, error, bool)
+	SearchAssets(query string, page int) ([]Asset, error, bool)
+	FilterAssets(query string, page int) ([]Asset, error, bool)
+	CreateAsset(payload AssetPayload) (*Asset, error)
+	UpdateAsset(displayID uint64, payload AssetPayload) (*Asset, error)
+	DeleteAsset(displayID uint64) (*interface{}, error)
+	RestoreAsset(displayID uint64) (*interface{}, error)
+	PermanentlyDeleteAsset(displayID uint64) (*interface{}, error)
+	ListAssetComponents(displayID uint64) ([]AssetComponent, error)
+
+	ListAssetRelationships(displayID uint64) ([]Relationship, error)
+	ListRelationshipTypes() ([]RelationshipType, error)
+	CreateRelationships(relationships []RelationshipPayload) (string, error)
+	GetJob(jobID string) (*Job, error)
+	DeleteRelationships(IDs []uint64) (*interface{}, error)
+
+	AttachAssetsToTicket(ticketID uint64, displayIDs []uint64) (*Ticket, error)
+
+	ListServiceCategories() ([]ServiceCategory, error)
+	ListServiceItems(categoryID uint64) ([]ServiceItem, error)
+	GetServiceItem(displayID uint64) (*ServiceItem, error)
+	ListServiceItemFields(displayID uint64) ([]ServiceItemField, error)
+	PlaceServiceRequest(displayID uint64, payload ServiceRequestPayload) (*Ticket, error)
+}
+
+type freshServiceService struct {
+	restyClient *resty.Client
+	rateLimiter ratelimit.Limiter
+}
+
+// NewClient creates a client for the Freshservice account at baseUrl,
+// https://domain.freshservice.com, authenticated with the API key as user
+// and any password.
+func NewClient(baseUrl string, user string, password string, maxRequestPerMinute int) Client {
+	return NewClientWithTransport(baseUrl, user, password, maxRequestPerMinute, nil)
+}
+
+// NewClientWithTransport creates a client sending its requests through the
+// given transport, or the default one when nil. Every request waits for
+// the budget of maxRequestPerMinute, and requests answered 429 are retried
+// after the delay the API asks for.
+func NewClientWithTransport(baseUrl string, user string, password string, maxRequestPerMinute int, transport http.RoundTripper) Client {
+	service := &freshServiceService{
+		restyClient: rest.NewClient(baseUrl, user, password, transport),
+		rateLimiter: ratelimit.New(maxRequestPerMinute, ratelimit.Per(time.Second*60), ratelimit.WithSlack(100)),
+	}
+	rest.Limit(service.restyClient, service.rateLimiter)
+	return service
+}
+
+// Ticket
+func (service *freshServiceService) GetTicket(ID uint64) (*Ticket, error) {
+	var responseSchema ticketResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/tickets/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Ticket, nil
+}
+
+func (service *freshServiceService) GetAllTicketConversations(ID uint64) ([]Conversation, error) {
+	var responseAll []Conversation
+	next := fmt.Sprintf("/api/v2/tickets/%v/conversations?per_page=100", ID)
+
+	for next != "" {
+		var responseSchema conversationsResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Conversations...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+// ListTickets returns tickets updated since the given time, oldest update
+// first. Without updatedSince the API only returns tickets created in the
+// last 30 days.
+func (service *freshServiceService) ListTickets(updatedSince *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	params := map[string]string{
+		"per_page":   fmt.Sprint(pageSize),
+		"page":       fmt.Sprint(page),
+		"order_type": "asc",
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema ticketsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/tickets")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Tickets, nil, resp.Header().Get("Link") != ""
+}
+
+// FilterTickets returns a page of 30 tickets matching a filter query such
+// as "priority:3 AND status:2".
+func (service *freshServiceService) FilterTickets(query string, page int) ([]Ticket, error, bool) {
+	var responseSchema ticketsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(map[string]string{
+			"query": `"` + query + `"`,
+			"page":  fmt.Sprint(page),
+		}).
+		Get("/api/v2/tickets/filter")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Tickets, nil, uint64(page*30) < responseSchema.Total
+}
+
+func (service *freshServiceService) CreateTicket(payload TicketCreatePayload) (*Ticket, error) {
+	var responseSchema ticketResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/tickets")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Ticket, nil
+}
+
+func (service *freshServiceService) UpdateTicket(ID uint64, payload TicketUpdatePayload) (*Ticket, error) {
+	var responseSchema ticketResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/tickets/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Ticket, nil
+}
+
+// DeleteTicket moves the ticket to the trash, RestoreTicket brings it back.
+func (service *freshServiceService) DeleteTicket(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/tickets/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) RestoreTicket(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/tickets/%v/restore", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) CreateTicketReply(ID uint64, payload ReplyCreatePayload) (*Conversation, error) {
+	var responseSchema conversationResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/tickets/%v/reply", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if (resp.StatusCode() != http.StatusOK) && (resp.StatusCode() != http.StatusCreated) {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Conversation, nil
+}
+
+func (service *freshServiceService) CreateTicketNote(ID uint64, payload NoteCreatePayload) (*Conversation, error) {
+	var responseSchema conversationResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/tickets/%v/notes", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Conversation, nil
+}
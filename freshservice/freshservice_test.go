@@ -0,0 +1,573 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+type stubResponse struct {
+	Status int
+	Body   string
+	Header http.Header
+}
+
+// stubServer serves canned responses keyed by "METHOD /path", the last one
+// registered for a key repeating.
+type stubServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string][]stubResponse
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	stub := &stubServer{routes: map[string][]stubResponse{}}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		stub.mu.Lock()
+		stub.requests = append(stub.requests, r)
+		stub.bodies = append(stub.bodies, body)
+		key := r.Method + " " + r.URL.Path
+		queue := stub.routes[key]
+		response := stubResponse{Status: http.StatusNotFound, Body: `{"message":"no stub for ` + key + `"}`}
+		if len(queue) > 0 {
+			response = queue[0]
+			if len(queue) > 1 {
+				stub.routes[key] = queue[1:]
+			}
+		}
+		stub.mu.Unlock()
+
+		for name, values := range response.Header {
+			w.Header()[name] = values
+		}
+		if response.Body != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(response.Status)
+		io.WriteString(w, response.Body)
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func (stub *stubServer) on(method, path string, responses ...stubResponse) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	stub.routes[method+" "+path] = append(stub.routes[method+" "+path], responses...)
+}
+
+func (stub *stubServer) reply(method, path string, status int, body string) {
+	stub.on(method, path, stubResponse{Status: status, Body: body})
+}
+
+func (stub *stubServer) client() Client {
+	return NewClient(stub.URL, "api-key", "X", 60000)
+}
+
+type methodCase struct {
+	name   string
+	method string
+	path   string
+	status int
+	body   string
+	call   func(c Client) (interface{}, error)
+}
+
+func paged[T any](items []T, err error, _ bool) ([]T, error) {
+	return items, err
+}
+
+var methodCases = []methodCase{
+	{"GetTicket", "GET", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetTicket(7) }},
+	{"GetAllTicketConversations", "GET", "/api/v2/tickets/7/conversations", 200, `{"conversations":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.GetAllTicketConversations(7) }},
+	{"ListTickets", "GET", "/api/v2/tickets", 200, `{"tickets":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListTickets(nil, 30, 1)) }},
+	{"FilterTickets", "GET", "/api/v2/tickets/filter", 200, `{"tickets":[{"id":7}],"total":1}`,
+		func(c Client) (interface{}, error) { return paged(c.FilterTickets("status:2", 1)) }},
+	{"CreateTicket", "POST", "/api/v2/tickets", 201, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateTicket(TicketCreatePayload{}) }},
+	{"UpdateTicket", "PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateTicket(7, TicketUpdatePayload{}) }},
+	{"DeleteTicket", "DELETE", "/api/v2/tickets/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteTicket(7) }},
+	{"RestoreTicket", "PUT", "/api/v2/tickets/7/restore", 204, "",
+		func(c Client) (interface{}, error) { return c.RestoreTicket(7) }},
+	{"CreateTicketReply", "POST", "/api/v2/tickets/7/reply", 201, `{"conversation":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateTicketReply(7, ReplyCreatePayload{}) }},
+	{"CreateTicketNote", "POST", "/api/v2/tickets/7/notes", 201, `{"conversation":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateTicketNote(7, NoteCreatePayload{}) }},
+
+	{"GetRequester", "GET", "/api/v2/requesters/7", 200, `{"requester":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetRequester(7) }},
+	{"FindRequesterByEmail", "GET", "/api/v2/requesters", 200, `{"requesters":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.FindRequesterByEmail("jane@example.com") }},
+	{"ListRequesters", "GET", "/api/v2/requesters", 200, `{"requesters":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListRequesters(30, 1)) }},
+	{"CreateRequester", "POST", "/api/v2/requesters", 201, `{"requester":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateRequester(RequesterPayload{}) }},
+	{"UpdateRequester", "PUT", "/api/v2/requesters/7", 200, `{"requester":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateRequester(7, RequesterPayload{}) }},
+	{"DeactivateRequester", "DELETE", "/api/v2/requesters/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeactivateRequester(7) }},
+	{"PermanentlyDeleteRequester", "DELETE", "/api/v2/requesters/7/forget", 204, "",
+		func(c Client) (interface{}, error) { return c.PermanentlyDeleteRequester(7) }},
+
+	{"GetAgent", "GET", "/api/v2/agents/7", 200, `{"agent":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetAgent(7) }},
+	{"ListAgents", "GET", "/api/v2/agents", 200, `{"agents":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListAgents() }},
+	{"CreateAgent", "POST", "/api/v2/agents", 201, `{"agent":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateAgent(AgentPayload{}) }},
+	{"UpdateAgent", "PUT", "/api/v2/agents/7", 200, `{"agent":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateAgent(7, AgentPayload{}) }},
+	{"DeactivateAgent", "DELETE", "/api/v2/agents/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeactivateAgent(7) }},
+
+	{"ListDepartments", "GET", "/api/v2/departments", 200, `{"departments":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListDepartments() }},
+	{"GetDepartment", "GET", "/api/v2/departments/7", 200, `{"department":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetDepartment(7) }},
+	{"CreateDepartment", "POST", "/api/v2/departments", 201, `{"department":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateDepartment(DepartmentPayload{}) }},
+	{"UpdateDepartment", "PUT", "/api/v2/departments/7", 200, `{"department":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateDepartment(7, DepartmentPayload{}) }},
+	{"DeleteDepartment", "DELETE", "/api/v2/departments/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteDepartment(7) }},
+
+	{"ListLocations", "GET", "/api/v2/locations", 200, `{"locations":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListLocations() }},
+	{"GetLocation", "GET", "/api/v2/locations/7", 200, `{"location":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetLocation(7) }},
+	{"CreateLocation", "POST", "/api/v2/locations", 201, `{"location":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateLocation(LocationPayload{}) }},
+	{"UpdateLocation", "PUT", "/api/v2/locations/7", 200, `{"location":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateLocation(7, LocationPayload{}) }},
+	{"DeleteLocation", "DELETE", "/api/v2/locations/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteLocation(7) }},
+
+	{"GetChange", "GET", "/api/v2/changes/7", 200, `{"change":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetChange(7) }},
+	{"ListChanges", "GET", "/api/v2/changes", 200, `{"changes":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListChanges(nil, 30, 1)) }},
+	{"CreateChange", "POST", "/api/v2/changes", 201, `{"change":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateChange(ChangePayload{}) }},
+	{"UpdateChange", "PUT", "/api/v2/changes/7", 200, `{"change":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateChange(7, ChangePayload{}) }},
+	{"DeleteChange", "DELETE", "/api/v2/changes/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteChange(7) }},
+
+	{"GetProblem", "GET", "/api/v2/problems/7", 200, `{"problem":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetProblem(7) }},
+	{"ListProblems", "GET", "/api/v2/problems", 200, `{"problems":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListProblems(nil, 30, 1)) }},
+	{"CreateProblem", "POST", "/api/v2/problems", 201, `{"problem":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateProblem(ProblemPayload{}) }},
+	{"UpdateProblem", "PUT", "/api/v2/problems/7", 200, `{"problem":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateProblem(7, ProblemPayload{}) }},
+	{"DeleteProblem", "DELETE", "/api/v2/problems/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteProblem(7) }},
+
+	{"GetRelease", "GET", "/api/v2/releases/7", 200, `{"release":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetRelease(7) }},
+	{"ListReleases", "GET", "/api/v2/releases", 200, `{"releases":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListReleases(nil, 30, 1)) }},
+	{"CreateRelease", "POST", "/api/v2/releases", 201, `{"release":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateRelease(ReleasePayload{}) }},
+	{"UpdateRelease", "PUT", "/api/v2/releases/7", 200, `{"release":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateRelease(7, ReleasePayload{}) }},
+	{"DeleteRelease", "DELETE", "/api/v2/releases/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteRelease(7) }},
+
+	{"ListNotes", "GET", "/api/v2/changes/7/notes", 200, `{"notes":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListNotes(ModuleChanges, 7) }},
+	{"CreateNote", "POST", "/api/v2/changes/7/notes", 201, `{"note":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateNote(ModuleChanges, 7, NotePayload{}) }},
+	{"UpdateNote", "PUT", "/api/v2/changes/7/notes/3", 200, `{"note":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateNote(ModuleChanges, 7, 3, NotePayload{}) }},
+	{"DeleteNote", "DELETE", "/api/v2/changes/7/notes/3", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteNote(ModuleChanges, 7, 3) }},
+
+	{"ListTasks", "GET", "/api/v2/tickets/7/tasks", 200, `{"tasks":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListTasks(ModuleTickets, 7) }},
+	{"CreateTask", "POST", "/api/v2/tickets/7/tasks", 201, `{"task":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateTask(ModuleTickets, 7, TaskPayload{}) }},
+	{"UpdateTask", "PUT", "/api/v2/tickets/7/tasks/3", 200, `{"task":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateTask(ModuleTickets, 7, 3, TaskPayload{}) }},
+	{"DeleteTask", "DELETE", "/api/v2/tickets/7/tasks/3", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteTask(ModuleTickets, 7, 3) }},
+
+	{"ListTimeEntries", "GET", "/api/v2/releases/7/time_entries", 200, `{"time_entries":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListTimeEntries(ModuleReleases, 7) }},
+	{"CreateTimeEntry", "POST", "/api/v2/releases/7/time_entries", 201, `{"time_entry":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateTimeEntry(ModuleReleases, 7, TimeEntryPayload{}) }},
+	{"UpdateTimeEntry", "PUT", "/api/v2/releases/7/time_entries/3", 200, `{"time_entry":{"id":7}}`,
+		func(c Client) (interface{}, error) {
+			return c.UpdateTimeEntry(ModuleReleases, 7, 3, TimeEntryPayload{})
+		}},
+	{"DeleteTimeEntry", "DELETE", "/api/v2/releases/7/time_entries/3", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteTimeEntry(ModuleReleases, 7, 3) }},
+
+	{"AssociateTicketWithProblem", "PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.AssociateTicketWithProblem(7, 3) }},
+	{"AssociateTicketWithChange", "PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.AssociateTicketWithChange(7, 3, ChangeCausingTicket) }},
+
+	{"ListAssetTypes", "GET", "/api/v2/asset_types", 200, `{"asset_types":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListAssetTypes() }},
+	{"GetAssetType", "GET", "/api/v2/asset_types/7", 200, `{"asset_type":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetAssetType(7) }},
+	{"ListAssetTypeFields", "GET", "/api/v2/asset_types/7/fields", 200, `{"asset_type_fields":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListAssetTypeFields(7) }},
+
+	{"GetAsset", "GET", "/api/v2/assets/7", 200, `{"asset":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetAsset(7) }},
+	{"ListAssets", "GET", "/api/v2/assets", 200, `{"assets":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.ListAssets(30, 1)) }},
+	{"SearchAssets", "GET", "/api/v2/assets", 200, `{"assets":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.SearchAssets("name:'dell'", 1)) }},
+	{"FilterAssets", "GET", "/api/v2/assets", 200, `{"assets":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return paged(c.FilterAssets("location_id:2", 1)) }},
+	{"CreateAsset", "POST", "/api/v2/assets", 201, `{"asset":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.CreateAsset(AssetPayload{}) }},
+	{"UpdateAsset", "PUT", "/api/v2/assets/7", 200, `{"asset":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.UpdateAsset(7, AssetPayload{}) }},
+	{"DeleteAsset", "DELETE", "/api/v2/assets/7", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteAsset(7) }},
+	{"RestoreAsset", "PUT", "/api/v2/assets/7/restore", 204, "",
+		func(c Client) (interface{}, error) { return c.RestoreAsset(7) }},
+	{"PermanentlyDeleteAsset", "PUT", "/api/v2/assets/7/delete_forever", 204, "",
+		func(c Client) (interface{}, error) { return c.PermanentlyDeleteAsset(7) }},
+	{"ListAssetComponents", "GET", "/api/v2/assets/7/components", 200, `{"components":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListAssetComponents(7) }},
+
+	{"ListAssetRelationships", "GET", "/api/v2/assets/7/relationships", 200, `{"relationships":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListAssetRelationships(7) }},
+	{"ListRelationshipTypes", "GET", "/api/v2/relationship_types", 200, `{"relationship_types":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListRelationshipTypes() }},
+	{"GetJob", "GET", "/api/v2/jobs/j7", 200, `{"id":"j7","relationships":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.GetJob("j7") }},
+	{"DeleteRelationships", "DELETE", "/api/v2/relationships", 204, "",
+		func(c Client) (interface{}, error) { return c.DeleteRelationships([]uint64{7}) }},
+
+	{"AttachAssetsToTicket", "PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.AttachAssetsToTicket(7, []uint64{3}) }},
+
+	{"ListServiceCategories", "GET", "/api/v2/service_catalog/categories", 200, `{"service_categories":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListServiceCategories() }},
+	{"ListServiceItems", "GET", "/api/v2/service_catalog/items", 200, `{"service_items":[{"id":7}]}`,
+		func(c Client) (interface{}, error) { return c.ListServiceItems(0) }},
+	{"GetServiceItem", "GET", "/api/v2/service_catalog/items/7", 200, `{"service_item":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.GetServiceItem(7) }},
+	{"ListServiceItemFields", "GET", "/api/v2/service_catalog/items/7", 200, `{"service_item":{"id":3,"custom_fields":[{"id":7}]}}`,
+		func(c Client) (interface{}, error) { return c.ListServiceItemFields(7) }},
+	{"PlaceServiceRequest", "POST", "/api/v2/service_catalog/items/7/place_request", 201, `{"service_request":{"id":7}}`,
+		func(c Client) (interface{}, error) { return c.PlaceServiceRequest(7, ServiceRequestPayload{}) }},
+}
+
+func TestMethods(t *testing.T) {
+	for _, tc := range methodCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stub := newStubServer(t)
+			stub.reply(tc.method, tc.path, tc.status, tc.body)
+			result, err := tc.call(stub.client())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.body != "" {
+				// Every stubbed record has id 7, finding it proves the
+				// wrapper object was unwrapped.
+				data, _ := json.Marshal(result)
+				if !strings.Contains(string(data), `"id":7`) {
+					t.Errorf("got %s", data)
+				}
+			}
+			if r := stub.requests[0]; r.Method != tc.method || r.URL.Path != tc.path {
+				t.Errorf("sent %s %s", r.Method, r.URL.Path)
+			}
+
+			stub = newStubServer(t)
+			stub.reply(tc.method, tc.path, 400, `{"description":"Validation failed"}`)
+			if _, err := tc.call(stub.client()); err == nil || !strings.Contains(err.Error(), "Validation failed") {
+				t.Errorf("error body: got %v", err)
+			}
+
+			stub = newStubServer(t)
+			stub.reply(tc.method, tc.path, 500, "")
+			if _, err := tc.call(stub.client()); err == nil || err.Error() != "Invalid status received: 500" {
+				t.Errorf("empty error: got %v", err)
+			}
+		})
+	}
+}
+
+func TestListTicketsQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets", stubResponse{Status: 200, Body: `{"tickets":[{"id":1,"impact":3,"urgency":2,"category":"Hardware"}]}`, Header: http.Header{"Link": {`<` + "https://x/api/v2/tickets?page=2" + `>; rel="next"`}}})
+	since := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tickets, err, hasMore := stub.client().ListTickets(&since, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasMore || len(tickets) != 1 || tickets[0].Impact != ImpactHigh || tickets[0].Urgency != UrgencyMedium || tickets[0].Category != "Hardware" {
+		t.Errorf("got %+v, hasMore %v", tickets, hasMore)
+	}
+	query := stub.requests[0].URL.Query()
+	if query.Get("updated_since") != "2023-05-01T12:00:00Z" || query.Get("per_page") != "100" || query.Get("page") != "2" {
+		t.Errorf("query %v", query)
+	}
+}
+
+func TestFilterTicketsPaging(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/tickets/filter", 200, `{"tickets":[{"id":1}],"total":45}`)
+
+	_, err, hasMore := stub.client().FilterTickets("priority:3", 1)
+	if err != nil || !hasMore {
+		t.Errorf("page 1: %v, hasMore %v", err, hasMore)
+	}
+	if got := stub.requests[0].URL.Query().Get("query"); got != `"priority:3"` {
+		t.Errorf("query = %q", got)
+	}
+	if _, _, hasMore := stub.client().FilterTickets("priority:3", 2); hasMore {
+		t.Error("page 2: hasMore set past the total")
+	}
+}
+
+func TestCreateTicketPayload(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"ticket":{"id":7}}`)
+
+	_, err := stub.client().CreateTicket(TicketCreatePayload{
+		Email:    "jane@example.com",
+		Subject:  "Laptop broken",
+		Status:   StatusOpen,
+		Priority: PriorityHigh,
+		Impact:   ImpactMedium,
+		Urgency:  UrgencyHigh,
+		Category: "Hardware",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(stub.bodies[0], &sent)
+	want := map[string]interface{}{"status": 2.0, "priority": 3.0, "impact": 2.0, "urgency": 3.0, "category": "Hardware"}
+	for key, value := range want {
+		if sent[key] != value {
+			t.Errorf("%s = %v, want %v", key, sent[key], value)
+		}
+	}
+	if _, ok := sent["source"]; ok {
+		t.Error("unset source sent")
+	}
+}
+
+func TestFindRequesterByEmailNotFound(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/requesters", 200, `{"requesters":[]}`)
+
+	_, err := stub.client().FindRequesterByEmail("nobody@example.com")
+	if err == nil || err.Error() != ERR_REQUESTER_NOT_FOUND {
+		t.Errorf("got %v", err)
+	}
+	if got := stub.requests[0].URL.Query().Get("email"); got != "nobody@example.com" {
+		t.Errorf("email = %q", got)
+	}
+}
+
+func TestListAgentsPages(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/agents",
+		stubResponse{Status: 200, Body: `{"agents":[{"id":1}]}`, Header: http.Header{"Link": {"<" + stub.URL + `/api/v2/agents?per_page=100&page=2>; rel="next"`}}},
+		stubResponse{Status: 200, Body: `{"agents":[{"id":2,"roles":[{"role_id":3,"assignment_scope":"entire_helpdesk"}]}]}`},
+	)
+
+	agents, err := stub.client().ListAgents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 || agents[1].Roles[0].RoleID != 3 {
+		t.Errorf("got %+v", agents)
+	}
+}
+
+func TestChangeEnums(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/changes", 201, `{"change":{"id":7,"risk":4,"change_type":4,"status":3,"impact":3}}`)
+
+	change, err := stub.client().CreateChange(ChangePayload{
+		Subject:    "Replace core switch",
+		Risk:       RiskVeryHigh,
+		ChangeType: ChangeTypeEmergency,
+		Status:     ChangeStatusAwaitingApproval,
+		Impact:     ImpactHigh,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.Risk != RiskVeryHigh || change.ChangeType != ChangeTypeEmergency || change.Status != ChangeStatusAwaitingApproval {
+		t.Errorf("got %+v", change)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(stub.bodies[0], &sent)
+	if sent["risk"] != 4.0 || sent["change_type"] != 4.0 || sent["status"] != 3.0 || sent["impact"] != 3.0 {
+		t.Errorf("sent %v", sent)
+	}
+}
+
+func TestAssociateTicketBody(t *testing.T) {
+	cases := []struct {
+		associate func(c Client) (*Ticket, error)
+		want      string
+	}{
+		{func(c Client) (*Ticket, error) { return c.AssociateTicketWithProblem(7, 3) }, `{"problem":{"id":3}}`},
+		{func(c Client) (*Ticket, error) { return c.AssociateTicketWithChange(7, 4, ChangeInitiatedByTicket) }, `{"change_initiated_by_ticket":{"id":4}}`},
+		{func(c Client) (*Ticket, error) { return c.AssociateTicketWithChange(7, 5, ChangeCausingTicket) }, `{"change_initiating_ticket":{"id":5}}`},
+	}
+	for _, tc := range cases {
+		stub := newStubServer(t)
+		stub.reply("PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`)
+		if _, err := tc.associate(stub.client()); err != nil {
+			t.Fatal(err)
+		}
+		if got := string(stub.bodies[0]); got != tc.want {
+			t.Errorf("sent %s, want %s", got, tc.want)
+		}
+	}
+}
+
+func TestAssetQueries(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/assets/7", 200, `{"asset":{"id":7}}`)
+	stub.reply("GET", "/api/v2/assets", 200, `{"assets":[]}`)
+	client := stub.client()
+
+	client.GetAsset(7)
+	client.SearchAssets("name:'dell'", 2)
+	client.FilterAssets("location_id:2", 1)
+	client.DeleteRelationships([]uint64{3, 4})
+
+	if got := stub.requests[0].URL.Query().Get("include"); got != "type_fields" {
+		t.Errorf("include = %q", got)
+	}
+	if got := stub.requests[1].URL.Query(); got.Get("search") != `"name:'dell'"` || got.Get("page") != "2" {
+		t.Errorf("search query = %v", got)
+	}
+	if got := stub.requests[2].URL.Query().Get("filter"); got != `"location_id:2"` {
+		t.Errorf("filter = %q", got)
+	}
+	if got := stub.requests[3].URL.Query().Get("ids"); got != "3,4" {
+		t.Errorf("ids = %q", got)
+	}
+}
+
+func TestAssetTypeField(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/assets/7", 200, `{"asset":{"id":7,"impact":"high","usage_type":"loaner","type_fields":{"product_26000303":12,"serial_number_26000303":"SN1","warranty":"2027-01-01"}}}`)
+
+	asset, err := stub.client().GetAsset(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Impact != AssetImpactHigh || asset.UsageType != UsageLoaner {
+		t.Errorf("got %+v", asset)
+	}
+	if value, ok := asset.TypeField("serial_number"); !ok || value != "SN1" {
+		t.Errorf("serial_number = %v, %v", value, ok)
+	}
+	if value, ok := asset.TypeField("warranty"); !ok || value != "2027-01-01" {
+		t.Errorf("warranty = %v, %v", value, ok)
+	}
+	if _, ok := asset.TypeField("product_id"); ok {
+		t.Error("found a missing field")
+	}
+}
+
+func TestCreateRelationships(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/relationships/bulk-create", 202, `{"job_id":"j7","href":"https://x/api/v2/jobs/j7"}`)
+
+	jobID, err := stub.client().CreateRelationships([]RelationshipPayload{
+		{RelationshipTypeID: 3, PrimaryID: 7, PrimaryType: "asset", SecondaryID: 8, SecondaryType: "asset"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobID != "j7" {
+		t.Errorf("job ID = %q", jobID)
+	}
+	want := `{"relationships":[{"relationship_type_id":3,"primary_id":7,"primary_type":"asset","secondary_id":8,"secondary_type":"asset"}]}`
+	if got := string(stub.bodies[0]); got != want {
+		t.Errorf("sent %s", got)
+	}
+}
+
+func TestAttachAssetsToTicketBody(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/tickets/7", 200, `{"ticket":{"id":7}}`)
+
+	if _, err := stub.client().AttachAssetsToTicket(7, []uint64{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(stub.bodies[0]); got != `{"assets":[{"display_id":3},{"display_id":4}]}` {
+		t.Errorf("sent %s", got)
+	}
+}
+
+func TestListServiceItemsCategory(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/service_catalog/items", 200, `{"service_items":[{"id":1,"display_id":4,"category_id":3,"custom_fields":[{"name":"cf_ram","label":"RAM","field_type":"custom_dropdown","required":true,"choices":["8GB","16GB"]}]}]}`)
+
+	items, err := stub.client().ListServiceItems(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].CustomFields[0].Name != "cf_ram" || !items[0].CustomFields[0].Required {
+		t.Errorf("got %+v", items)
+	}
+	if got := stub.requests[0].URL.Query().Get("category_id"); got != "3" {
+		t.Errorf("category_id = %q", got)
+	}
+}
+
+func TestPlaceServiceRequestPayload(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/service_catalog/items/4/place_request", 201, `{"service_request":{"id":9,"type":"Service Request","status":2}}`)
+
+	ticket, err := stub.client().PlaceServiceRequest(4, ServiceRequestPayload{
+		Quantity:     2,
+		Email:        "manager@example.com",
+		RequestedFor: "newhire@example.com",
+		CustomFields: map[string]interface{}{"cf_ram": "16GB"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 9 || ticket.Type != "Service Request" || ticket.Status != StatusOpen {
+		t.Errorf("got %+v", ticket)
+	}
+	want := `{"quantity":2,"email":"manager@example.com","requested_for":"newhire@example.com","custom_fields":{"cf_ram":"16GB"}}`
+	if got := string(stub.bodies[0]); got != want {
+		t.Errorf("sent %s", got)
+	}
+}
@@ -0,0 +1,313 @@
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// Change
+func (service *freshServiceService) GetChange(ID uint64) (*Change, error) {
+	var responseSchema changeResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/changes/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Change, nil
+}
+
+func (service *freshServiceService) ListChanges(updatedSince *time.Time, pageSize, page int) ([]Change, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema changesResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/changes")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Changes, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshServiceService) CreateChange(payload ChangePayload) (*Change, error) {
+	var responseSchema changeResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/changes")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Change, nil
+}
+
+func (service *freshServiceService) UpdateChange(ID uint64, payload ChangePayload) (*Change, error) {
+	var responseSchema changeResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/changes/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Change, nil
+}
+
+func (service *freshServiceService) DeleteChange(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/changes/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// Problem
+func (service *freshServiceService) GetProblem(ID uint64) (*Problem, error) {
+	var responseSchema problemResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/problems/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Problem, nil
+}
+
+func (service *freshServiceService) ListProblems(updatedSince *time.Time, pageSize, page int) ([]Problem, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema problemsResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/problems")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Problems, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshServiceService) CreateProblem(payload ProblemPayload) (*Problem, error) {
+	var responseSchema problemResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/problems")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Problem, nil
+}
+
+func (service *freshServiceService) UpdateProblem(ID uint64, payload ProblemPayload) (*Problem, error) {
+	var responseSchema problemResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/problems/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Problem, nil
+}
+
+func (service *freshServiceService) DeleteProblem(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/problems/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// Release
+func (service *freshServiceService) GetRelease(ID uint64) (*Release, error) {
+	var responseSchema releaseResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/releases/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Release, nil
+}
+
+func (service *freshServiceService) ListReleases(updatedSince *time.Time, pageSize, page int) ([]Release, error, bool) {
+	params := map[string]string{
+		"per_page": fmt.Sprint(pageSize),
+		"page":     fmt.Sprint(page),
+	}
+	if updatedSince != nil {
+		params["updated_since"] = updatedSince.UTC().Format(time.RFC3339)
+	}
+
+	var responseSchema releasesResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(params).
+		Get("/api/v2/releases")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Releases, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshServiceService) CreateRelease(payload ReleasePayload) (*Release, error) {
+	var responseSchema releaseResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/releases")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Release, nil
+}
+
+func (service *freshServiceService) UpdateRelease(ID uint64, payload ReleasePayload) (*Release, error) {
+	var responseSchema releaseResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/releases/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Release, nil
+}
+
+func (service *freshServiceService) DeleteRelease(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/releases/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
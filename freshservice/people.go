@@ -0,0 +1,251 @@
+package freshservice
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// Requester
+func (service *freshServiceService) GetRequester(ID uint64) (*Requester, error) {
+	var responseSchema requesterResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/requesters/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Requester, nil
+}
+
+func (service *freshServiceService) FindRequesterByEmail(email string) (*Requester, error) {
+	var responseSchema requestersResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParam("email", email).
+		Get("/api/v2/requesters")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	if len(responseSchema.Requesters) == 0 {
+		return nil, errors.New(ERR_REQUESTER_NOT_FOUND)
+	}
+
+	return &responseSchema.Requesters[0], nil
+}
+
+func (service *freshServiceService) ListRequesters(pageSize, page int) ([]Requester, error, bool) {
+	var responseSchema requestersResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		SetQueryParams(map[string]string{
+			"per_page": fmt.Sprint(pageSize),
+			"page":     fmt.Sprint(page),
+		}).
+		Get("/api/v2/requesters")
+
+	if err != nil {
+		return nil, err, false
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp), false
+	}
+
+	return responseSchema.Requesters, nil, resp.Header().Get("Link") != ""
+}
+
+func (service *freshServiceService) CreateRequester(payload RequesterPayload) (*Requester, error) {
+	var responseSchema requesterResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/requesters")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Requester, nil
+}
+
+func (service *freshServiceService) UpdateRequester(ID uint64, payload RequesterPayload) (*Requester, error) {
+	var responseSchema requesterResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/requesters/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Requester, nil
+}
+
+// DeactivateRequester keeps the requester and its tickets but prevents it
+// from logging in, PermanentlyDeleteRequester erases both.
+func (service *freshServiceService) DeactivateRequester(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/requesters/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) PermanentlyDeleteRequester(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v%v", "/api/v2/requesters/", ID, "/forget"))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// Agent
+func (service *freshServiceService) GetAgent(ID uint64) (*Agent, error) {
+	var responseSchema agentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Get(fmt.Sprintf("%v%v", "/api/v2/agents/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Agent, nil
+}
+
+func (service *freshServiceService) ListAgents() ([]Agent, error) {
+	var responseAll []Agent
+	next := "/api/v2/agents?per_page=100"
+
+	for next != "" {
+		var responseSchema agentsResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Agents...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) CreateAgent(payload AgentPayload) (*Agent, error) {
+	var responseSchema agentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post("/api/v2/agents")
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Agent, nil
+}
+
+func (service *freshServiceService) UpdateAgent(ID uint64, payload AgentPayload) (*Agent, error) {
+	var responseSchema agentResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/agents/%v", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Agent, nil
+}
+
+// DeactivateAgent turns the agent into a requester.
+func (service *freshServiceService) DeactivateAgent(ID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("%v%v", "/api/v2/agents/", ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
@@ -0,0 +1,995 @@
+package freshservice
+
+import (
+	"time"
+)
+
+// Ticket
+type Ticket struct {
+	Attachments     []Attachment           `json:"attachments"`
+	CcEmails        []string               `json:"cc_emails"`
+	Category        string                 `json:"category"`
+	CreatedAt       *time.Time             `json:"created_at"`
+	CustomFields    map[string]interface{} `json:"custom_fields"`
+	DepartmentID    uint64                 `json:"department_id"`
+	Deleted         bool                   `json:"deleted"`
+	Description     string                 `json:"description"`
+	DescriptionText string                 `json:"description_text"`
+	DueBy           *time.Time             `json:"due_by"`
+	EmailConfigID   uint64                 `json:"email_config_id"`
+	FrDueBy         *time.Time             `json:"fr_due_by"`
+	FrEscalated     bool                   `json:"fr_escalated"`
+	FwdEmails       []string               `json:"fwd_emails"`
+	GroupID         uint64                 `json:"group_id"`
+	ID              uint64                 `json:"id"`
+	Impact          Impact                 `json:"impact"`
+	IsEscalated     bool                   `json:"is_escalated"`
+	ItemCategory    string                 `json:"item_category"`
+	Priority        Priority               `json:"priority"`
+	ReplyCcEmails   []string               `json:"reply_cc_emails"`
+	RequesterID     uint64                 `json:"requester_id"`
+	ResponderID     uint64                 `json:"responder_id"`
+	Source          Source                 `json:"source"`
+	Spam            bool                   `json:"spam"`
+	Status          Status                 `json:"status"`
+	SubCategory     string                 `json:"sub_category"`
+	Subject         string                 `json:"subject"`
+	Tags            []string               `json:"tags"`
+	ToEmails        []string               `json:"to_emails"`
+	Type            string                 `json:"type"`
+	UpdatedAt       *time.Time             `json:"updated_at"`
+	Urgency         Urgency                `json:"urgency"`
+	WorkspaceID     uint64                 `json:"workspace_id,omitempty"`
+}
+
+type Status int64
+
+const (
+	StatusOpen     Status = 2
+	StatusPending  Status = 3
+	StatusResolved Status = 4
+	StatusClosed   Status = 5
+)
+
+type Priority int64
+
+const (
+	PriorityLow    Priority = 1
+	PriorityMedium Priority = 2
+	PriorityHigh   Priority = 3
+	PriorityUrgent Priority = 4
+)
+
+type Impact int64
+
+const (
+	ImpactLow    Impact = 1
+	ImpactMedium Impact = 2
+	ImpactHigh   Impact = 3
+)
+
+type Urgency int64
+
+const (
+	UrgencyLow    Urgency = 1
+	UrgencyMedium Urgency = 2
+	UrgencyHigh   Urgency = 3
+)
+
+type Source int64
+
+const (
+	SourceEmail          Source = 1
+	SourcePortal         Source = 2
+	SourcePhone          Source = 3
+	SourceChat           Source = 4
+	SourceFeedbackWidget Source = 5
+	SourceYammer         Source = 6
+	SourceAWSCloudwatch  Source = 7
+	SourcePagerduty      Source = 8
+	SourceWalkup         Source = 9
+	SourceSlack          Source = 10
+)
+
+type Attachment struct {
+	AttachmentURL string     `json:"attachment_url"`
+	ContentType   string     `json:"content_type"`
+	CreatedAt     *time.Time `json:"created_at"`
+	ID            uint64     `json:"id"`
+	Name          string     `json:"name"`
+	Size          uint64     `json:"size"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+// TicketCreatePayload identifies the requester by RequesterID, Email or
+// Phone; Status, Priority and Source are required by the API.
+type TicketCreatePayload struct {
+	Name         string                 `json:"name,omitempty"`
+	RequesterID  uint64                 `json:"requester_id,omitempty"`
+	Email        string                 `json:"email,omitempty"`
+	Phone        string                 `json:"phone,omitempty"`
+	Subject      string                 `json:"subject,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Status       Status                 `json:"status"`
+	Priority     Priority               `json:"priority"`
+	Source       Source                 `json:"source,omitempty"`
+	Impact       Impact                 `json:"impact,omitempty"`
+	Urgency      Urgency                `json:"urgency,omitempty"`
+	Category     string                 `json:"category,omitempty"`
+	SubCategory  string                 `json:"sub_category,omitempty"`
+	ItemCategory string                 `json:"item_category,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ResponderID  uint64                 `json:"responder_id,omitempty"`
+	GroupID      uint64                 `json:"group_id,omitempty"`
+	DepartmentID uint64                 `json:"department_id,omitempty"`
+	CcEmails     []string               `json:"cc_emails,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	DueBy        *time.Time             `json:"due_by,omitempty"`
+	FrDueBy      *time.Time             `json:"fr_due_by,omitempty"`
+	Assets       []AssetRef             `json:"assets,omitempty"`
+	WorkspaceID  uint64                 `json:"workspace_id,omitempty"`
+}
+
+type TicketUpdatePayload struct {
+	Name         string                 `json:"name,omitempty"`
+	RequesterID  uint64                 `json:"requester_id,omitempty"`
+	Email        string                 `json:"email,omitempty"`
+	Phone        string                 `json:"phone,omitempty"`
+	Subject      string                 `json:"subject,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Status       Status                 `json:"status,omitempty"`
+	Priority     Priority               `json:"priority,omitempty"`
+	Source       Source                 `json:"source,omitempty"`
+	Impact       Impact                 `json:"impact,omitempty"`
+	Urgency      Urgency                `json:"urgency,omitempty"`
+	Category     string                 `json:"category,omitempty"`
+	SubCategory  string                 `json:"sub_category,omitempty"`
+	ItemCategory string                 `json:"item_category,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ResponderID  uint64                 `json:"responder_id,omitempty"`
+	GroupID      uint64                 `json:"group_id,omitempty"`
+	DepartmentID uint64                 `json:"department_id,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	DueBy        *time.Time             `json:"due_by,omitempty"`
+	FrDueBy      *time.Time             `json:"fr_due_by,omitempty"`
+}
+
+type Conversation struct {
+	Attachments  []Attachment `json:"attachments"`
+	Body         string       `json:"body"`
+	BodyText     string       `json:"body_text"`
+	CcEmails     []string     `json:"cc_emails"`
+	CreatedAt    *time.Time   `json:"created_at"`
+	FromEmail    string       `json:"from_email"`
+	ID           uint64       `json:"id"`
+	Incoming     bool         `json:"incoming"`
+	Private      bool         `json:"private"`
+	Source       int64        `json:"source"`
+	SupportEmail string       `json:"support_email"`
+	TicketID     uint64       `json:"ticket_id"`
+	ToEmails     []string     `json:"to_emails"`
+	UpdatedAt    *time.Time   `json:"updated_at"`
+	UserID       uint64       `json:"user_id"`
+}
+
+type ReplyCreatePayload struct {
+	Body      string   `json:"body"`
+	FromEmail string   `json:"from_email,omitempty"`
+	UserID    uint64   `json:"user_id,omitempty"`
+	CcEmails  []string `json:"cc_emails,omitempty"`
+	BccEmails []string `json:"bcc_emails,omitempty"`
+}
+
+type NoteCreatePayload struct {
+	Body         string   `json:"body"`
+	Private      bool     `json:"private"`
+	Incoming     bool     `json:"incoming,omitempty"`
+	UserID       uint64   `json:"user_id,omitempty"`
+	NotifyEmails []string `json:"notify_emails,omitempty"`
+}
+
+// Requester
+type Requester struct {
+	Active                    bool                   `json:"active"`
+	Address                   string                 `json:"address"`
+	BackgroundInformation     string                 `json:"background_information"`
+	CanSeeAllTicketsFromDepts bool                   `json:"can_see_all_tickets_from_associated_departments"`
+	CreatedAt                 *time.Time             `json:"created_at"`
+	CustomFields              map[string]interface{} `json:"custom_fields"`
+	DepartmentIDs             []uint64               `json:"department_ids"`
+	FirstName                 string                 `json:"first_name"`
+	HasLoggedIn               bool                   `json:"has_logged_in"`
+	ID                        uint64                 `json:"id"`
+	IsAgent                   bool                   `json:"is_agent"`
+	JobTitle                  string                 `json:"job_title"`
+	Language                  string                 `json:"language"`
+	LastName                  string                 `json:"last_name"`
+	LocationID                uint64                 `json:"location_id"`
+	MobilePhoneNumber         string                 `json:"mobile_phone_number"`
+	PrimaryEmail              string                 `json:"primary_email"`
+	ReportingManagerID        uint64                 `json:"reporting_manager_id"`
+	SecondaryEmails           []string               `json:"secondary_emails"`
+	TimeZone                  string                 `json:"time_zone"`
+	UpdatedAt                 *time.Time             `json:"updated_at"`
+	WorkPhoneNumber           string                 `json:"work_phone_number"`
+}
+
+type RequesterPayload struct {
+	FirstName                 string                 `json:"first_name,omitempty"`
+	LastName                  string                 `json:"last_name,omitempty"`
+	JobTitle                  string                 `json:"job_title,omitempty"`
+	PrimaryEmail              string                 `json:"primary_email,omitempty"`
+	SecondaryEmails           []string               `json:"secondary_emails,omitempty"`
+	WorkPhoneNumber           string                 `json:"work_phone_number,omitempty"`
+	MobilePhoneNumber         string                 `json:"mobile_phone_number,omitempty"`
+	DepartmentIDs             []uint64               `json:"department_ids,omitempty"`
+	CanSeeAllTicketsFromDepts bool                   `json:"can_see_all_tickets_from_associated_departments,omitempty"`
+	ReportingManagerID        uint64                 `json:"reporting_manager_id,omitempty"`
+	Address                   string                 `json:"address,omitempty"`
+	TimeZone                  string                 `json:"time_zone,omitempty"`
+	Language                  string                 `json:"language,omitempty"`
+	LocationID                uint64                 `json:"location_id,omitempty"`
+	BackgroundInformation     string                 `json:"background_information,omitempty"`
+	CustomFields              map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Agent
+type Agent struct {
+	Active             bool        `json:"active"`
+	Address            string      `json:"address"`
+	CreatedAt          *time.Time  `json:"created_at"`
+	DepartmentIDs      []uint64    `json:"department_ids"`
+	Email              string      `json:"email"`
+	FirstName          string      `json:"first_name"`
+	GroupIDs           []uint64    `json:"group_ids"`
+	ID                 uint64      `json:"id"`
+	JobTitle           string      `json:"job_title"`
+	Language           string      `json:"language"`
+	LastLoginAt        *time.Time  `json:"last_login_at"`
+	LastName           string      `json:"last_name"`
+	LocationID         uint64      `json:"location_id"`
+	MemberOf           []uint64    `json:"member_of"`
+	MobilePhoneNumber  string      `json:"mobile_phone_number"`
+	ObserverOf         []uint64    `json:"observer_of"`
+	Occasional         bool        `json:"occasional"`
+	ReportingManagerID uint64      `json:"reporting_manager_id"`
+	RoleIDs            []uint64    `json:"role_ids"`
+	Roles              []AgentRole `json:"roles"`
+	ScoreboardLevelID  uint64      `json:"scoreboard_level_id"`
+	Signature          string      `json:"signature"`
+	TimeZone           string      `json:"time_zone"`
+	UpdatedAt          *time.Time  `json:"updated_at"`
+	WorkPhoneNumber    string      `json:"work_phone_number"`
+}
+
+type AgentRole struct {
+	RoleID          uint64   `json:"role_id"`
+	AssignmentScope string   `json:"assignment_scope"`
+	Groups          []uint64 `json:"groups,omitempty"`
+}
+
+type AgentPayload struct {
+	FirstName          string      `json:"first_name,omitempty"`
+	LastName           string      `json:"last_name,omitempty"`
+	Email              string      `json:"email,omitempty"`
+	Occasional         bool        `json:"occasional,omitempty"`
+	JobTitle           string      `json:"job_title,omitempty"`
+	WorkPhoneNumber    string      `json:"work_phone_number,omitempty"`
+	MobilePhoneNumber  string      `json:"mobile_phone_number,omitempty"`
+	DepartmentIDs      []uint64    `json:"department_ids,omitempty"`
+	ReportingManagerID uint64      `json:"reporting_manager_id,omitempty"`
+	Address            string      `json:"address,omitempty"`
+	TimeZone           string      `json:"time_zone,omitempty"`
+	Language           string      `json:"language,omitempty"`
+	LocationID         uint64      `json:"location_id,omitempty"`
+	ScoreboardLevelID  uint64      `json:"scoreboard_level_id,omitempty"`
+	MemberOf           []uint64    `json:"member_of,omitempty"`
+	ObserverOf         []uint64    `json:"observer_of,omitempty"`
+	Roles              []AgentRole `json:"roles,omitempty"`
+	Signature          string      `json:"signature,omitempty"`
+}
+
+// Department
+type Department struct {
+	CreatedAt    *time.Time             `json:"created_at"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Description  string                 `json:"description"`
+	Domains      []string               `json:"domains"`
+	HeadUserID   uint64                 `json:"head_user_id"`
+	ID           uint64                 `json:"id"`
+	Name         string                 `json:"name"`
+	PrimeUserID  uint64                 `json:"prime_user_id"`
+	UpdatedAt    *time.Time             `json:"updated_at"`
+}
+
+type DepartmentPayload struct {
+	Name         string                 `json:"name,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	HeadUserID   uint64                 `json:"head_user_id,omitempty"`
+	PrimeUserID  uint64                 `json:"prime_user_id,omitempty"`
+	Domains      []string               `json:"domains,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Location
+type Location struct {
+	Address          *Address   `json:"address"`
+	CreatedAt        *time.Time `json:"created_at"`
+	Email            string     `json:"email"`
+	ID               uint64     `json:"id"`
+	Name             string     `json:"name"`
+	ParentLocationID uint64     `json:"parent_location_id"`
+	Phone            string     `json:"phone"`
+	PrimaryContactID uint64     `json:"primary_contact_id"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type Address struct {
+	Line1   string `json:"line1,omitempty"`
+	Line2   string `json:"line2,omitempty"`
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty"`
+	Country string `json:"country,omitempty"`
+	Zipcode string `json:"zipcode,omitempty"`
+}
+
+type LocationPayload struct {
+	Name             string   `json:"name,omitempty"`
+	ParentLocationID uint64   `json:"parent_location_id,omitempty"`
+	PrimaryContactID uint64   `json:"primary_contact_id,omitempty"`
+	Address          *Address `json:"address,omitempty"`
+}
+
+// The API wraps every record in an object named after its type.
+type ticketResp struct {
+	Ticket Ticket `json:"ticket"`
+}
+
+type ticketsResp struct {
+	Tickets []Ticket `json:"tickets"`
+	Total   uint64   `json:"total"`
+}
+
+type conversationResp struct {
+	Conversation Conversation `json:"conversation"`
+}
+
+type conversationsResp struct {
+	Conversations []Conversation `json:"conversations"`
+}
+
+type requesterResp struct {
+	Requester Requester `json:"requester"`
+}
+
+type requestersResp struct {
+	Requesters []Requester `json:"requesters"`
+}
+
+type agentResp struct {
+	Agent Agent `json:"agent"`
+}
+
+type agentsResp struct {
+	Agents []Agent `json:"agents"`
+}
+
+type departmentResp struct {
+	Department Department `json:"department"`
+}
+
+type departmentsResp struct {
+	Departments []Department `json:"departments"`
+}
+
+type locationResp struct {
+	Location Location `json:"location"`
+}
+
+type locationsResp struct {
+	Locations []Location `json:"locations"`
+}
+
+const (
+	ERR_REQUESTER_NOT_FOUND string = "Requester not found"
+)
+
+// Change
+type Change struct {
+	AgentID          uint64                 `json:"agent_id"`
+	ApprovalStatus   int64                  `json:"approval_status"`
+	Category         string                 `json:"category"`
+	ChangeType       ChangeType             `json:"change_type"`
+	CreatedAt        *time.Time             `json:"created_at"`
+	CustomFields     map[string]interface{} `json:"custom_fields"`
+	DepartmentID     uint64                 `json:"department_id"`
+	Description      string                 `json:"description"`
+	DescriptionText  string                 `json:"description_text"`
+	GroupID          uint64                 `json:"group_id"`
+	ID               uint64                 `json:"id"`
+	Impact           Impact                 `json:"impact"`
+	ItemCategory     string                 `json:"item_category"`
+	PlannedEndDate   *time.Time             `json:"planned_end_date"`
+	PlannedStartDate *time.Time             `json:"planned_start_date"`
+	PlanningFields   map[string]interface{} `json:"planning_fields"`
+	Priority         Priority               `json:"priority"`
+	RequesterID      uint64                 `json:"requester_id"`
+	Risk             Risk                   `json:"risk"`
+	Status           ChangeStatus           `json:"status"`
+	SubCategory      string                 `json:"sub_category"`
+	Subject          string                 `json:"subject"`
+	UpdatedAt        *time.Time             `json:"updated_at"`
+	WorkspaceID      uint64                 `json:"workspace_id,omitempty"`
+}
+
+type Risk int64
+
+const (
+	RiskLow      Risk = 1
+	RiskMedium   Risk = 2
+	RiskHigh     Risk = 3
+	RiskVeryHigh Risk = 4
+)
+
+type ChangeType int64
+
+const (
+	ChangeTypeMinor     ChangeType = 1
+	ChangeTypeStandard  ChangeType = 2
+	ChangeTypeMajor     ChangeType = 3
+	ChangeTypeEmergency ChangeType = 4
+)
+
+type ChangeStatus int64
+
+const (
+	ChangeStatusOpen             ChangeStatus = 1
+	ChangeStatusPlanning         ChangeStatus = 2
+	ChangeStatusAwaitingApproval ChangeStatus = 3
+	ChangeStatusPendingRelease   ChangeStatus = 4
+	ChangeStatusPendingReview    ChangeStatus = 5
+	ChangeStatusClosed           ChangeStatus = 6
+)
+
+// ChangePayload is used to create and update changes; RequesterID,
+// Subject, Description, Priority, Impact, Status, Risk and ChangeType are
+// required on creation.
+type ChangePayload struct {
+	AgentID          uint64                 `json:"agent_id,omitempty"`
+	RequesterID      uint64                 `json:"requester_id,omitempty"`
+	GroupID          uint64                 `json:"group_id,omitempty"`
+	DepartmentID     uint64                 `json:"department_id,omitempty"`
+	Subject          string                 `json:"subject,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Priority         Priority               `json:"priority,omitempty"`
+	Impact           Impact                 `json:"impact,omitempty"`
+	Status           ChangeStatus           `json:"status,omitempty"`
+	Risk             Risk                   `json:"risk,omitempty"`
+	ChangeType       ChangeType             `json:"change_type,omitempty"`
+	Category         string                 `json:"category,omitempty"`
+	SubCategory      string                 `json:"sub_category,omitempty"`
+	ItemCategory     string                 `json:"item_category,omitempty"`
+	PlannedStartDate *time.Time             `json:"planned_start_date,omitempty"`
+	PlannedEndDate   *time.Time             `json:"planned_end_date,omitempty"`
+	PlanningFields   map[string]interface{} `json:"planning_fields,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Problem
+type Problem struct {
+	AgentID         uint64                 `json:"agent_id"`
+	AnalysisFields  map[string]interface{} `json:"analysis_fields"`
+	Category        string                 `json:"category"`
+	CreatedAt       *time.Time             `json:"created_at"`
+	CustomFields    map[string]interface{} `json:"custom_fields"`
+	DepartmentID    uint64                 `json:"department_id"`
+	Description     string                 `json:"description"`
+	DescriptionText string                 `json:"description_text"`
+	DueBy           *time.Time             `json:"due_by"`
+	GroupID         uint64                 `json:"group_id"`
+	ID              uint64                 `json:"id"`
+	Impact          Impact                 `json:"impact"`
+	ItemCategory    string                 `json:"item_category"`
+	KnownError      bool                   `json:"known_error"`
+	Priority        Priority               `json:"priority"`
+	RequesterID     uint64                 `json:"requester_id"`
+	Status          ProblemStatus          `json:"status"`
+	SubCategory     string                 `json:"sub_category"`
+	Subject         string                 `json:"subject"`
+	UpdatedAt       *time.Time             `json:"updated_at"`
+	WorkspaceID     uint64                 `json:"workspace_id,omitempty"`
+}
+
+type ProblemStatus int64
+
+const (
+	ProblemStatusOpen            ProblemStatus = 1
+	ProblemStatusChangeRequested ProblemStatus = 2
+	ProblemStatusClosed          ProblemStatus = 3
+)
+
+// ProblemPayload is used to create and update problems; RequesterID,
+// Subject, Description, Priority, Impact, Status and DueBy are required on
+// creation.
+type ProblemPayload struct {
+	AgentID        uint64                 `json:"agent_id,omitempty"`
+	RequesterID    uint64                 `json:"requester_id,omitempty"`
+	GroupID        uint64                 `json:"group_id,omitempty"`
+	DepartmentID   uint64                 `json:"department_id,omitempty"`
+	Subject        string                 `json:"subject,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Priority       Priority               `json:"priority,omitempty"`
+	Impact         Impact                 `json:"impact,omitempty"`
+	Status         ProblemStatus          `json:"status,omitempty"`
+	DueBy          *time.Time             `json:"due_by,omitempty"`
+	KnownError     bool                   `json:"known_error,omitempty"`
+	Category       string                 `json:"category,omitempty"`
+	SubCategory    string                 `json:"sub_category,omitempty"`
+	ItemCategory   string                 `json:"item_category,omitempty"`
+	AnalysisFields map[string]interface{} `json:"analysis_fields,omitempty"`
+	CustomFields   map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Release
+type Release struct {
+	AgentID          uint64                 `json:"agent_id"`
+	Category         string                 `json:"category"`
+	CreatedAt        *time.Time             `json:"created_at"`
+	CustomFields     map[string]interface{} `json:"custom_fields"`
+	DepartmentID     uint64                 `json:"department_id"`
+	Description      string                 `json:"description"`
+	DescriptionText  string                 `json:"description_text"`
+	GroupID          uint64                 `json:"group_id"`
+	ID               uint64                 `json:"id"`
+	ItemCategory     string                 `json:"item_category"`
+	PlannedEndDate   *time.Time             `json:"planned_end_date"`
+	PlannedStartDate *time.Time             `json:"planned_start_date"`
+	PlanningFields   map[string]interface{} `json:"planning_fields"`
+	Priority         Priority               `json:"priority"`
+	ReleaseType      ReleaseType            `json:"release_type"`
+	Status           ReleaseStatus          `json:"status"`
+	SubCategory      string                 `json:"sub_category"`
+	Subject          string                 `json:"subject"`
+	UpdatedAt        *time.Time             `json:"updated_at"`
+	WorkEndDate      *time.Time             `json:"work_end_date"`
+	WorkStartDate    *time.Time             `json:"work_start_date"`
+	WorkspaceID      uint64                 `json:"workspace_id,omitempty"`
+}
+
+type ReleaseType int64
+
+const (
+	ReleaseTypeMinor     ReleaseType = 1
+	ReleaseTypeStandard  ReleaseType = 2
+	ReleaseTypeMajor     ReleaseType = 3
+	ReleaseTypeEmergency ReleaseType = 4
+)
+
+type ReleaseStatus int64
+
+const (
+	ReleaseStatusOpen       ReleaseStatus = 1
+	ReleaseStatusOnHold     ReleaseStatus = 2
+	ReleaseStatusInProgress ReleaseStatus = 3
+	ReleaseStatusIncomplete ReleaseStatus = 4
+	ReleaseStatusCompleted  ReleaseStatus = 5
+)
+
+// ReleasePayload is used to create and update releases; Subject,
+// Description, Priority, Status, ReleaseType and the planned dates are
+// required on creation.
+type ReleasePayload struct {
+	AgentID          uint64                 `json:"agent_id,omitempty"`
+	GroupID          uint64                 `json:"group_id,omitempty"`
+	DepartmentID     uint64                 `json:"department_id,omitempty"`
+	Subject          string                 `json:"subject,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Priority         Priority               `json:"priority,omitempty"`
+	Status           ReleaseStatus          `json:"status,omitempty"`
+	ReleaseType      ReleaseType            `json:"release_type,omitempty"`
+	Category         string                 `json:"category,omitempty"`
+	SubCategory      string                 `json:"sub_category,omitempty"`
+	ItemCategory     string                 `json:"item_category,omitempty"`
+	PlannedStartDate *time.Time             `json:"planned_start_date,omitempty"`
+	PlannedEndDate   *time.Time             `json:"planned_end_date,omitempty"`
+	WorkStartDate    *time.Time             `json:"work_start_date,omitempty"`
+	WorkEndDate      *time.Time             `json:"work_end_date,omitempty"`
+	PlanningFields   map[string]interface{} `json:"planning_fields,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Module names the records notes, tasks and time entries are attached to.
+type Module string
+
+const (
+	ModuleTickets  Module = "tickets"
+	ModuleChanges  Module = "changes"
+	ModuleProblems Module = "problems"
+	ModuleReleases Module = "releases"
+)
+
+type Note struct {
+	Body         string     `json:"body"`
+	BodyText     string     `json:"body_text"`
+	CreatedAt    *time.Time `json:"created_at"`
+	ID           uint64     `json:"id"`
+	NotifyEmails []string   `json:"notify_emails"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	UserID       uint64     `json:"user_id"`
+}
+
+type NotePayload struct {
+	Body         string   `json:"body"`
+	NotifyEmails []string `json:"notify_emails,omitempty"`
+}
+
+type Task struct {
+	AgentID      uint64     `json:"agent_id"`
+	ClosedAt     *time.Time `json:"closed_at"`
+	CreatedAt    *time.Time `json:"created_at"`
+	Description  string     `json:"description"`
+	DueDate      *time.Time `json:"due_date"`
+	GroupID      uint64     `json:"group_id"`
+	ID           uint64     `json:"id"`
+	NotifyBefore uint64     `json:"notify_before"`
+	Status       TaskStatus `json:"status"`
+	Title        string     `json:"title"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
+
+type TaskStatus int64
+
+const (
+	TaskStatusOpen       TaskStatus = 1
+	TaskStatusInProgress TaskStatus = 2
+	TaskStatusCompleted  TaskStatus = 3
+)
+
+// TaskPayload is used to create and update tasks, NotifyBefore is in
+// seconds before DueDate.
+type TaskPayload struct {
+	AgentID      uint64     `json:"agent_id,omitempty"`
+	GroupID      uint64     `json:"group_id,omitempty"`
+	Status       TaskStatus `json:"status,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	NotifyBefore uint64     `json:"notify_before,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+}
+
+// TimeEntry
+type TimeEntry struct {
+	AgentID      uint64                 `json:"agent_id"`
+	Billable     bool                   `json:"billable"`
+	CreatedAt    *time.Time             `json:"created_at"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	ExecutedAt   *time.Time             `json:"executed_at"`
+	ID           uint64                 `json:"id"`
+	Note         string                 `json:"note"`
+	StartTime    *time.Time             `json:"start_time"`
+	TaskID       uint64                 `json:"task_id"`
+	TimeSpent    string                 `json:"time_spent"` // hh:mm
+	TimerRunning bool                   `json:"timer_running"`
+	UpdatedAt    *time.Time             `json:"updated_at"`
+}
+
+type TimeEntryPayload struct {
+	AgentID      uint64                 `json:"agent_id,omitempty"`
+	Billable     bool                   `json:"billable"`
+	ExecutedAt   *time.Time             `json:"executed_at,omitempty"`
+	Note         string                 `json:"note,omitempty"`
+	StartTime    *time.Time             `json:"start_time,omitempty"`
+	TaskID       uint64                 `json:"task_id,omitempty"`
+	TimeSpent    string                 `json:"time_spent,omitempty"` // hh:mm
+	TimerRunning bool                   `json:"timer_running,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// TicketChangeRelation tells how a ticket and a change are related.
+type TicketChangeRelation string
+
+const (
+	// The change was raised to fix the ticket.
+	ChangeInitiatedByTicket TicketChangeRelation = "change_initiated_by_ticket"
+	// The change caused the ticket.
+	ChangeCausingTicket TicketChangeRelation = "change_initiating_ticket"
+)
+
+type changeResp struct {
+	Change Change `json:"change"`
+}
+
+type changesResp struct {
+	Changes []Change `json:"changes"`
+}
+
+type problemResp struct {
+	Problem Problem `json:"problem"`
+}
+
+type problemsResp struct {
+	Problems []Problem `json:"problems"`
+}
+
+type releaseResp struct {
+	Release Release `json:"release"`
+}
+
+type releasesResp struct {
+	Releases []Release `json:"releases"`
+}
+
+type noteResp struct {
+	Note Note `json:"note"`
+}
+
+type notesResp struct {
+	Notes []Note `json:"notes"`
+}
+
+type taskResp struct {
+	Task Task `json:"task"`
+}
+
+type tasksResp struct {
+	Tasks []Task `json:"tasks"`
+}
+
+type timeEntryResp struct {
+	TimeEntry TimeEntry `json:"time_entry"`
+}
+
+type timeEntriesResp struct {
+	TimeEntries []TimeEntry `json:"time_entries"`
+}
+
+// Asset
+type AssetType struct {
+	CreatedAt         *time.Time `json:"created_at"`
+	Description       string     `json:"description"`
+	ID                uint64     `json:"id"`
+	Name              string     `json:"name"`
+	ParentAssetTypeID uint64     `json:"parent_asset_type_id"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+	Visible           bool       `json:"visible"`
+}
+
+// AssetTypeFieldGroup holds the fields an asset type adds to its parent,
+// FieldHeader naming the type they come from.
+type AssetTypeFieldGroup struct {
+	Fields      []AssetTypeField `json:"fields"`
+	FieldHeader string           `json:"field_header"`
+	ID          uint64           `json:"id"`
+}
+
+type AssetTypeField struct {
+	AssetTypeID  uint64        `json:"asset_type_id"`
+	Choices      []interface{} `json:"choices"`
+	CreatedAt    *time.Time    `json:"created_at"`
+	DefaultField bool          `json:"default_field"`
+	FieldType    string        `json:"field_type"`
+	ID           uint64        `json:"id"`
+	Label        string        `json:"label"`
+	Mandatory    bool          `json:"mandatory"`
+	Name         string        `json:"name"`
+	UpdatedAt    *time.Time    `json:"updated_at"`
+}
+
+type Asset struct {
+	AgentID      uint64      `json:"agent_id"`
+	AssetTag     string      `json:"asset_tag"`
+	AssetTypeID  uint64      `json:"asset_type_id"`
+	AssignedOn   *time.Time  `json:"assigned_on"`
+	AuthorType   string      `json:"author_type"`
+	CreatedAt    *time.Time  `json:"created_at"`
+	DepartmentID uint64      `json:"department_id"`
+	Description  string      `json:"description"`
+	DisplayID    uint64      `json:"display_id"`
+	GroupID      uint64      `json:"group_id"`
+	ID           uint64      `json:"id"`
+	Impact       AssetImpact `json:"impact"`
+	LocationID   uint64      `json:"location_id"`
+	Name         string      `json:"name"`
+	// TypeFields holds the fields of the asset type and its parents, keyed
+	// by field name and the ID of the type defining them, see TypeField.
+	TypeFields  map[string]interface{} `json:"type_fields"`
+	UpdatedAt   *time.Time             `json:"updated_at"`
+	UsageType   UsageType              `json:"usage_type"`
+	UserID      uint64                 `json:"user_id"`
+	WorkspaceID uint64                 `json:"workspace_id,omitempty"`
+}
+
+type AssetImpact string
+
+const (
+	AssetImpactLow    AssetImpact = "low"
+	AssetImpactMedium AssetImpact = "medium"
+	AssetImpactHigh   AssetImpact = "high"
+)
+
+type UsageType string
+
+const (
+	UsagePermanent UsageType = "permanent"
+	UsageLoaner    UsageType = "loaner"
+)
+
+// AssetPayload is used to create and update assets; Name and AssetTypeID
+// are required on creation. TypeFields keys carry the ID of the type
+// defining the field, as in "product_26000303".
+type AssetPayload struct {
+	Name         string                 `json:"name,omitempty"`
+	AssetTypeID  uint64                 `json:"asset_type_id,omitempty"`
+	AssetTag     string                 `json:"asset_tag,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Impact       AssetImpact            `json:"impact,omitempty"`
+	UsageType    UsageType              `json:"usage_type,omitempty"`
+	UserID       uint64                 `json:"user_id,omitempty"`
+	AgentID      uint64                 `json:"agent_id,omitempty"`
+	GroupID      uint64                 `json:"group_id,omitempty"`
+	DepartmentID uint64                 `json:"department_id,omitempty"`
+	LocationID   uint64                 `json:"location_id,omitempty"`
+	AssignedOn   *time.Time             `json:"assigned_on,omitempty"`
+	TypeFields   map[string]interface{} `json:"type_fields,omitempty"`
+}
+
+// AssetRef points at an asset from a ticket.
+type AssetRef struct {
+	DisplayID uint64 `json:"display_id"`
+}
+
+// AssetComponent is a part discovered on an asset, such as a processor or
+// a disk, with ComponentData listing its properties.
+type AssetComponent struct {
+	ComponentData []map[string]interface{} `json:"component_data"`
+	ComponentType string                   `json:"component_type"`
+	CreatedAt     *time.Time               `json:"created_at"`
+	ID            uint64                   `json:"id"`
+	UpdatedAt     *time.Time               `json:"updated_at"`
+}
+
+type RelationshipType struct {
+	CreatedAt          *time.Time `json:"created_at"`
+	Description        string     `json:"description"`
+	DownstreamRelation string     `json:"downstream_relation"`
+	ID                 uint64     `json:"id"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	UpstreamRelation   string     `json:"upstream_relation"`
+}
+
+// Relationship links two CMDB records, primary and secondary are read
+// through the downstream relation of the type: "primary uses secondary".
+type Relationship struct {
+	CreatedAt          *time.Time `json:"created_at"`
+	ID                 uint64     `json:"id"`
+	PrimaryID          uint64     `json:"primary_id"`
+	PrimaryType        string     `json:"primary_type"`
+	RelationshipTypeID uint64     `json:"relationship_type_id"`
+	SecondaryID        uint64     `json:"secondary_id"`
+	SecondaryType      string     `json:"secondary_type"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+}
+
+// RelationshipPayload creates a relationship, the record types being
+// "asset", "requester", "agent", "department" or "software".
+type RelationshipPayload struct {
+	RelationshipTypeID uint64 `json:"relationship_type_id"`
+	PrimaryID          uint64 `json:"primary_id"`
+	PrimaryType        string `json:"primary_type"`
+	SecondaryID        uint64 `json:"secondary_id"`
+	SecondaryType      string `json:"secondary_type"`
+}
+
+// Job reports on the background creation of relationships.
+type Job struct {
+	ID            string         `json:"id"`
+	Relationships []Relationship `json:"relationships"`
+	Status        string         `json:"status"` // queued, in progress, success, failed or partial
+	SubmittedAt   *time.Time     `json:"submitted_at"`
+}
+
+type assetTypeResp struct {
+	AssetType AssetType `json:"asset_type"`
+}
+
+type assetTypesResp struct {
+	AssetTypes []AssetType `json:"asset_types"`
+}
+
+type assetTypeFieldsResp struct {
+	AssetTypeFields []AssetTypeFieldGroup `json:"asset_type_fields"`
+}
+
+type assetResp struct {
+	Asset Asset `json:"asset"`
+}
+
+type assetsResp struct {
+	Assets []Asset `json:"assets"`
+}
+
+type componentsResp struct {
+	Components []AssetComponent `json:"components"`
+}
+
+type relationshipsResp struct {
+	Relationships []Relationship `json:"relationships"`
+}
+
+type relationshipTypesResp struct {
+	RelationshipTypes []RelationshipType `json:"relationship_types"`
+}
+
+type jobResp struct {
+	JobID string `json:"job_id"`
+	Href  string `json:"href"`
+}
+
+// Service catalog
+type ServiceCategory struct {
+	CreatedAt   *time.Time `json:"created_at"`
+	Description string     `json:"description"`
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Position    int        `json:"position"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+type ServiceItem struct {
+	AllowAttachments bool               `json:"allow_attachments"`
+	AllowQuantity    bool               `json:"allow_quantity"`
+	CategoryID       uint64             `json:"category_id"`
+	CreatedAt        *time.Time         `json:"created_at"`
+	CustomFields     []ServiceItemField `json:"custom_fields"`
+	Deleted          bool               `json:"deleted"`
+	DeliveryTime     uint64             `json:"delivery_time"` // hours
+	Description      string             `json:"description"`
+	DisplayID        uint64             `json:"display_id"`
+	ID               uint64             `json:"id"`
+	IsBundle         bool               `json:"is_bundle"`
+	Name             string             `json:"name"`
+	ProductID        uint64             `json:"product_id"`
+	Quantity         int                `json:"quantity"`
+	ShortDescription string             `json:"short_description"`
+	UpdatedAt        *time.Time         `json:"updated_at"`
+	Visibility       int                `json:"visibility"`
+}
+
+// ServiceItemField is a field the requester fills in when placing a
+// request, its Name being the key of ServiceRequestPayload.CustomFields.
+type ServiceItemField struct {
+	Choices   []interface{} `json:"choices"`
+	FieldType string        `json:"field_type"`
+	ID        uint64        `json:"id"`
+	Label     string        `json:"label"`
+	Name      string        `json:"name"`
+	Required  bool          `json:"required"`
+}
+
+// ServiceRequestPayload places a request for a service item, Email being
+// the requester and RequestedFor the email of the user it is placed for
+// when different.
+type ServiceRequestPayload struct {
+	Quantity     int                    `json:"quantity,omitempty"`
+	Email        string                 `json:"email,omitempty"`
+	RequestedFor string                 `json:"requested_for,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type serviceCategoriesResp struct {
+	ServiceCategories []ServiceCategory `json:"service_categories"`
+}
+
+type serviceItemResp struct {
+	ServiceItem ServiceItem `json:"service_item"`
+}
+
+type serviceItemsResp struct {
+	ServiceItems []ServiceItem `json:"service_items"`
+}
+
+type serviceRequestResp struct {
+	ServiceRequest Ticket `json:"service_request"`
+}
@@ -0,0 +1,289 @@
+package freshservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Peter2121/freshdesk-go/internal/rest"
+)
+
+// Notes are kept on changes, problems and releases, the notes of a ticket
+// are conversations, see CreateTicketNote.
+func (service *freshServiceService) ListNotes(module Module, ID uint64) ([]Note, error) {
+	var responseAll []Note
+	next := fmt.Sprintf("/api/v2/%v/%v/notes?per_page=100", module, ID)
+
+	for next != "" {
+		var responseSchema notesResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Notes...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) CreateNote(module Module, ID uint64, payload NotePayload) (*Note, error) {
+	var responseSchema noteResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/%v/%v/notes", module, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Note, nil
+}
+
+func (service *freshServiceService) UpdateNote(module Module, ID uint64, noteID uint64, payload NotePayload) (*Note, error) {
+	var responseSchema noteResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/%v/%v/notes/%v", module, ID, noteID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Note, nil
+}
+
+func (service *freshServiceService) DeleteNote(module Module, ID uint64, noteID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("/api/v2/%v/%v/notes/%v", module, ID, noteID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// Tasks and time entries are kept on tickets, changes, problems and
+// releases.
+func (service *freshServiceService) ListTasks(module Module, ID uint64) ([]Task, error) {
+	var responseAll []Task
+	next := fmt.Sprintf("/api/v2/%v/%v/tasks?per_page=100", module, ID)
+
+	for next != "" {
+		var responseSchema tasksResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.Tasks...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) CreateTask(module Module, ID uint64, payload TaskPayload) (*Task, error) {
+	var responseSchema taskResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/%v/%v/tasks", module, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Task, nil
+}
+
+func (service *freshServiceService) UpdateTask(module Module, ID uint64, taskID uint64, payload TaskPayload) (*Task, error) {
+	var responseSchema taskResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/%v/%v/tasks/%v", module, ID, taskID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Task, nil
+}
+
+func (service *freshServiceService) DeleteTask(module Module, ID uint64, taskID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("/api/v2/%v/%v/tasks/%v", module, ID, taskID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+func (service *freshServiceService) ListTimeEntries(module Module, ID uint64) ([]TimeEntry, error) {
+	var responseAll []TimeEntry
+	next := fmt.Sprintf("/api/v2/%v/%v/time_entries?per_page=100", module, ID)
+
+	for next != "" {
+		var responseSchema timeEntriesResp
+		resp, err := service.restyClient.R().
+			SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+			Get(next)
+
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, rest.Error(resp)
+		}
+
+		responseAll = append(responseAll, responseSchema.TimeEntries...)
+		next = rest.NextPageLink(resp)
+	}
+
+	return responseAll, nil
+}
+
+func (service *freshServiceService) CreateTimeEntry(module Module, ID uint64, payload TimeEntryPayload) (*TimeEntry, error) {
+	var responseSchema timeEntryResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Post(fmt.Sprintf("/api/v2/%v/%v/time_entries", module, ID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.TimeEntry, nil
+}
+
+func (service *freshServiceService) UpdateTimeEntry(module Module, ID uint64, timeEntryID uint64, payload TimeEntryPayload) (*TimeEntry, error) {
+	var responseSchema timeEntryResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/%v/%v/time_entries/%v", module, ID, timeEntryID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.TimeEntry, nil
+}
+
+func (service *freshServiceService) DeleteTimeEntry(module Module, ID uint64, timeEntryID uint64) (*interface{}, error) {
+	var responseSchema interface{}
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").SetResult(&responseSchema).
+		Delete(fmt.Sprintf("/api/v2/%v/%v/time_entries/%v", module, ID, timeEntryID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema, nil
+}
+
+// AssociateTicketWithProblem links the ticket to the problem causing it.
+func (service *freshServiceService) AssociateTicketWithProblem(ticketID uint64, problemID uint64) (*Ticket, error) {
+	return service.associateTicket(ticketID, "problem", problemID)
+}
+
+// AssociateTicketWithChange links the ticket to a change, raised to fix the
+// ticket or having caused it depending on relation.
+func (service *freshServiceService) AssociateTicketWithChange(ticketID uint64, changeID uint64, relation TicketChangeRelation) (*Ticket, error) {
+	return service.associateTicket(ticketID, string(relation), changeID)
+}
+
+func (service *freshServiceService) associateTicket(ticketID uint64, field string, ID uint64) (*Ticket, error) {
+	var responseSchema ticketResp
+	resp, err := service.restyClient.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{field: map[string]uint64{"id": ID}}).SetResult(&responseSchema).
+		Put(fmt.Sprintf("/api/v2/tickets/%v", ticketID))
+
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, rest.Error(resp)
+	}
+
+	return &responseSchema.Ticket, nil
+}
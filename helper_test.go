@@ -0,0 +1,138 @@
+package freshdesk
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// The client logs every failed request, which only clutters the output
+	// of the error cases.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// stubResponse is one canned answer of the stub server.
+type stubResponse struct {
+	Status int
+	Body   string
+	Header http.Header
+}
+
+// stubRequest is a request received by the stub server.
+type stubRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// stubServer answers requests from a table keyed by "METHOD /path", the
+// query string is not part of the key. The tests of this package cannot
+// use freshdesktest, which imports it, and they need canned answers for
+// endpoints the fake does not emulate and for empty or malformed bodies;
+// client_test.go runs the end-to-end cases against freshdesktest. Several responses registered for
+// the same key are served in turn, the last one repeating, which is how
+// paginated endpoints are stubbed. Unknown routes get a 404.
+type stubServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string][]stubResponse
+	requests []stubRequest
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	t.Helper()
+	stub := &stubServer{routes: map[string][]stubResponse{}}
+	stub.Server = httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+// client returns a client talking to the stub.
+func (stub *stubServer) client() *freshDeskService {
+	return NewClient(stub.URL, "api-key", "X", 60000).(*freshDeskService)
+}
+
+// on registers the responses served for method and path.
+func (stub *stubServer) on(method, path string, responses ...stubResponse) {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	stub.routes[method+" "+path] = append(stub.routes[method+" "+path], responses...)
+}
+
+// reply registers a single JSON response.
+func (stub *stubServer) reply(method, path string, status int, body string) {
+	stub.on(method, path, stubResponse{Status: status, Body: body})
+}
+
+// received returns the requests served so far.
+func (stub *stubServer) received() []stubRequest {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	return append([]stubRequest(nil), stub.requests...)
+}
+
+// link returns a Link header pointing at the next page.
+func (stub *stubServer) link(pathAndQuery string) http.Header {
+	return http.Header{"Link": {"<" + stub.URL + pathAndQuery + `>; rel="next"`}}
+}
+
+func (stub *stubServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	stub.mu.Lock()
+	stub.requests = append(stub.requests, stubRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	key := r.Method + " " + r.URL.Path
+	queue, ok := stub.routes[key]
+	var response stubResponse
+	if ok {
+		response = queue[0]
+		if len(queue) > 1 {
+			stub.routes[key] = queue[1:]
+		}
+	}
+	stub.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"code":"not_found","message":"no stub for `+key+`"}`)
+		return
+	}
+
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+	// resty only decodes JSON responses into the result, an empty answer
+	// comes without a content type as it does from the API.
+	if response.Body != "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+	w.WriteHeader(response.Status)
+	io.WriteString(w, response.Body)
+}
+
+// closedClient returns a client whose server is already gone, every
+// request fails at the transport.
+func closedClient(t *testing.T) *freshDeskService {
+	t.Helper()
+	server := httptest.NewServer(http.NotFoundHandler())
+	service := NewClient(server.URL, "api-key", "X", 60000).(*freshDeskService)
+	server.Close()
+	return service
+}
@@ -0,0 +1,94 @@
+// Package rest holds the HTTP plumbing shared by the Freshdesk and
+// Freshservice clients: both APIs use basic auth with the API key, answer
+// errors with a JSON body and paginate with a Link header.
+package rest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/ratelimit"
+)
+
+// Requests answered 429 Too Many Requests are retried this many times,
+// each after the delay of the Retry-After header, at most MaxRetryAfter.
+const (
+	RateLimitRetries = 3
+	MaxRetryAfter    = 2 * time.Minute
+)
+
+// NewClient returns a resty client sending requests to baseUrl with basic
+// auth, through the given transport or the default one when nil. Requests
+// over the rate limit of the account are retried once the API allows it.
+func NewClient(baseUrl string, user string, password string, transport http.RoundTripper) *resty.Client {
+	client := resty.New()
+	if transport != nil {
+		client.SetTransport(transport)
+	}
+	client.SetBaseURL(baseUrl)
+	client.SetHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	client.SetRetryCount(RateLimitRetries).
+		SetRetryMaxWaitTime(MaxRetryAfter).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return resp != nil && resp.StatusCode() == http.StatusTooManyRequests
+		}).
+		SetRetryAfter(retryAfter)
+	return client
+}
+
+// retryAfter returns the delay asked by the Retry-After header, in seconds
+// or as a date. Zero lets resty back off on its own.
+func retryAfter(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+	value := resp.Header().Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, nil
+		}
+	}
+	return 0, nil
+}
+
+// Limit makes every request of the client, retries included, wait for the
+// limiter.
+func Limit(client *resty.Client, limiter ratelimit.Limiter) {
+	client.OnBeforeRequest(func(*resty.Client, *resty.Request) error {
+		limiter.Take()
+		return nil
+	})
+}
+
+// Error returns the error for an unexpected response, its body, or the
+// status when the body is empty.
+func Error(resp *resty.Response) error {
+	body := string(resp.Body())
+	if len(body) == 0 {
+		body = fmt.Sprintf("Invalid status received: %d", resp.StatusCode())
+	}
+	return errors.New(body)
+}
+
+// NextPageLink returns the URL of the next page from the Link header of a
+// list response, or an empty string on the last page.
+func NextPageLink(resp *resty.Response) string {
+	for _, link := range strings.Split(resp.Header().Get("Link"), ",") {
+		if !strings.Contains(link, `rel="next"`) {
+			continue
+		}
+		start := strings.Index(link, "<")
+		end := strings.Index(link, ">")
+		if start < 0 || end <= start {
+			return ""
+		}
+		return link[start+1 : end]
+	}
+	return ""
+}
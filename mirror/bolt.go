@@ -0,0 +1,99 @@
+package mirror
+
+import (
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type boltStore struct {
+	db *bolt.DB
+}
+
+var (
+	stateBucket = []byte("_state")
+	stateKey    = []byte("state")
+)
+
+// NewBoltStore keeps the mirror in a single bbolt database file, one
+// bucket per collection. Every write is a committed transaction.
+func NewBoltStore(path string) (Store, error) {
+	db, err := bolt.Open(path, 0o644, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+func boltKey(ID uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, ID)
+	return key
+}
+
+func (store *boltStore) Put(collection string, ID uint64, record []byte) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(collection))
+		if err != nil {
+			return err
+		}
+		return bucket.Put(boltKey(ID), record)
+	})
+}
+
+func (store *boltStore) Get(collection string, ID uint64) ([]byte, error) {
+	var record []byte
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		if value := bucket.Get(boltKey(ID)); value != nil {
+			record = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	return record, err
+}
+
+// Each visits the records in id order. fn must not write to the store.
+func (store *boltStore) Each(collection string, fn func(ID uint64, record []byte) error) error {
+	return store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key []byte, value []byte) error {
+			return fn(binary.BigEndian.Uint64(key), value)
+		})
+	})
+}
+
+func (store *boltStore) LoadState() ([]byte, error) {
+	var state []byte
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(stateBucket)
+		if bucket == nil {
+			return nil
+		}
+		if value := bucket.Get(stateKey); value != nil {
+			state = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	return state, err
+}
+
+func (store *boltStore) SaveState(state []byte) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(stateBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(stateKey, state)
+	})
+}
+
+func (store *boltStore) Close() error {
+	return store.db.Close()
+}
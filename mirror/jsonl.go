@@ -0,0 +1,268 @@
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type jsonlStore struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]*jsonlFile
+}
+
+type jsonlFile struct {
+	file  *os.File
+	size  int64
+	index map[uint64]jsonlEntry
+	dirty bool
+}
+
+type jsonlEntry struct {
+	offset int64
+	length int
+}
+
+// NewJSONLStore keeps every collection in dir as a <collection>.jsonl file
+// holding one record per line, readable by any JSONL tool. Updates are
+// appended, so a file may hold several versions of a record until Compact
+// rewrites it, see Compacter; the last one wins. A line cut short by a crash is dropped
+// when the store is opened.
+func NewJSONLStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &jsonlStore{dir: dir, files: map[string]*jsonlFile{}}, nil
+}
+
+func (store *jsonlStore) open(collection string) (*jsonlFile, error) {
+	if f, ok := store.files[collection]; ok {
+		return f, nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(store.dir, collection+".jsonl"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &jsonlFile{file: file, index: map[uint64]jsonlEntry{}}
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Whatever follows the last newline is an interrupted write.
+			break
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		var record struct {
+			ID uint64 `json:"id"`
+		}
+		if json.Unmarshal(line, &record) == nil {
+			f.index[record.ID] = jsonlEntry{offset: f.size, length: len(line) - 1}
+		}
+		f.size += int64(len(line))
+	}
+	if err := file.Truncate(f.size); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	store.files[collection] = f
+	return f, nil
+}
+
+func (store *jsonlStore) Put(collection string, ID uint64, record []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	f, err := store.open(collection)
+	if err != nil {
+		return err
+	}
+
+	var line bytes.Buffer
+	if err := json.Compact(&line, record); err != nil {
+		return err
+	}
+	length := line.Len()
+	line.WriteByte('\n')
+
+	if _, err := f.file.WriteAt(line.Bytes(), f.size); err != nil {
+		return err
+	}
+	f.index[ID] = jsonlEntry{offset: f.size, length: length}
+	f.size += int64(line.Len())
+	f.dirty = true
+	return nil
+}
+
+func (store *jsonlStore) Get(collection string, ID uint64) ([]byte, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	f, err := store.open(collection)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := f.index[ID]
+	if !ok {
+		return nil, nil
+	}
+	record := make([]byte, entry.length)
+	_, err = f.file.ReadAt(record, entry.offset)
+	return record, err
+}
+
+// Each visits the latest version of every record in id order.
+func (store *jsonlStore) Each(collection string, fn func(ID uint64, record []byte) error) error {
+	store.mu.Lock()
+	f, err := store.open(collection)
+	var ids []uint64
+	if err == nil {
+		for id := range f.index {
+			ids = append(ids, id)
+		}
+	}
+	store.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		record, err := store.Get(collection, id)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			continue
+		}
+		if err := fn(id, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *jsonlStore) LoadState() ([]byte, error) {
+	state, err := os.ReadFile(filepath.Join(store.dir, "state.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return state, err
+}
+
+func (store *jsonlStore) SaveState(state []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, f := range store.files {
+		if !f.dirty {
+			continue
+		}
+		if err := f.file.Sync(); err != nil {
+			return err
+		}
+		f.dirty = false
+	}
+	return writeFileAtomic(filepath.Join(store.dir, "state.json"), state)
+}
+
+// Compact rewrites the collection files of the directory so that they
+// hold only the latest version of each record.
+func (store *jsonlStore) Compact() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(store.dir, "*.jsonl"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		collection := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+		f, err := store.open(collection)
+		if err != nil {
+			return err
+		}
+
+		var ids []uint64
+		for id := range f.index {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		var out bytes.Buffer
+		index := map[uint64]jsonlEntry{}
+		for _, id := range ids {
+			entry := f.index[id]
+			record := make([]byte, entry.length)
+			if _, err := f.file.ReadAt(record, entry.offset); err != nil {
+				return err
+			}
+			index[id] = jsonlEntry{offset: int64(out.Len()), length: entry.length}
+			out.Write(record)
+			out.WriteByte('\n')
+		}
+
+		if err := writeFileAtomic(path, out.Bytes()); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_RDWR, 0o644)
+		if err != nil {
+			return err
+		}
+		f.file.Close()
+		f.file, f.index, f.size, f.dirty = file, index, int64(out.Len()), false
+	}
+	return nil
+}
+
+func (store *jsonlStore) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var first error
+	for _, f := range store.files {
+		if err := f.file.Sync(); err != nil && first == nil {
+			first = err
+		}
+		if err := f.file.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	store.files = map[string]*jsonlFile{}
+	return first
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
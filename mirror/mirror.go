@@ -0,0 +1,302 @@
+// Package mirror keeps a local copy of the tickets, conversations,
+// contacts and companies of a Freshdesk account. The first Sync exports
+// everything, later ones only fetch the records updated since.
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// State is the sync position of every feed, saved in the store after each
+// page so that an interrupted sync resumes where it stopped. Records of a
+// page are stored before the state moves past it; pages fetched twice are
+// harmless since writes replace the previous version.
+type State struct {
+	Feeds map[string]*FeedState `json:"feeds"`
+}
+
+// FeedState is the position of one feed: every record updated before Since
+// has been stored. Page is the next page of the pass in progress, zero
+// when no pass is in progress, and Latest the newest update time seen
+// during that pass.
+type FeedState struct {
+	Since  time.Time `json:"since"`
+	Page   int       `json:"page,omitempty"`
+	Latest time.Time `json:"latest"`
+}
+
+// Feed names used in State.
+const (
+	FeedTickets         = "tickets"
+	FeedDeletedTickets  = "deleted_tickets"
+	FeedContacts        = "contacts"
+	FeedDeletedContacts = "deleted_contacts"
+	FeedCompanies       = "companies"
+)
+
+// Freshdesk refuses ticket list pages beyond this one.
+const maxListPages = 300
+
+// Tickets include at most this many conversations, the rest have to be
+// fetched from the conversations endpoint.
+const includedConversations = 10
+
+// Mirror copies the account into a Store. Deleted tickets and contacts
+// stay in the store with their deleted flag set. Every ticket is fetched
+// on its own with its conversations; requests over the rate limit of the
+// account are retried by the client once Retry-After has passed.
+type Mirror struct {
+	client   freshdesk.Client
+	store    Store
+	overlap  time.Duration
+	pageSize int
+}
+
+func New(client freshdesk.Client, store Store) *Mirror {
+	return &Mirror{
+		client:   client,
+		store:    store,
+		overlap:  time.Minute,
+		pageSize: 100,
+	}
+}
+
+// SetOverlap sets how far back each incremental pass re-reads to catch
+// records committed late, one minute by default.
+func (mirror *Mirror) SetOverlap(overlap time.Duration) {
+	mirror.overlap = overlap
+}
+
+// State returns the saved sync position.
+func (mirror *Mirror) State() (State, error) {
+	state := State{Feeds: map[string]*FeedState{}}
+	data, err := mirror.store.LoadState()
+	if err != nil || data == nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+	if state.Feeds == nil {
+		state.Feeds = map[string]*FeedState{}
+	}
+	return state, nil
+}
+
+func (mirror *Mirror) saveState(state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return mirror.store.SaveState(data)
+}
+
+type pageFunc func(since *time.Time, page int) (latest time.Time, err error, more bool)
+
+// Sync brings the store up to date with the account.
+func (mirror *Mirror) Sync() error {
+	state, err := mirror.State()
+	if err != nil {
+		return err
+	}
+
+	// Only the ticket lists are ordered by update time, and only they are
+	// limited in pages.
+	feeds := []struct {
+		name    string
+		page    pageFunc
+		ordered bool
+	}{
+		{FeedTickets, mirror.ticketPage(mirror.client.ListTickets), true},
+		{FeedDeletedTickets, mirror.ticketPage(mirror.client.ListDeletedTickets), true},
+		{FeedContacts, mirror.contactPage(mirror.client.ListContacts), false},
+		{FeedDeletedContacts, mirror.contactPage(mirror.client.ListDeletedContacts), false},
+		{FeedCompanies, mirror.companyPage, false},
+	}
+	for _, feed := range feeds {
+		if state.Feeds[feed.name] == nil {
+			state.Feeds[feed.name] = &FeedState{}
+		}
+		if err := mirror.syncFeed(state, state.Feeds[feed.name], feed.page, feed.ordered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncFeed runs a pass over the records of the feed updated since the last
+// one. A pass over an ordered feed stops at the page limit and restarts
+// from the newest record seen; other feeds are read to their last page,
+// records updated during the pass may move between pages and are caught
+// by the next one.
+func (mirror *Mirror) syncFeed(state State, feed *FeedState, page pageFunc, ordered bool) error {
+	for {
+		if feed.Page == 0 {
+			feed.Page = 1
+			feed.Latest = feed.Since
+		}
+
+		// The first pass starts at the epoch: without updated_since the
+		// ticket list only covers the last 30 days.
+		since := time.Unix(0, 0).UTC()
+		if !feed.Since.IsZero() {
+			since = feed.Since.Add(-mirror.overlap)
+		}
+
+		more := false
+		for {
+			latest, err, next := page(&since, feed.Page)
+			if err != nil {
+				return err
+			}
+			if latest.After(feed.Latest) {
+				feed.Latest = latest
+			}
+			more = next
+			if !more || ordered && feed.Page >= maxListPages {
+				break
+			}
+
+			feed.Page++
+			if err := mirror.saveState(state); err != nil {
+				return err
+			}
+		}
+
+		// Past the page limit the pass restarts from the newest record
+		// seen, which only helps if it moved forward.
+		restart := more && feed.Latest.After(feed.Since)
+		feed.Since = feed.Latest
+		feed.Page = 0
+		feed.Latest = time.Time{}
+		if err := mirror.saveState(state); err != nil {
+			return err
+		}
+		if !restart {
+			return nil
+		}
+	}
+}
+
+func (mirror *Mirror) ticketPage(list func(*time.Time, int, int) ([]freshdesk.Ticket, error, bool)) pageFunc {
+	return func(since *time.Time, page int) (time.Time, error, bool) {
+		var latest time.Time
+		tickets, err, more := list(since, mirror.pageSize, page)
+		if err != nil {
+			return latest, err, false
+		}
+		for _, ticket := range tickets {
+			if err := mirror.putTicket(ticket); err != nil {
+				return latest, err, false
+			}
+			if ticket.UpdatedAt != nil && ticket.UpdatedAt.After(latest) {
+				latest = *ticket.UpdatedAt
+			}
+		}
+		return latest, nil, more
+	}
+}
+
+// putTicket stores the ticket and its conversations, each conversation as
+// its own record.
+func (mirror *Mirror) putTicket(ticket freshdesk.Ticket) error {
+	if !ticket.Deleted {
+		full, err := mirror.client.GetTicketWithConversations(ticket.ID)
+		if err != nil {
+			return err
+		}
+		conversations := full.Conversations
+		if len(conversations) >= includedConversations {
+			conversations, err = mirror.client.GetAllTicketConversations(ticket.ID)
+			if err != nil {
+				return err
+			}
+		}
+		for _, conversation := range conversations {
+			if err := mirror.put(Conversations, conversation.ID, conversation); err != nil {
+				return err
+			}
+		}
+		ticket = *full
+	}
+	ticket.Conversations = nil
+	return mirror.put(Tickets, ticket.ID, ticket)
+}
+
+func (mirror *Mirror) contactPage(list func(*time.Time, int, int) ([]freshdesk.Contact, error, bool)) pageFunc {
+	return func(since *time.Time, page int) (time.Time, error, bool) {
+		var latest time.Time
+		contacts, err, more := list(since, mirror.pageSize, page)
+		if err != nil {
+			return latest, err, false
+		}
+		for _, contact := range contacts {
+			if err := mirror.put(Contacts, contact.ID, contact); err != nil {
+				return latest, err, false
+			}
+			if contact.UpdatedAt != nil && contact.UpdatedAt.After(latest) {
+				latest = *contact.UpdatedAt
+			}
+		}
+		return latest, nil, more
+	}
+}
+
+func (mirror *Mirror) companyPage(since *time.Time, page int) (time.Time, error, bool) {
+	var latest time.Time
+	companies, err, more := mirror.client.ListCompanies(since, mirror.pageSize, page)
+	if err != nil {
+		return latest, err, false
+	}
+	for _, company := range companies {
+		if err := mirror.put(Companies, company.ID, company); err != nil {
+			return latest, err, false
+		}
+		if company.UpdatedAt != nil && company.UpdatedAt.After(latest) {
+			latest = *company.UpdatedAt
+		}
+	}
+	return latest, nil, more
+}
+
+func (mirror *Mirror) put(collection string, ID uint64, record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return mirror.store.Put(collection, ID, data)
+}
+
+// ErrNotFound is returned by the typed getters for records missing from
+// the store.
+var ErrNotFound = errors.New("record not found in mirror")
+
+func (mirror *Mirror) Ticket(ID uint64) (*freshdesk.Ticket, error) {
+	var ticket freshdesk.Ticket
+	return &ticket, mirror.get(Tickets, ID, &ticket)
+}
+
+func (mirror *Mirror) Contact(ID uint64) (*freshdesk.Contact, error) {
+	var contact freshdesk.Contact
+	return &contact, mirror.get(Contacts, ID, &contact)
+}
+
+func (mirror *Mirror) Company(ID uint64) (*freshdesk.Company, error) {
+	var company freshdesk.Company
+	return &company, mirror.get(Companies, ID, &company)
+}
+
+func (mirror *Mirror) get(collection string, ID uint64, v interface{}) error {
+	data, err := mirror.store.Get(collection, ID)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return ErrNotFound
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,195 @@
+package mirror
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+	"github.com/Peter2121/freshdesk-go/freshdeskmock"
+)
+
+var epoch = time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+
+func minutes(n int) *time.Time {
+	t := epoch.Add(time.Duration(n) * time.Minute)
+	return &t
+}
+
+// newClient returns a mock serving each ticket with one conversation.
+func newClient() *freshdeskmock.Client {
+	client := &freshdeskmock.Client{}
+	client.GetTicketWithConversationsFunc = func(ID uint64) (*freshdesk.Ticket, error) {
+		return &freshdesk.Ticket{ID: ID, UpdatedAt: minutes(0), Conversations: []freshdesk.TicketMessage{{ID: ID * 10}}}, nil
+	}
+	return client
+}
+
+func newMirror(t *testing.T, client freshdesk.Client) *Mirror {
+	t.Helper()
+	store, err := NewJSONLStore(filepath.Join(t.TempDir(), "mirror"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return New(client, store)
+}
+
+func TestSyncIncremental(t *testing.T) {
+	client := newClient()
+	tickets := []freshdesk.Ticket{{ID: 1, UpdatedAt: minutes(5)}}
+	client.ListTicketsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Ticket, error, bool) {
+		var result []freshdesk.Ticket
+		for _, ticket := range tickets {
+			if !ticket.UpdatedAt.Before(*since) {
+				result = append(result, ticket)
+			}
+		}
+		return result, nil, false
+	}
+	client.ListDeletedTicketsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Ticket, error, bool) {
+		return []freshdesk.Ticket{{ID: 2, Deleted: true, UpdatedAt: minutes(3)}}, nil, false
+	}
+	client.ListContactsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Contact, error, bool) {
+		return []freshdesk.Contact{{ID: 3, UpdatedAt: minutes(4)}}, nil, false
+	}
+	client.ListCompaniesFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Company, error, bool) {
+		return []freshdesk.Company{{ID: 4, UpdatedAt: minutes(2)}}, nil, false
+	}
+
+	mirror := newMirror(t, client)
+	if err := mirror.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if ticket, err := mirror.Ticket(1); err != nil || ticket.Conversations != nil {
+		t.Errorf("ticket = %+v, %v", ticket, err)
+	}
+	if record, err := mirror.store.Get(Conversations, 10); err != nil || record == nil {
+		t.Errorf("conversation = %s, %v", record, err)
+	}
+	if ticket, err := mirror.Ticket(2); err != nil || !ticket.Deleted {
+		t.Errorf("deleted ticket = %+v, %v", ticket, err)
+	}
+	if client.CallCount("GetTicketWithConversations") != 1 {
+		t.Error("fetched the conversations of a deleted ticket")
+	}
+	if _, err := mirror.Contact(3); err != nil {
+		t.Error(err)
+	}
+	if _, err := mirror.Company(4); err != nil {
+		t.Error(err)
+	}
+	if _, err := mirror.Ticket(9); err != ErrNotFound {
+		t.Errorf("missing ticket: %v", err)
+	}
+
+	state, err := mirror.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed := state.Feeds[FeedTickets]; !feed.Since.Equal(*minutes(5)) || feed.Page != 0 {
+		t.Errorf("tickets feed = %+v", feed)
+	}
+	if calls := client.CallsTo("ListTickets"); !calls[0].Args[0].(*time.Time).Equal(time.Unix(0, 0)) {
+		t.Errorf("first pass since %v", calls[0].Args[0])
+	}
+
+	// The next pass starts one overlap before the newest update seen.
+	tickets = append(tickets, freshdesk.Ticket{ID: 5, UpdatedAt: minutes(20)})
+	client.Reset()
+	if err := mirror.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if calls := client.CallsTo("ListTickets"); len(calls) != 1 || !calls[0].Args[0].(*time.Time).Equal(*minutes(4)) {
+		t.Errorf("ListTickets calls = %+v", calls)
+	}
+	if _, err := mirror.Ticket(5); err != nil {
+		t.Error(err)
+	}
+	if state, _ := mirror.State(); !state.Feeds[FeedTickets].Since.Equal(*minutes(20)) {
+		t.Errorf("tickets feed = %+v", state.Feeds[FeedTickets])
+	}
+}
+
+func TestSyncResumesInterruptedPass(t *testing.T) {
+	client := newClient()
+	fail := true
+	client.ListContactsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Contact, error, bool) {
+		if page == 2 && fail {
+			fail = false
+			return nil, errors.New("connection reset"), false
+		}
+		return []freshdesk.Contact{{ID: uint64(page), UpdatedAt: minutes(page)}}, nil, page < 3
+	}
+
+	mirror := newMirror(t, client)
+	if err := mirror.Sync(); err == nil {
+		t.Fatal("sync succeeded")
+	}
+	state, err := mirror.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed := state.Feeds[FeedContacts]; feed.Page != 2 || !feed.Latest.Equal(*minutes(1)) {
+		t.Errorf("contacts feed = %+v", feed)
+	}
+
+	client.Reset()
+	if err := mirror.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	calls := client.CallsTo("ListContacts")
+	if len(calls) != 2 || calls[0].Args[2] != 2 || calls[1].Args[2] != 3 {
+		t.Errorf("ListContacts calls = %+v", calls)
+	}
+	if state, _ := mirror.State(); !state.Feeds[FeedContacts].Since.Equal(*minutes(3)) {
+		t.Errorf("contacts feed = %+v", state.Feeds[FeedContacts])
+	}
+}
+
+func TestSyncRestartsTicketsPastPageLimit(t *testing.T) {
+	client := newClient()
+	client.ListTicketsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Ticket, error, bool) {
+		if since.After(epoch) {
+			return nil, nil, false
+		}
+		return []freshdesk.Ticket{{ID: uint64(page), UpdatedAt: minutes(page)}}, nil, true
+	}
+
+	mirror := newMirror(t, client)
+	mirror.SetOverlap(0)
+	if err := mirror.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	calls := client.CallsTo("ListTickets")
+	if len(calls) != maxListPages+1 {
+		t.Fatalf("got %d ListTickets calls", len(calls))
+	}
+	if restart := calls[maxListPages]; restart.Args[2] != 1 || !restart.Args[0].(*time.Time).Equal(*minutes(maxListPages)) {
+		t.Errorf("restarted with %+v", restart)
+	}
+}
+
+func TestSyncReadsUnorderedFeedsToTheLastPage(t *testing.T) {
+	const pages = maxListPages + 5
+	client := newClient()
+	client.ListContactsFunc = func(since *time.Time, pageSize, page int) ([]freshdesk.Contact, error, bool) {
+		// Unordered: the last page holds the oldest update.
+		return []freshdesk.Contact{{ID: uint64(page), UpdatedAt: minutes(pages - page)}}, nil, page < pages
+	}
+
+	mirror := newMirror(t, client)
+	if err := mirror.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if calls := client.CallsTo("ListContacts"); len(calls) != pages || calls[pages-1].Args[2] != pages {
+		t.Errorf("got %d ListContacts calls", len(calls))
+	}
+	if _, err := mirror.Contact(pages); err != nil {
+		t.Error(err)
+	}
+	if state, _ := mirror.State(); !state.Feeds[FeedContacts].Since.Equal(*minutes(pages - 1)) {
+		t.Errorf("contacts feed = %+v", state.Feeds[FeedContacts])
+	}
+}
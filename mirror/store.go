@@ -0,0 +1,33 @@
+package mirror
+
+// Collections the mirror writes to.
+const (
+	Tickets       = "tickets"
+	Conversations = "conversations"
+	Contacts      = "contacts"
+	Companies     = "companies"
+)
+
+// Store keeps the mirrored records, the JSON encoding of the API types
+// keyed by record id, and the sync state. Put replaces any previous
+// version of a record. SaveState must only return once the records put
+// before it are durable, so that a restarted sync never skips data.
+type Store interface {
+	Put(collection string, ID uint64, record []byte) error
+	Get(collection string, ID uint64) ([]byte, error) // nil when missing
+	Each(collection string, fn func(ID uint64, record []byte) error) error
+	LoadState() ([]byte, error) // nil before the first SaveState
+	SaveState(state []byte) error
+	Close() error
+}
+
+// Compacter is implemented by stores that accumulate superseded versions of
+// records, like the JSONL store. Compact drops them; callers type-assert
+// the Store to find out whether it needs compacting:
+//
+//	if compacter, ok := store.(mirror.Compacter); ok {
+//		err = compacter.Compact()
+//	}
+type Compacter interface {
+	Compact() error
+}
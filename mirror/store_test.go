@@ -0,0 +1,189 @@
+package mirror
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testStore checks the behaviour common to every Store, reopen closes the
+// store and opens it again from the same files.
+func testStore(t *testing.T, store Store, reopen func() Store) {
+	t.Helper()
+	if state, err := store.LoadState(); err != nil || state != nil {
+		t.Fatalf("state before any save = %s, %v", state, err)
+	}
+
+	for _, record := range []struct {
+		id   uint64
+		data string
+	}{
+		{2, `{"id":2,"subject":"first"}`},
+		{1, `{"id":1}`},
+		{2, `{"id":2,"subject":"second"}`},
+	} {
+		if err := store.Put(Tickets, record.id, []byte(record.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Put(Contacts, 2, []byte(`{"id":2,"name":"Jane"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveState([]byte(`{"feeds":{}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	store = reopen()
+	defer store.Close()
+	if record, err := store.Get(Tickets, 2); err != nil || string(record) != `{"id":2,"subject":"second"}` {
+		t.Errorf("Get = %s, %v", record, err)
+	}
+	if record, err := store.Get(Tickets, 3); err != nil || record != nil {
+		t.Errorf("Get of a missing record = %s, %v", record, err)
+	}
+	if record, err := store.Get(Companies, 1); err != nil || record != nil {
+		t.Errorf("Get from an empty collection = %s, %v", record, err)
+	}
+	if state, err := store.LoadState(); err != nil || string(state) != `{"feeds":{}}` {
+		t.Errorf("state = %s, %v", state, err)
+	}
+
+	var ids []uint64
+	err := store.Each(Tickets, func(ID uint64, record []byte) error {
+		ids = append(ids, ID)
+		return nil
+	})
+	if err != nil || len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Each visited %v, %v", ids, err)
+	}
+}
+
+func TestJSONLStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store, func() Store {
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+		store, err = NewJSONLStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	})
+}
+
+func TestJSONLStoreDropsInterruptedWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, Tickets+".jsonl")
+	if err := os.WriteFile(path, []byte("{\"id\":1}\n{\"id\":2,\"sub"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewJSONLStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if record, _ := store.Get(Tickets, 2); record != nil {
+		t.Errorf("got interrupted record %s", record)
+	}
+	if err := store.Put(Tickets, 3, []byte(`{"id": 3}`)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\"id\":1}\n{\"id\":3}\n" {
+		t.Errorf("file holds %q", data)
+	}
+}
+
+func TestJSONLStoreCompact(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for _, record := range []struct {
+		id   uint64
+		data string
+	}{
+		{2, `{"id":2,"v":1}`},
+		{1, `{"id":1}`},
+		{2, `{"id":2,"v":2}`},
+	} {
+		if err := store.Put(Tickets, record.id, []byte(record.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compacter, ok := store.(Compacter)
+	if !ok {
+		t.Fatal("the JSONL store is not a Compacter")
+	}
+	if err := compacter.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, Tickets+".jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\"id\":1}\n{\"id\":2,\"v\":2}\n" {
+		t.Errorf("compacted file holds %q", data)
+	}
+	if record, err := store.Get(Tickets, 2); err != nil || !bytes.Equal(record, []byte(`{"id":2,"v":2}`)) {
+		t.Errorf("Get after Compact = %s, %v", record, err)
+	}
+}
+
+func TestJSONLStoreCompactUnopened(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, Contacts+".jsonl"), []byte("{\"id\":1,\"v\":1}\n{\"id\":1,\"v\":2}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Collections not touched since the store was opened are compacted too.
+	store, err := NewJSONLStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.(Compacter).Compact(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, Contacts+".jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\"id\":1,\"v\":2}\n" {
+		t.Errorf("compacted file holds %q", data)
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("directory holds %v, %v", entries, err)
+	}
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.db")
+	store, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store, func() Store {
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+		store, err = NewBoltStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	})
+}
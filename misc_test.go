@@ -0,0 +1,152 @@
+package freshdesk
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestNextPageLink(t *testing.T) {
+	cases := map[string]string{
+		"": "",
+		`<https://example.freshdesk.com/api/v2/groups?page=2>; rel="next"`:                                                                   "https://example.freshdesk.com/api/v2/groups?page=2",
+		`<https://example.freshdesk.com/api/v2/groups?page=1>; rel="prev", <https://example.freshdesk.com/api/v2/groups?page=3>; rel="next"`: "https://example.freshdesk.com/api/v2/groups?page=3",
+		`<https://example.freshdesk.com/api/v2/groups?page=1>; rel="prev"`:                                                                   "",
+		`https://example.freshdesk.com/api/v2/groups?page=2; rel="next"`:                                                                     "",
+	}
+	for header, want := range cases {
+		resp := &resty.Response{RawResponse: &http.Response{Header: http.Header{}}}
+		if header != "" {
+			resp.RawResponse.Header.Set("Link", header)
+		}
+		if got := nextPageLink(resp); got != want {
+			t.Errorf("nextPageLink(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestPutCustomData(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/custom", 200, `{"ok":true}`)
+
+	body, status, err := stub.client().PutCustomData([2]string{"Content-Type", "text/plain"}, "hello", "/api/v2/custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != 200 || body != `{"ok":true}` {
+		t.Errorf("got %d %q", status, body)
+	}
+	request := stub.received()[0]
+	if string(request.Body) != "hello" || request.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("sent %q as %q", request.Body, request.Header.Get("Content-Type"))
+	}
+}
+
+func TestPutCustomDataError(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/custom", 403, `{"code":"access_denied"}`)
+
+	body, status, err := stub.client().PutCustomData([2]string{"X-Test", "1"}, "", "/api/v2/custom")
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("got %v", err)
+	}
+	if status != 403 || body != "" {
+		t.Errorf("got %d %q", status, body)
+	}
+
+	if _, _, err := closedClient(t).PutCustomData([2]string{"X-Test", "1"}, "", "/api/v2/custom"); err == nil {
+		t.Error("expected a transport error")
+	}
+}
+
+func TestFindEmailConfigByEmail(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/email_configs",
+		stubResponse{Status: 200, Body: `[{"id":1,"to_email":"sales@example.com","reply_email":"sales@example.com"}]`, Header: stub.link("/api/v2/email_configs?per_page=100&page=2")},
+		stubResponse{Status: 200, Body: `[{"id":2,"to_email":"help@example.freshdesk.com","reply_email":"Support@Example.com"}]`},
+	)
+
+	config, err := stub.client().FindEmailConfigByEmail("support@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ID != 2 {
+		t.Errorf("got config %d", config.ID)
+	}
+}
+
+func TestSearchCustomObjectsFilter(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/custom_objects/schemas/3/records", 200, `{"records":[{"display_id":"7"},{"display_id":"8"}],"_links":{}}`)
+
+	records, err := stub.client().SearchCustomObjects(3, map[string]string{"serial": "A-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].DisplayID != "8" {
+		t.Errorf("got %+v", records)
+	}
+	if got := stub.received()[0].Query.Get("serial"); got != "A-1" {
+		t.Errorf("serial = %q", got)
+	}
+}
+
+func TestUpdateCustomObjectConflict(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/custom_objects/schemas/3/records/7", 409, `{"code":"version_conflict"}`)
+
+	_, err := stub.client().UpdateCustomObject(3, CustomObjectUpdatePayload{DisplayID: "7", Version: 1})
+	if err == nil || !strings.Contains(err.Error(), "version_conflict") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestListPagesFollowLink(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/admin/skills",
+		stubResponse{Status: 200, Body: `[{"id":1}]`, Header: stub.link("/api/v2/admin/skills?per_page=100&page=2")},
+		stubResponse{Status: 200, Body: `[{"id":2}]`, Header: stub.link("/api/v2/admin/skills?per_page=100&page=3")},
+		stubResponse{Status: 200, Body: `[]`},
+	)
+
+	skills, err := stub.client().ListSkills()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 2 || len(stub.received()) != 3 {
+		t.Errorf("got %d skills in %d requests", len(skills), len(stub.received()))
+	}
+}
+
+func TestRateLimitRetry(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets/7",
+		stubResponse{Status: 429, Body: `{"message":"rate limited"}`, Header: http.Header{"Retry-After": {"1"}}},
+		stubResponse{Status: 200, Body: `{"id":7}`},
+	)
+
+	start := time.Now()
+	ticket, err := stub.client().GetTicket(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 7 || len(stub.received()) != 2 {
+		t.Errorf("got ticket %d in %d requests", ticket.ID, len(stub.received()))
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, before Retry-After", elapsed)
+	}
+}
+
+func TestRateLimitRetriesExhausted(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets/7", stubResponse{Status: 429, Body: `{"message":"rate limited"}`, Header: http.Header{"Retry-After": {"0"}}})
+
+	_, err := stub.client().GetTicket(7)
+	if err == nil || !strings.Contains(err.Error(), "rate limited") || len(stub.received()) != 4 {
+		t.Errorf("got %v after %d requests", err, len(stub.received()))
+	}
+}
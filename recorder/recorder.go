@@ -0,0 +1,198 @@
+// Package recorder records the HTTP interactions of a freshdesk.Client in
+// cassette files and replays them, so tests of code using the client run
+// offline and deterministically.
+//
+// Cassettes never hold credentials: authorization and cookie headers are
+// dropped and email addresses are replaced with stable placeholders, in
+// requests and responses alike. The client gets the scrubbed response
+// while recording too, so a test sees the same data in both modes.
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves the requests from the cassette and fails those it
+	// does not hold.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the server and records them,
+	// replacing the cassette.
+	ModeRecord
+	// ModeReplayOrRecord replays an existing cassette and records a
+	// missing one.
+	ModeReplayOrRecord
+)
+
+// Recorder is an http.RoundTripper recording to or replaying from one
+// cassette file.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	filters   []func(*Interaction)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New opens the cassette at path. Recordings go through transport,
+// http.DefaultTransport when nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if mode == ModeReplayOrRecord {
+		mode = ModeRecord
+		if cassetteExists(path) {
+			mode = ModeReplay
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	recorder := &Recorder{path: path, mode: mode, transport: transport, cassette: &Cassette{}}
+	if mode == ModeReplay {
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		recorder.cassette = cassette
+		recorder.used = make([]bool, len(cassette.Interactions))
+	}
+	return recorder, nil
+}
+
+// Mode returns ModeRecord or ModeReplay.
+func (recorder *Recorder) Mode() Mode {
+	return recorder.mode
+}
+
+// AddFilter registers a function applied to every interaction after the
+// built-in scrubbing, before it is recorded or matched. Filters must
+// change requests the same way each time for replays to match.
+func (recorder *Recorder) AddFilter(filter func(*Interaction)) {
+	recorder.filters = append(recorder.filters, filter)
+}
+
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	interaction := Interaction{Request: Request{
+		Method:  req.Method,
+		URL:     ScrubEmails(requestURL(req.URL)),
+		Headers: scrubHeaders(req.Header),
+		Body:    ScrubEmails(string(body)),
+	}}
+
+	if recorder.mode == ModeReplay {
+		recorder.filter(&interaction)
+		recorded, err := recorder.match(interaction.Request)
+		if err != nil {
+			return nil, err
+		}
+		return recorded.Response.httpResponse(req), nil
+	}
+
+	resp, err := recorder.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.Response = Response{
+		Status:  resp.StatusCode,
+		Headers: scrubHeaders(resp.Header),
+		Body:    ScrubEmails(string(respBody)),
+	}
+	// Scrubbing changes the body length.
+	interaction.Response.Headers.Del("Content-Length")
+	recorder.filter(&interaction)
+
+	recorder.mu.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	recorder.mu.Unlock()
+	return interaction.Response.httpResponse(req), nil
+}
+
+func (recorder *Recorder) filter(interaction *Interaction) {
+	for _, filter := range recorder.filters {
+		filter(interaction)
+	}
+}
+
+// match returns the first unused interaction with the same method, URL
+// and body, so that repeated requests get the responses in recorded order.
+func (recorder *Recorder) match(request Request) (*Interaction, error) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	for i := range recorder.cassette.Interactions {
+		recorded := &recorder.cassette.Interactions[i]
+		if recorder.used[i] || recorded.Request.Method != request.Method ||
+			recorded.Request.URL != request.URL || recorded.Request.Body != request.Body {
+			continue
+		}
+		recorder.used[i] = true
+		return recorded, nil
+	}
+	return nil, fmt.Errorf("recorder: %v has no interaction for %v %v", recorder.path, request.Method, request.URL)
+}
+
+// Stop saves the cassette when recording.
+func (recorder *Recorder) Stop() error {
+	if recorder.mode != ModeRecord {
+		return nil
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.cassette.Save(recorder.path)
+}
+
+func requestURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.EscapedPath()
+	}
+	query, err := url.QueryUnescape(u.RawQuery)
+	if err != nil {
+		query = u.RawQuery
+	}
+	return u.EscapedPath() + "?" + query
+}
+
+func (response *Response) httpResponse(req *http.Request) *http.Response {
+	headers := response.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}
+}
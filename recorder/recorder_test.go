@@ -0,0 +1,159 @@
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// contactServer answers every request with a contact, the name holding
+// the request count.
+func contactServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Request-Owner", "jane@acme.io")
+		io.WriteString(w, `{"id": 7, "email": "jane@acme.io", "other_emails": ["ops@example.com"], "name": "Jane `+strings.Repeat("I", int(n))+`"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &count
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	server, count := contactServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "contact.json")
+
+	recorder, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := freshdesk.NewClientWithTransport(server.URL, "secret-key", "X", 60000, recorder)
+	var recorded []*freshdesk.Contact
+	for i := 0; i < 2; i++ {
+		contact, err := client.GetContact(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorded = append(recorded, contact)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The recording test already sees the scrubbed data.
+	if email := recorded[0].Email; !strings.HasPrefix(email, "user-") || !strings.HasSuffix(email, "@example.com") {
+		t.Errorf("recorded email %q", email)
+	}
+	if recorded[0].OtherEmails[0] != "ops@example.com" {
+		t.Errorf("example.com address scrubbed to %q", recorded[0].OtherEmails[0])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-key", "Authorization", "session=secret", "jane@acme.io"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette holds %q", secret)
+		}
+	}
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = freshdesk.NewClientWithTransport(ReplayURL, "replay", "X", 60000, replayer)
+	for i := 0; i < 2; i++ {
+		contact, err := client.GetContact(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(contact, recorded[i]) {
+			t.Errorf("replay %d = %+v, recorded %+v", i, contact, recorded[i])
+		}
+	}
+	if _, err := client.GetContact(7); err == nil || !strings.Contains(err.Error(), "no interaction for GET /api/v2/contacts/7") {
+		t.Errorf("third replay: %v", err)
+	}
+	if _, err := client.GetContact(8); err == nil {
+		t.Error("replayed an unrecorded request")
+	}
+	if n := atomic.LoadInt32(count); n != 2 {
+		t.Errorf("server got %d requests", n)
+	}
+}
+
+func TestRecordedResponseHeaders(t *testing.T) {
+	server, _ := contactServer(t)
+	recorder, err := New(filepath.Join(t.TempDir(), "headers.json"), ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: recorder}).Get(server.URL + "/api/v2/contacts/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.Header.Get("Set-Cookie") != "" || strings.Contains(resp.Header.Get("X-Request-Owner"), "acme.io") {
+		t.Errorf("headers = %v", resp.Header)
+	}
+	if resp.ContentLength != int64(len(body)) || strings.Contains(string(body), "acme.io") {
+		t.Errorf("body %q of length %d", body, resp.ContentLength)
+	}
+}
+
+func TestFilterAppliesInBothModes(t *testing.T) {
+	server, _ := contactServer(t)
+	path := filepath.Join(t.TempDir(), "filtered.json")
+	redact := func(interaction *Interaction) {
+		interaction.Response.Body = strings.ReplaceAll(interaction.Response.Body, "Jane", "Someone")
+	}
+
+	get := func(recorder *Recorder, baseUrl string) string {
+		t.Helper()
+		recorder.AddFilter(redact)
+		contact, err := freshdesk.NewClientWithTransport(baseUrl, "key", "X", 60000, recorder).GetContact(7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return contact.Name
+	}
+
+	recorder, err := New(path, ModeReplayOrRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Mode() != ModeRecord {
+		t.Fatalf("missing cassette opened in mode %v", recorder.Mode())
+	}
+	if name := get(recorder, server.URL); name != "Someone I" {
+		t.Errorf("recorded name %q", name)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := New(path, ModeReplayOrRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayer.Mode() != ModeReplay {
+		t.Fatalf("existing cassette opened in mode %v", replayer.Mode())
+	}
+	if name := get(replayer, ReplayURL); name != "Someone I" {
+		t.Errorf("replayed name %q", name)
+	}
+}
@@ -0,0 +1,41 @@
+package recorder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Headers never written to a cassette.
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// ScrubEmails replaces every email address with user-<hash>@example.com.
+// The hash depends only on the address, so a scrubbed request still
+// matches the scrubbed recording, and addresses at example.com are kept.
+func ScrubEmails(text string) string {
+	return emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+		if strings.HasSuffix(strings.ToLower(email), "@example.com") {
+			return email
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(email)))
+		return "user-" + hex.EncodeToString(sum[:4]) + "@example.com"
+	})
+}
+
+func scrubHeaders(headers http.Header) http.Header {
+	scrubbed := headers.Clone()
+	for _, name := range secretHeaders {
+		scrubbed.Del(name)
+	}
+	for name, values := range scrubbed {
+		for i := range values {
+			values[i] = ScrubEmails(values[i])
+		}
+		scrubbed[name] = values
+	}
+	return scrubbed
+}
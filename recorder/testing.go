@@ -0,0 +1,44 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+// ReplayURL is the helpdesk URL of clients replaying a cassette.
+const ReplayURL = "https://example.freshdesk.com"
+
+// NewClient returns a client for a test using the cassette
+// testdata/cassettes/<name>.json.
+//
+// With FRESHDESK_RECORD=1 the test runs against the helpdesk given by
+// FRESHDESK_URL and FRESHDESK_API_KEY and the cassette is rewritten when
+// the test ends. Otherwise the cassette is replayed and the test fails on
+// requests it does not hold.
+func NewClient(t testing.TB, name string) freshdesk.Client {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", name+".json")
+
+	mode, baseUrl, apiKey := ModeReplay, ReplayURL, "replay"
+	if os.Getenv("FRESHDESK_RECORD") == "1" {
+		mode, baseUrl, apiKey = ModeRecord, os.Getenv("FRESHDESK_URL"), os.Getenv("FRESHDESK_API_KEY")
+		if baseUrl == "" || apiKey == "" {
+			t.Fatal("recording needs FRESHDESK_URL and FRESHDESK_API_KEY")
+		}
+	}
+
+	recorder, err := New(path, mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return freshdesk.NewClientWithTransport(baseUrl, apiKey, "X", 6000, recorder)
+}
@@ -1,6 +1,7 @@
 package freshdesk
 
 import (
+	"encoding/json"
 	"os"
 	"time"
 )
@@ -10,33 +11,33 @@ type SdTicketMessage struct {
 }
 
 type TicketMessage struct {
-	Body              string      `json:"body"`
-	BodyText          string      `json:"body_text"`
-	ID                uint64      `json:"id"`
-	IsIncoming        bool        `json:"incoming"`
-	IsPrivate         bool        `json:"private"`
-	UserId            uint64      `json:"user_id"`
-	SupportEmail      string      `json:"support_email"`
-	Source            uint64      `json:"source"`
-	Category          uint64      `json:"category"`
-	EmailsTo          []string    `json:"to_emails"`
-	EmailFrom         string      `json:"from_email"`
-	EmailsCc          []string    `json:"cc_emails"`
-	EmailsBcc         []string    `json:"bcc_emails"`
-	EmailFailureCount uint64      `json:"email_failure_count"`
-	OutgoingFailures  uint64      `json:"outgoing_failures"`
-	ThreadId          uint64      `json:"thread_id"`
-	ThreadMessageId   uint64      `json:"thread_message_id"`
-	CreatedAt         *time.Time  `json:"created_at"`
-	UpdatedAt         *time.Time  `json:"updated_at"`
-	EditedAt          *time.Time  `json:"last_edited_at"`
-	EditedByUserId    uint64      `json:"last_edited_user_id"`
-	Attachments       interface{} `json:"attachments"`
-	AutomationId      uint64      `json:"automation_id"`
-	AutomationTypeId  uint64      `json:"automation_type_id"`
-	IsAutoResponse    bool        `json:"auto_response"`
-	TicketId          uint64      `json:"ticket_id"`
-	SrcAdditionalInfo interface{} `json:"source_additional_info"`
+	Body              string        `json:"body"`
+	BodyText          string        `json:"body_text"`
+	ID                uint64        `json:"id"`
+	IsIncoming        bool          `json:"incoming"`
+	IsPrivate         bool          `json:"private"`
+	UserId            uint64        `json:"user_id"`
+	SupportEmail      string        `json:"support_email"`
+	Source            MessageSource `json:"source"`
+	Category          uint64        `json:"category"`
+	EmailsTo          []string      `json:"to_emails"`
+	EmailFrom         string        `json:"from_email"`
+	EmailsCc          []string      `json:"cc_emails"`
+	EmailsBcc         []string      `json:"bcc_emails"`
+	EmailFailureCount uint64        `json:"email_failure_count"`
+	OutgoingFailures  uint64        `json:"outgoing_failures"`
+	ThreadId          uint64        `json:"thread_id"`
+	ThreadMessageId   uint64        `json:"thread_message_id"`
+	CreatedAt         *time.Time    `json:"created_at"`
+	UpdatedAt         *time.Time    `json:"updated_at"`
+	EditedAt          *time.Time    `json:"last_edited_at"`
+	EditedByUserId    uint64        `json:"last_edited_user_id"`
+	Attachments       interface{}   `json:"attachments"`
+	AutomationId      uint64        `json:"automation_id"`
+	AutomationTypeId  uint64        `json:"automation_type_id"`
+	IsAutoResponse    bool          `json:"auto_response"`
+	TicketId          uint64        `json:"ticket_id"`
+	SrcAdditionalInfo interface{}   `json:"source_additional_info"`
 }
 
 type TicketMessageCreatePayload struct {
@@ -77,7 +78,7 @@ type Ticket struct {
 	ReplyCcEmails   []string        `json:"reply_cc_emails"`
 	RequesterID     int64           `json:"requester_id"` // UserID of the requester
 	ResponderID     int64           `json:"responder_id"`
-	Source          int64           `json:"source"`
+	Source          Source          `json:"source"`
 	Spam            bool            `json:"spam"`
 	Status          Status          `json:"status"`
 	Subject         string          `json:"subject"`
@@ -88,26 +89,54 @@ type Ticket struct {
 	CreatedAt       *time.Time      `json:"created_at"`
 	UpdatedAt       *time.Time      `json:"updated_at"`
 	Conversations   []TicketMessage `json:"conversations"`
+
+	AssociationType       AssociationType `json:"association_type"`
+	AssociatedTicketsList []uint64        `json:"associated_tickets_list"` // Children of a parent, related tickets of a tracker
 }
 
-type Priority int64
-type Status int64
+// AssociationType is the role of a ticket in a parent/child or tracker
+// association, zero when not associated.
+type AssociationType int64
 
 const (
-	PriorityLow    Priority = 1
-	PriorityMedium Priority = 2
-	PriorityHigh   Priority = 3
-	PriorityUrgent Priority = 4
+	AssociationParent  AssociationType = 1
+	AssociationChild   AssociationType = 2
+	AssociationTracker AssociationType = 3
+	AssociationRelated AssociationType = 4
 )
+
+type TicketWatchers struct {
+	WatcherIDs []uint64 `json:"watcher_ids"`
+}
+
 const (
-	StatusOpen     Status = 2
-	StatusPending  Status = 3
-	StatusResolved Status = 4
-	StatusClosed   Status = 5
+	ERR_CONTACT_NOT_FOUND string = "Contact not found"
+	ERR_COMPANY_NOT_FOUND string = "Company not found"
+	ERR_DOMAIN_CONFLICT   string = "Domain belongs to several companies"
+	ERR_ALREADY_STARTED   string = "Already started"
+
+	ERR_EMAIL_CONFIG_NOT_FOUND string = "Email config not found"
+	ERR_STATUS_FIELD_NOT_FOUND string = "Status ticket field not found"
+	ERR_UPSERT_KEY_MISSING     string = "Payload has no value for the upsert key"
 )
 
+// UpsertKey is the field used to find the record to update.
+type UpsertKey string
+
 const (
-	ERR_CONTACT_NOT_FOUND string = "Contact not found"
+	UpsertByEmail      UpsertKey = "email"
+	UpsertByPhone      UpsertKey = "phone"
+	UpsertByExternalID UpsertKey = "unique_external_id"
+	UpsertByName       UpsertKey = "name"
+	UpsertByDomain     UpsertKey = "domain"
+)
+
+type UpsertResult string
+
+const (
+	UpsertCreated   UpsertResult = "created"
+	UpsertUpdated   UpsertResult = "updated"
+	UpsertUnchanged UpsertResult = "unchanged"
 )
 
 type TicketCreatePayload struct {
@@ -120,8 +149,8 @@ type TicketCreatePayload struct {
 	UniqueExternalID string        `json:"unique_external_id,omitempty"`
 	Subject          string        `json:"subject,omitempty"`
 	Type             string        `json:"type,omitempty"`
-	Status           int64         `json:"status,omitempty"`
-	Priority         int64         `json:"priority,omitempty"`
+	Status           Status        `json:"status,omitempty"`
+	Priority         Priority      `json:"priority,omitempty"`
 	Description      string        `json:"description,omitempty"`
 	ResponderID      int64         `json:"responder_id,omitempty"`
 	Attachments      []interface{} `json:"attachments,omitempty"`
@@ -132,13 +161,16 @@ type TicketCreatePayload struct {
 	FrDueBy          *time.Time    `json:"fr_due_by,omitempty"`
 	GroupID          int64         `json:"group_id,omitempty"`
 	ProductID        int64         `json:"product_id,omitempty"`
-	Source           int64         `json:"source,omitempty"`
+	Source           Source        `json:"source,omitempty"`
 	Tags             []string      `json:"tags,omitempty"`
 	CompanyID        uint64        `json:"company_id,omitempty"`
 	InternalAgentID  int64         `json:"internal_agent_id,omitempty"`
 	InternalGroupID  int64         `json:"internal_group_id,omitempty"`
+	ParentID         uint64        `json:"parent_id,omitempty"`          // Creates a child ticket, see CreateChildTicket
+	RelatedTicketIDs []uint64      `json:"related_ticket_ids,omitempty"` // Creates a tracker ticket
 }
 
+// Deprecated: Freshservice tickets are handled by the freshservice package.
 type SdTicketCreatePayload struct {
 	Name             string        `json:"name,omitempty"`
 	RequesterID      int64         `json:"requester_id,omitempty"`
@@ -150,8 +182,8 @@ type SdTicketCreatePayload struct {
 	Subject          string        `json:"subject,omitempty"`
 	Type             string        `json:"type,omitempty"`
 	Category         string        `json:"category"`
-	Status           int64         `json:"status,omitempty"`
-	Priority         int64         `json:"priority,omitempty"`
+	Status           Status        `json:"status,omitempty"`
+	Priority         Priority      `json:"priority,omitempty"`
 	Description      string        `json:"description,omitempty"`
 	ResponderID      int64         `json:"responder_id,omitempty"`
 	Impact           int64         `json:"impact"`
@@ -164,7 +196,7 @@ type SdTicketCreatePayload struct {
 	FrDueBy          *time.Time    `json:"fr_due_by,omitempty"`
 	GroupID          int64         `json:"group_id,omitempty"`
 	ProductID        int64         `json:"product_id,omitempty"`
-	Source           int64         `json:"source,omitempty"`
+	Source           Source        `json:"source,omitempty"`
 	Tags             []string      `json:"tags,omitempty"`
 	CompanyID        uint64        `json:"company_id,omitempty"`
 	InternalAgentID  int64         `json:"internal_agent_id,omitempty"`
@@ -181,8 +213,8 @@ type TicketUpdatePayload struct {
 	UniqueExternalID string        `json:"unique_external_id,omitempty"`
 	Subject          string        `json:"subject,omitempty"`
 	Type             string        `json:"type,omitempty"`
-	Status           int64         `json:"status,omitempty"`
-	Priority         int64         `json:"priority,omitempty"`
+	Status           Status        `json:"status,omitempty"`
+	Priority         Priority      `json:"priority,omitempty"`
 	Description      string        `json:"description,omitempty"`
 	ResponderID      int64         `json:"responder_id,omitempty"`
 	Attachments      []interface{} `json:"attachments,omitempty"`
@@ -192,7 +224,7 @@ type TicketUpdatePayload struct {
 	FrDueBy          *time.Time    `json:"fr_due_by,omitempty"`
 	GroupID          int64         `json:"group_id,omitempty"`
 	ProductID        int64         `json:"product_id,omitempty"`
-	Source           int64         `json:"source,omitempty"`
+	Source           Source        `json:"source,omitempty"`
 	Tags             []string      `json:"tags,omitempty"`
 	CompanyID        uint64        `json:"company_id,omitempty"`
 	InternalAgentID  int64         `json:"internal_agent_id,omitempty"`
@@ -200,7 +232,7 @@ type TicketUpdatePayload struct {
 }
 
 type TicketStatusUpdatePayload struct {
-	Status int64 `json:"status"`
+	Status Status `json:"status"`
 }
 
 type Contact struct {
@@ -315,8 +347,25 @@ type Company struct {
 	CreatedAt    *time.Time  `json:"created_at"`
 	UpdatedAt    *time.Time  `json:"updated_at"`
 	OrgCompanyID uint64      `json:"org_company_id"`
+	ContactCount *uint64     `json:"contact_count,omitempty"` // Only set by GetCompanyWithContactCount
 }
 
+// Default values of the account_tier and health_score company fields.
+const (
+	AccountTierBasic      string = "Basic"
+	AccountTierPremium    string = "Premium"
+	AccountTierEnterprise string = "Enterprise"
+)
+const (
+	HealthScoreAtRisk    string = "At risk"
+	HealthScoreDoingOkay string = "Doing okay"
+	HealthScoreHappy     string = "Happy"
+)
+
+// CustomFields holds the custom_fields object of a record, keyed by the
+// field name configured in the helpdesk.
+type CustomFields map[string]interface{}
+
 type CompanyName struct {
 	ID   uint64 `json:"id"`
 	Name string `json:"name"`
@@ -326,6 +375,11 @@ type SrchCompanyResp struct {
 	CompanyNames []CompanyName `json:"companies"`
 }
 
+type SrchCompanyFullResp struct {
+	Total   uint64    `json:"total"`
+	Results []Company `json:"results"`
+}
+
 type SrchContactResp struct {
 	Total   uint64    `json:"total"`
 	Results []Contact `json:"results"`
@@ -388,6 +442,29 @@ type Group struct {
 	AutoAgentAssign        interface{} `json:"automatic_agent_assignment,omitempty"`
 }
 
+type GroupCreatePayload struct {
+	Name                   string      `json:"name"`
+	Description            string      `json:"description,omitempty"`
+	EscalateTo             uint64      `json:"escalate_to,omitempty"`
+	UnassignedFor          string      `json:"unassigned_for,omitempty"`
+	Agents                 []uint64    `json:"agent_ids,omitempty"`
+	AllowAgentsChangeAvail bool        `json:"allow_agents_to_change_availability,omitempty"`
+	BusinessCalendar       uint64      `json:"business_calendar_id,omitempty"`
+	Type                   string      `json:"type,omitempty"`
+	AutoAgentAssign        interface{} `json:"automatic_agent_assignment,omitempty"`
+}
+
+type GroupUpdatePayload struct {
+	Name                   string      `json:"name,omitempty"`
+	Description            string      `json:"description,omitempty"`
+	EscalateTo             uint64      `json:"escalate_to,omitempty"`
+	UnassignedFor          string      `json:"unassigned_for,omitempty"`
+	Agents                 []uint64    `json:"agent_ids,omitempty"`
+	AllowAgentsChangeAvail bool        `json:"allow_agents_to_change_availability,omitempty"`
+	BusinessCalendar       uint64      `json:"business_calendar_id,omitempty"`
+	AutoAgentAssign        interface{} `json:"automatic_agent_assignment,omitempty"`
+}
+
 type CustomObject struct {
 	DisplayID   string                 `json:"display_id"`
 	CreatedTime uint64                 `json:"created_time"`
@@ -415,3 +492,538 @@ type CustomObjectUpdateResult struct {
 	UpdatedTime uint64                 `json:"updated_time"`
 	Data        map[string]interface{} `json:"data"`
 }
+
+type SolutionCategory struct {
+	ID               uint64     `json:"id"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	VisibleInPortals []uint64   `json:"visible_in_portals"`
+	CreatedAt        *time.Time `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type SolutionCategoryCreatePayload struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	VisibleInPortals []uint64 `json:"visible_in_portals,omitempty"`
+}
+
+type SolutionFolder struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Visibility  int64      `json:"visibility"`
+	CompanyIDs  []uint64   `json:"company_ids"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+type SolutionFolderCreatePayload struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Visibility  int64    `json:"visibility"`
+	CompanyIDs  []uint64 `json:"company_ids,omitempty"`
+}
+
+type SolutionArticle struct {
+	ID              uint64        `json:"id"`
+	Type            int64         `json:"type"`
+	CategoryID      uint64        `json:"category_id"`
+	FolderID        uint64        `json:"folder_id"`
+	AgentID         uint64        `json:"agent_id"`
+	Title           string        `json:"title"`
+	Description     string        `json:"description"`
+	DescriptionText string        `json:"description_text"`
+	Status          ArticleStatus `json:"status"`
+	Tags            []string      `json:"tags"`
+	SeoData         interface{}   `json:"seo_data"`
+	Hits            uint64        `json:"hits"`
+	ThumbsUp        uint64        `json:"thumbs_up"`
+	ThumbsDown      uint64        `json:"thumbs_down"`
+	CreatedAt       *time.Time    `json:"created_at"`
+	UpdatedAt       *time.Time    `json:"updated_at"`
+}
+
+type ArticleStatus int64
+
+const (
+	ArticleStatusDraft     ArticleStatus = 1
+	ArticleStatusPublished ArticleStatus = 2
+)
+
+type SolutionArticleCreatePayload struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Status      ArticleStatus `json:"status"`
+	Type        int64         `json:"type,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	SeoData     interface{}   `json:"seo_data,omitempty"`
+}
+
+// SolutionArticleUpdatePayload leaves the tags unchanged when Tags is nil,
+// a pointer to an empty list removes them all.
+type SolutionArticleUpdatePayload struct {
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Status      ArticleStatus `json:"status,omitempty"`
+	Type        int64         `json:"type,omitempty"`
+	Tags        *[]string     `json:"tags,omitempty"`
+	SeoData     interface{}   `json:"seo_data,omitempty"`
+}
+
+type Product struct {
+	ID           uint64     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	PrimaryEmail string     `json:"primary_email"`
+	Default      bool       `json:"default"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
+
+type EmailConfig struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	ProductID   uint64     `json:"product_id"`
+	ToEmail     string     `json:"to_email"`
+	ReplyEmail  string     `json:"reply_email"`
+	GroupID     uint64     `json:"group_id"`
+	PrimaryRole bool       `json:"primary_role"`
+	Active      bool       `json:"active"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+type ProductCreatePayload struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	PrimaryEmail string `json:"primary_email,omitempty"`
+}
+
+type ProductUpdatePayload struct {
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	PrimaryEmail string `json:"primary_email,omitempty"`
+}
+
+type Mailbox struct {
+	ID                uint64                 `json:"id"`
+	Name              string                 `json:"name"`
+	SupportEmail      string                 `json:"support_email"`
+	GroupID           uint64                 `json:"group_id"`
+	DefaultReplyEmail bool                   `json:"default_reply_email"`
+	Active            bool                   `json:"active"`
+	MailboxType       string                 `json:"mailbox_type"`
+	ProductID         uint64                 `json:"product_id"`
+	FreshdeskMailbox  *FreshdeskMailbox      `json:"freshdesk_mailbox,omitempty"`
+	CustomMailbox     map[string]interface{} `json:"custom_mailbox,omitempty"`
+	CreatedAt         *time.Time             `json:"created_at"`
+	UpdatedAt         *time.Time             `json:"updated_at"`
+}
+
+type FreshdeskMailbox struct {
+	ForwardEmail string `json:"forward_email"`
+}
+
+const (
+	MailboxTypeFreshdesk = "freshdesk_mailbox"
+	MailboxTypeCustom    = "custom_mailbox"
+)
+
+type MailboxCreatePayload struct {
+	Name              string                 `json:"name,omitempty"`
+	SupportEmail      string                 `json:"support_email,omitempty"`
+	GroupID           uint64                 `json:"group_id,omitempty"`
+	DefaultReplyEmail bool                   `json:"default_reply_email,omitempty"`
+	MailboxType       string                 `json:"mailbox_type,omitempty"`
+	ProductID         uint64                 `json:"product_id,omitempty"`
+	FreshdeskMailbox  *FreshdeskMailbox      `json:"freshdesk_mailbox,omitempty"`
+	CustomMailbox     map[string]interface{} `json:"custom_mailbox,omitempty"`
+}
+
+type MailboxUpdatePayload struct {
+	Name              string                 `json:"name,omitempty"`
+	SupportEmail      string                 `json:"support_email,omitempty"`
+	GroupID           uint64                 `json:"group_id,omitempty"`
+	DefaultReplyEmail bool                   `json:"default_reply_email,omitempty"`
+	ProductID         uint64                 `json:"product_id,omitempty"`
+	FreshdeskMailbox  *FreshdeskMailbox      `json:"freshdesk_mailbox,omitempty"`
+	CustomMailbox     map[string]interface{} `json:"custom_mailbox,omitempty"`
+}
+
+type BusinessHours struct {
+	ID            uint64                `json:"id"`
+	Name          string                `json:"name"`
+	Description   string                `json:"description"`
+	IsDefault     bool                  `json:"is_default"`
+	TimeZone      string                `json:"time_zone"`
+	BusinessHours map[string]WorkingDay `json:"business_hours"` // Keyed by lower case weekday name
+	Holidays      []Holiday             `json:"holidays,omitempty"`
+	CreatedAt     *time.Time            `json:"created_at"`
+	UpdatedAt     *time.Time            `json:"updated_at"`
+
+	parsed *businessCalendar // Built on first use, see calendar
+}
+
+type WorkingDay struct {
+	StartTime string `json:"start_time"` // "8:00 am"
+	EndTime   string `json:"end_time"`   // "5:00 pm"
+}
+
+// Holiday is a non working day, either on a given date ("2024-12-25") or
+// on the same day every year ("Dec 25"). The v2 business hours endpoint
+// does not return holidays on every plan, callers may fill them in.
+type Holiday struct {
+	Name string `json:"name"`
+	Date string `json:"date"`
+}
+
+type SLAPolicy struct {
+	ID           uint64               `json:"id"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Active       bool                 `json:"active"`
+	IsDefault    bool                 `json:"is_default"`
+	Position     int64                `json:"position"`
+	ApplicableTo SLAApplicableTo      `json:"applicable_to"`
+	SLATarget    map[string]SLATarget `json:"sla_target"` // Keyed by "priority_1" to "priority_4"
+	Escalation   interface{}          `json:"escalation,omitempty"`
+	CreatedAt    *time.Time           `json:"created_at"`
+	UpdatedAt    *time.Time           `json:"updated_at"`
+}
+
+type SLAApplicableTo struct {
+	CompanyIDs      []uint64 `json:"company_ids,omitempty"`
+	GroupIDs        []int64  `json:"group_ids,omitempty"`
+	ProductIDs      []int64  `json:"product_ids,omitempty"`
+	Sources         []Source `json:"sources,omitempty"`
+	TicketTypes     []string `json:"ticket_types,omitempty"`
+	ContactSegments []uint64 `json:"contact_segments,omitempty"`
+	CompanySegments []uint64 `json:"company_segments,omitempty"`
+}
+
+type SLATarget struct {
+	RespondWithin       int64 `json:"respond_within"` // Seconds
+	ResolveWithin       int64 `json:"resolve_within"` // Seconds
+	EveryResponseWithin int64 `json:"every_response_within,omitempty"`
+	BusinessHours       bool  `json:"business_hours"`
+	EscalationEnabled   bool  `json:"escalation_enabled"`
+}
+
+type SLAPolicyCreatePayload struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description,omitempty"`
+	Active       *bool                `json:"active,omitempty"`
+	ApplicableTo *SLAApplicableTo     `json:"applicable_to,omitempty"`
+	SLATarget    map[string]SLATarget `json:"sla_target,omitempty"`
+	Escalation   interface{}          `json:"escalation,omitempty"`
+}
+
+type SLAPolicyUpdatePayload struct {
+	Name         string               `json:"name,omitempty"`
+	Description  string               `json:"description,omitempty"`
+	Active       *bool                `json:"active,omitempty"`
+	ApplicableTo *SLAApplicableTo     `json:"applicable_to,omitempty"`
+	SLATarget    map[string]SLATarget `json:"sla_target,omitempty"`
+	Escalation   interface{}          `json:"escalation,omitempty"`
+}
+
+type Role struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Default     bool       `json:"default"`
+	AgentType   int64      `json:"agent_type"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+type Skill struct {
+	ID         uint64           `json:"id"`
+	Name       string           `json:"name"`
+	Rank       int64            `json:"rank"`
+	MatchType  string           `json:"match_type"` // "any" or "all"
+	Conditions []SkillCondition `json:"conditions"`
+	Agents     []SkillAgent     `json:"agents"`
+	CreatedAt  *time.Time       `json:"created_at"`
+	UpdatedAt  *time.Time       `json:"updated_at"`
+}
+
+type SkillCondition struct {
+	ResourceType string        `json:"resource_type"` // "ticket", "contact" or "company"
+	FieldName    string        `json:"field_name"`
+	Operator     string        `json:"operator"` // "in" or "not_in"
+	Value        []interface{} `json:"value"`
+}
+
+type SkillAgent struct {
+	ID uint64 `json:"id"`
+}
+
+const (
+	SkillMatchAny = "any"
+	SkillMatchAll = "all"
+)
+
+type SkillCreatePayload struct {
+	Name       string           `json:"name"`
+	Rank       int64            `json:"rank,omitempty"`
+	MatchType  string           `json:"match_type,omitempty"`
+	Conditions []SkillCondition `json:"conditions,omitempty"`
+	Agents     []SkillAgent     `json:"agents,omitempty"`
+}
+
+type SkillUpdatePayload struct {
+	Name       string           `json:"name,omitempty"`
+	Rank       int64            `json:"rank,omitempty"`
+	MatchType  string           `json:"match_type,omitempty"`
+	Conditions []SkillCondition `json:"conditions,omitempty"`
+	Agents     []SkillAgent     `json:"agents,omitempty"`
+}
+
+type AgentAvailability struct {
+	AgentID             uint64                `json:"agent_id"`
+	Available           bool                  `json:"available"`
+	AvailableSince      *time.Time            `json:"available_since"`
+	ChannelAvailability []ChannelAvailability `json:"channel_availability,omitempty"`
+}
+
+type ChannelAvailability struct {
+	Channel        string     `json:"channel"` // "freshdesk", "freshchat" or "freshcaller"
+	Available      bool       `json:"available"`
+	AvailableSince *time.Time `json:"available_since"`
+	Status         string     `json:"status,omitempty"`
+}
+
+type AgentAvailabilityUpdatePayload struct {
+	Available           *bool                 `json:"available,omitempty"`
+	ChannelAvailability []ChannelAvailability `json:"channel_availability,omitempty"`
+}
+
+type AutomationType int64
+
+const (
+	AutomationTicketCreation AutomationType = 1
+	AutomationTimeTriggers   AutomationType = 3
+	AutomationTicketUpdates  AutomationType = 4
+)
+
+type AutomationRule struct {
+	ID            uint64                     `json:"id,omitempty"`
+	Name          string                     `json:"name"`
+	Position      int64                      `json:"position,omitempty"`
+	Active        bool                       `json:"active"`
+	Performer     *AutomationPerformer       `json:"performer,omitempty"`
+	Events        []AutomationEvent          `json:"events,omitempty"`
+	Conditions    []AutomationConditionSet   `json:"conditions,omitempty"`
+	Operator      string                     `json:"operator,omitempty"`
+	Actions       []AutomationAction         `json:"actions"`
+	Summary       interface{}                `json:"summary,omitempty"`
+	Outdated      bool                       `json:"outdated,omitempty"`
+	LastUpdatedBy uint64                     `json:"last_updated_by,omitempty"`
+	CreatedAt     *time.Time                 `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time                 `json:"updated_at,omitempty"`
+	Extra         map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationPerformer struct {
+	Type    int64                      `json:"type"`
+	Members []uint64                   `json:"members,omitempty"`
+	Extra   map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationEvent struct {
+	FieldName string                     `json:"field_name"`
+	From      interface{}                `json:"from,omitempty"`
+	To        interface{}                `json:"to,omitempty"`
+	Value     interface{}                `json:"value,omitempty"`
+	Extra     map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationConditionSet struct {
+	Name       string                     `json:"name,omitempty"`
+	MatchType  string                     `json:"match_type"` // "all" or "any"
+	Properties []AutomationCondition      `json:"properties"`
+	Extra      map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationCondition struct {
+	ResourceType string                     `json:"resource_type"`
+	FieldName    string                     `json:"field_name"`
+	Operator     string                     `json:"operator"`
+	Value        interface{}                `json:"value,omitempty"`
+	Extra        map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationAction struct {
+	FieldName string                     `json:"field_name,omitempty"`
+	Value     interface{}                `json:"value,omitempty"`
+	Extra     map[string]json.RawMessage `json:"-"`
+}
+
+type AutomationRulePayload struct {
+	Name       string                     `json:"name"`
+	Position   int64                      `json:"position,omitempty"`
+	Active     bool                       `json:"active"`
+	Performer  *AutomationPerformer       `json:"performer,omitempty"`
+	Events     []AutomationEvent          `json:"events,omitempty"`
+	Conditions []AutomationConditionSet   `json:"conditions,omitempty"`
+	Operator   string                     `json:"operator,omitempty"`
+	Actions    []AutomationAction         `json:"actions"`
+	Extra      map[string]json.RawMessage `json:"-"`
+}
+
+type ScenarioAutomation struct {
+	ID          uint64             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Actions     []AutomationAction `json:"actions"`
+	Private     bool               `json:"private"`
+}
+
+type TicketForm struct {
+	ID          uint64                     `json:"id,omitempty"`
+	Name        string                     `json:"name"`
+	Title       string                     `json:"title,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Default     bool                       `json:"default,omitempty"`
+	Fields      []TicketFormField          `json:"fields,omitempty"`
+	Portals     []uint64                   `json:"portals,omitempty"`
+	CreatedAt   *time.Time                 `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time                 `json:"updated_at,omitempty"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+type TicketFormField struct {
+	ID                   uint64                     `json:"id"`
+	Name                 string                     `json:"name,omitempty"`
+	Label                string                     `json:"label,omitempty"`
+	LabelForCustomers    string                     `json:"label_for_customers,omitempty"`
+	Type                 string                     `json:"type,omitempty"`
+	Position             int64                      `json:"position,omitempty"`
+	RequiredForAgents    bool                       `json:"required_for_agents,omitempty"`
+	RequiredForCustomers bool                       `json:"required_for_customers,omitempty"`
+	Extra                map[string]json.RawMessage `json:"-"`
+}
+
+type TicketFormPayload struct {
+	Name        string                     `json:"name,omitempty"`
+	Title       string                     `json:"title,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Fields      []TicketFormField          `json:"fields,omitempty"`
+	Portals     []uint64                   `json:"portals,omitempty"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+type TicketField struct {
+	ID                   uint64      `json:"id"`
+	Name                 string      `json:"name"`
+	Label                string      `json:"label"`
+	LabelForCustomers    string      `json:"label_for_customers"`
+	Description          string      `json:"description"`
+	Position             int64       `json:"position"`
+	Type                 string      `json:"type"`
+	Default              bool        `json:"default"`
+	RequiredForClosure   bool        `json:"required_for_closure"`
+	RequiredForAgents    bool        `json:"required_for_agents"`
+	RequiredForCustomers bool        `json:"required_for_customers"`
+	CustomersCanEdit     bool        `json:"customers_can_edit"`
+	DisplayedToCustomers bool        `json:"displayed_to_customers"`
+	PortalCc             bool        `json:"portal_cc,omitempty"`
+	PortalCcTo           string      `json:"portal_cc_to,omitempty"`
+	Choices              interface{} `json:"choices,omitempty"` // Shape depends on the field type
+	CreatedAt            *time.Time  `json:"created_at"`
+	UpdatedAt            *time.Time  `json:"updated_at"`
+}
+
+type TicketFieldCreatePayload struct {
+	Label                string      `json:"label"`
+	LabelForCustomers    string      `json:"label_for_customers,omitempty"`
+	Description          string      `json:"description,omitempty"`
+	Position             int64       `json:"position,omitempty"`
+	Type                 string      `json:"type"`
+	RequiredForClosure   bool        `json:"required_for_closure,omitempty"`
+	RequiredForAgents    bool        `json:"required_for_agents,omitempty"`
+	RequiredForCustomers bool        `json:"required_for_customers,omitempty"`
+	CustomersCanEdit     bool        `json:"customers_can_edit,omitempty"`
+	DisplayedToCustomers bool        `json:"displayed_to_customers,omitempty"`
+	Choices              interface{} `json:"choices,omitempty"`
+}
+
+type TicketFieldUpdatePayload struct {
+	Label                string      `json:"label,omitempty"`
+	LabelForCustomers    string      `json:"label_for_customers,omitempty"`
+	Description          string      `json:"description,omitempty"`
+	Position             int64       `json:"position,omitempty"`
+	RequiredForClosure   *bool       `json:"required_for_closure,omitempty"`
+	RequiredForAgents    *bool       `json:"required_for_agents,omitempty"`
+	RequiredForCustomers *bool       `json:"required_for_customers,omitempty"`
+	CustomersCanEdit     *bool       `json:"customers_can_edit,omitempty"`
+	DisplayedToCustomers *bool       `json:"displayed_to_customers,omitempty"`
+	Choices              interface{} `json:"choices,omitempty"`
+}
+
+type CannedResponseFolder struct {
+	ID             uint64     `json:"id"`
+	Name           string     `json:"name"`
+	Personal       bool       `json:"personal"`
+	ResponsesCount uint64     `json:"responses_count"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+type CannedResponseFolderCreatePayload struct {
+	Name string `json:"name"`
+}
+
+type CannedResponse struct {
+	ID          uint64        `json:"id"`
+	Title       string        `json:"title"`
+	FolderID    uint64        `json:"folder_id"`
+	Content     string        `json:"content"`
+	ContentHtml string        `json:"content_html"`
+	Visibility  int64         `json:"visibility"` // 0 all agents, 1 personal, 2 selected groups
+	GroupIDs    []int64       `json:"group_ids"`
+	Attachments []interface{} `json:"attachments"`
+	CreatedAt   *time.Time    `json:"created_at"`
+	UpdatedAt   *time.Time    `json:"updated_at"`
+}
+
+type CannedResponseCreatePayload struct {
+	Title       string  `json:"title"`
+	FolderID    uint64  `json:"folder_id"`
+	ContentHtml string  `json:"content_html"`
+	Visibility  int64   `json:"visibility"`
+	GroupIDs    []int64 `json:"group_ids,omitempty"`
+}
+
+type CannedResponseUpdatePayload struct {
+	Title       string  `json:"title,omitempty"`
+	FolderID    uint64  `json:"folder_id,omitempty"`
+	ContentHtml string  `json:"content_html,omitempty"`
+	Visibility  *int64  `json:"visibility,omitempty"`
+	GroupIDs    []int64 `json:"group_ids,omitempty"`
+}
+
+// TicketActivitiesExport lists the files of a ticket activities export.
+type TicketActivitiesExport struct {
+	Export []TicketActivitiesFile `json:"export"`
+}
+
+type TicketActivitiesFile struct {
+	URL string `json:"url"`
+}
+
+type TicketActivitiesData struct {
+	ActivitiesData []TicketActivity `json:"activities_data"`
+}
+
+// TicketActivity holds the changes a performer made to a ticket at once,
+// keyed by property name as in the export, see Events.
+type TicketActivity struct {
+	TicketID      uint64                 `json:"ticket_id"`
+	PerformerType string                 `json:"performer_type"` // agent, contact or system
+	PerformerID   uint64                 `json:"performer_id"`
+	PerformedAt   time.Time              `json:"performed_at"`
+	Activity      map[string]interface{} `json:"activity"`
+}
@@ -0,0 +1,106 @@
+package freshdesk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Matches reports whether the ticket satisfies the skill conditions. Only
+// ticket conditions on priority, source, ticket_type, group_id,
+// product_id and company_id and on ticket custom fields (cf_*) can be
+// evaluated offline; any other condition does not match.
+func (skill *Skill) Matches(ticket *Ticket) bool {
+	if len(skill.Conditions) == 0 {
+		return false
+	}
+
+	for _, condition := range skill.Conditions {
+		matched := condition.matches(ticket)
+		if matched && skill.MatchType == SkillMatchAny {
+			return true
+		}
+		if !matched && skill.MatchType != SkillMatchAny {
+			return false
+		}
+	}
+	return skill.MatchType != SkillMatchAny
+}
+
+// AgentIDs returns the ids of the agents having the skill.
+func (skill *Skill) AgentIDs() []uint64 {
+	ids := make([]uint64, 0, len(skill.Agents))
+	for _, agent := range skill.Agents {
+		ids = append(ids, agent.ID)
+	}
+	return ids
+}
+
+// MatchingSkills returns the skills matching the ticket, highest ranked
+// (lowest rank number) first.
+func MatchingSkills(skills []Skill, ticket *Ticket) []Skill {
+	var matching []Skill
+	for i := range skills {
+		if skills[i].Matches(ticket) {
+			matching = append(matching, skills[i])
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Rank < matching[j].Rank })
+	return matching
+}
+
+func (condition SkillCondition) matches(ticket *Ticket) bool {
+	if condition.ResourceType != "" && condition.ResourceType != "ticket" {
+		return false
+	}
+
+	var value interface{}
+	switch condition.FieldName {
+	case "priority":
+		value = int64(ticket.Priority)
+	case "source":
+		value = int64(ticket.Source)
+	case "ticket_type":
+		value = ticket.Type
+	case "group_id":
+		value = ticket.GroupID
+	case "product_id":
+		value = ticket.ProductID
+	case "company_id":
+		value = ticket.CompanyID
+	default:
+		fields, ok := ticket.CustomFields.(map[string]interface{})
+		if !strings.HasPrefix(condition.FieldName, "cf_") || !ok {
+			return false
+		}
+		if value, ok = fields[condition.FieldName]; !ok {
+			return false
+		}
+	}
+
+	found := false
+	for _, candidate := range condition.Value {
+		if conditionValue(candidate) == conditionValue(value) {
+			found = true
+			break
+		}
+	}
+
+	switch condition.Operator {
+	case "in", "is":
+		return found
+	case "not_in", "is_not":
+		return !found
+	}
+	return false
+}
+
+// conditionValue formats condition values for comparison. Numbers decoded
+// from JSON are float64 and must not turn into exponent notation.
+func conditionValue(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
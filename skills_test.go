@@ -0,0 +1,135 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decodeSkill decodes a skill like the API returns it, numbers in
+// condition values become float64.
+func decodeSkill(t *testing.T, data string) Skill {
+	t.Helper()
+	var skill Skill
+	if err := json.Unmarshal([]byte(data), &skill); err != nil {
+		t.Fatal(err)
+	}
+	return skill
+}
+
+func TestConditionValue(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{float64(3), "3"},
+		{float64(12000000000), "12000000000"},
+		{1.5, "1.5"},
+		{int64(12000000000), "12000000000"},
+		{uint64(7), "7"},
+		{"Incident", "Incident"},
+		{true, "true"},
+	}
+	for _, tc := range cases {
+		if got := conditionValue(tc.value); got != tc.want {
+			t.Errorf("conditionValue(%#v) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestSkillConditionMatches(t *testing.T) {
+	ticket := &Ticket{
+		Priority:     PriorityHigh,
+		Source:       SourcePhone,
+		Type:         "Incident",
+		GroupID:      12000000000,
+		ProductID:    5,
+		CompanyID:    9,
+		CustomFields: map[string]interface{}{"cf_region": "EMEA", "cf_seats": float64(50)},
+	}
+
+	cases := []struct {
+		condition string
+		want      bool
+	}{
+		{`{"field_name": "priority", "operator": "in", "value": [3, 4]}`, true},
+		{`{"field_name": "priority", "operator": "in", "value": [1, 2]}`, false},
+		{`{"field_name": "priority", "operator": "not_in", "value": [1, 2]}`, true},
+		{`{"field_name": "priority", "operator": "not_in", "value": [3]}`, false},
+		{`{"field_name": "source", "operator": "is", "value": [3]}`, true},
+		{`{"field_name": "source", "operator": "is_not", "value": [3]}`, false},
+		{`{"field_name": "ticket_type", "operator": "in", "value": ["Incident"]}`, true},
+		{`{"field_name": "group_id", "operator": "in", "value": [12000000000]}`, true},
+		{`{"field_name": "product_id", "operator": "in", "value": [5]}`, true},
+		{`{"field_name": "company_id", "operator": "in", "value": [8]}`, false},
+		{`{"resource_type": "ticket", "field_name": "cf_region", "operator": "in", "value": ["EMEA", "APAC"]}`, true},
+		{`{"field_name": "cf_seats", "operator": "in", "value": [50]}`, true},
+		{`{"field_name": "cf_missing", "operator": "not_in", "value": ["x"]}`, false},
+		{`{"field_name": "status", "operator": "in", "value": [2]}`, false},
+		{`{"resource_type": "contact", "field_name": "priority", "operator": "in", "value": [3]}`, false},
+		{`{"field_name": "priority", "operator": "greater_than", "value": [1]}`, false},
+	}
+	for _, tc := range cases {
+		var condition SkillCondition
+		if err := json.Unmarshal([]byte(tc.condition), &condition); err != nil {
+			t.Fatal(err)
+		}
+		if got := condition.matches(ticket); got != tc.want {
+			t.Errorf("%s: got %v", tc.condition, got)
+		}
+	}
+}
+
+func TestSkillMatches(t *testing.T) {
+	ticket := &Ticket{Priority: PriorityUrgent, Type: "Question"}
+	urgent := `{"field_name": "priority", "operator": "in", "value": [4]}`
+	incident := `{"field_name": "ticket_type", "operator": "in", "value": ["Incident"]}`
+
+	cases := []struct {
+		name  string
+		skill string
+		want  bool
+	}{
+		{"all matching", `{"match_type": "all", "conditions": [` + urgent + `]}`, true},
+		{"all with one failing", `{"match_type": "all", "conditions": [` + urgent + `, ` + incident + `]}`, false},
+		{"any with one matching", `{"match_type": "any", "conditions": [` + incident + `, ` + urgent + `]}`, true},
+		{"any with none matching", `{"match_type": "any", "conditions": [` + incident + `]}`, false},
+		{"all by default", `{"conditions": [` + urgent + `, ` + incident + `]}`, false},
+		{"no conditions", `{"match_type": "any", "conditions": []}`, false},
+	}
+	for _, tc := range cases {
+		skill := decodeSkill(t, tc.skill)
+		if got := skill.Matches(ticket); got != tc.want {
+			t.Errorf("%s: got %v", tc.name, got)
+		}
+	}
+}
+
+func TestMatchingSkills(t *testing.T) {
+	skills := []Skill{
+		decodeSkill(t, `{"id": 1, "rank": 3, "match_type": "any", "conditions": [{"field_name": "priority", "operator": "in", "value": [4]}]}`),
+		decodeSkill(t, `{"id": 2, "rank": 1, "match_type": "any", "conditions": [{"field_name": "priority", "operator": "in", "value": [1]}]}`),
+		decodeSkill(t, `{"id": 3, "rank": 2, "match_type": "all", "conditions": [{"field_name": "source", "operator": "not_in", "value": [3]}]}`),
+	}
+
+	var ids []uint64
+	for _, skill := range MatchingSkills(skills, &Ticket{Priority: PriorityUrgent, Source: SourceEmail}) {
+		ids = append(ids, skill.ID)
+	}
+	if want := []uint64{3, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got skills %v, want %v", ids, want)
+	}
+	if matching := MatchingSkills(skills, &Ticket{Priority: PriorityHigh, Source: SourcePhone}); len(matching) != 0 {
+		t.Errorf("got %+v", matching)
+	}
+}
+
+func TestSkillAgentIDs(t *testing.T) {
+	skill := decodeSkill(t, `{"agents": [{"id": 4}, {"id": 2}]}`)
+	if got := skill.AgentIDs(); !reflect.DeepEqual(got, []uint64{4, 2}) {
+		t.Errorf("got %v", got)
+	}
+	if got := (&Skill{}).AgentIDs(); got == nil || len(got) != 0 {
+		t.Errorf("got %#v without agents", got)
+	}
+}
@@ -0,0 +1,450 @@
+package freshdesk
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SLACalculator predicts the first response and resolution due times of a
+// ticket from SLA policies, business hours and groups fetched beforehand,
+// without calling the API.
+//
+// Policies are evaluated like Freshdesk does: active policies in position
+// order, the first one whose conditions all match the ticket wins, the
+// default policy applies otherwise. Contact and company segment
+// conditions cannot be evaluated offline, policies using them are
+// skipped. Timer pauses for statuses that stop the SLA clock are not
+// accounted for.
+type SLACalculator struct {
+	policies []SLAPolicy
+	hours    map[uint64]*BusinessHours
+	defHours *BusinessHours
+	groups   map[int64]Group
+}
+
+type SLAEstimate struct {
+	Policy        *SLAPolicy
+	Target        SLATarget
+	BusinessHours *BusinessHours // nil when the target runs on calendar hours
+
+	FirstResponseDue time.Time
+	ResolutionDue    time.Time
+
+	// Business time left until each due time at the moment passed to
+	// Estimate, negative once overdue.
+	FirstResponseRemaining time.Duration
+	ResolutionRemaining    time.Duration
+}
+
+func NewSLACalculator(policies []SLAPolicy, hours []BusinessHours, groups []Group) *SLACalculator {
+	calc := &SLACalculator{
+		hours:  map[uint64]*BusinessHours{},
+		groups: map[int64]Group{},
+	}
+
+	for _, policy := range policies {
+		if policy.Active || policy.IsDefault {
+			calc.policies = append(calc.policies, policy)
+		}
+	}
+	sort.SliceStable(calc.policies, func(i, j int) bool {
+		return calc.policies[i].Position < calc.policies[j].Position
+	})
+
+	for i := range hours {
+		calc.hours[hours[i].ID] = &hours[i]
+		if hours[i].IsDefault {
+			calc.defHours = &hours[i]
+		}
+	}
+	for _, group := range groups {
+		calc.groups[group.ID] = group
+	}
+	return calc
+}
+
+// PolicyFor returns the SLA policy applying to the ticket.
+func (calc *SLACalculator) PolicyFor(ticket *Ticket) (*SLAPolicy, error) {
+	var fallback *SLAPolicy
+	for i := range calc.policies {
+		policy := &calc.policies[i]
+		if policy.IsDefault {
+			if fallback == nil {
+				fallback = policy
+			}
+			continue
+		}
+		if policy.matches(ticket) {
+			return policy, nil
+		}
+	}
+	if fallback == nil {
+		return nil, errors.New("no SLA policy applies to the ticket")
+	}
+	return fallback, nil
+}
+
+// BusinessHoursFor returns the business hours of the ticket's group, or
+// the default business hours.
+func (calc *SLACalculator) BusinessHoursFor(ticket *Ticket) (*BusinessHours, error) {
+	if group, ok := calc.groups[ticket.GroupID]; ok && group.BusinessCalendar != 0 {
+		if hours, ok := calc.hours[group.BusinessCalendar]; ok {
+			return hours, nil
+		}
+	}
+	if calc.defHours == nil {
+		return nil, errors.New("no default business hours")
+	}
+	return calc.defHours, nil
+}
+
+// Estimate computes the due times of a ticket from its creation time and
+// the business time remaining at now.
+func (calc *SLACalculator) Estimate(ticket *Ticket, now time.Time) (*SLAEstimate, error) {
+	if ticket.CreatedAt == nil {
+		return nil, errors.New("ticket has no creation time")
+	}
+
+	policy, err := calc.PolicyFor(ticket)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := policy.Target(ticket.Priority)
+	if !ok {
+		return nil, fmt.Errorf("SLA policy %q has no target for priority %d", policy.Name, ticket.Priority)
+	}
+
+	estimate := &SLAEstimate{Policy: policy, Target: target}
+	respond := time.Duration(target.RespondWithin) * time.Second
+	resolve := time.Duration(target.ResolveWithin) * time.Second
+
+	if !target.BusinessHours {
+		estimate.FirstResponseDue = ticket.CreatedAt.Add(respond)
+		estimate.ResolutionDue = ticket.CreatedAt.Add(resolve)
+		estimate.FirstResponseRemaining = estimate.FirstResponseDue.Sub(now)
+		estimate.ResolutionRemaining = estimate.ResolutionDue.Sub(now)
+		return estimate, nil
+	}
+
+	hours, err := calc.BusinessHoursFor(ticket)
+	if err != nil {
+		return nil, err
+	}
+	estimate.BusinessHours = hours
+
+	if estimate.FirstResponseDue, err = hours.Add(*ticket.CreatedAt, respond); err != nil {
+		return nil, err
+	}
+	if estimate.ResolutionDue, err = hours.Add(*ticket.CreatedAt, resolve); err != nil {
+		return nil, err
+	}
+	if estimate.FirstResponseRemaining, err = hours.Between(now, estimate.FirstResponseDue); err != nil {
+		return nil, err
+	}
+	if estimate.ResolutionRemaining, err = hours.Between(now, estimate.ResolutionDue); err != nil {
+		return nil, err
+	}
+	return estimate, nil
+}
+
+// Target returns the SLA target for a ticket priority.
+func (policy *SLAPolicy) Target(priority Priority) (SLATarget, bool) {
+	target, ok := policy.SLATarget[fmt.Sprintf("priority_%d", priority)]
+	return target, ok
+}
+
+func (policy *SLAPolicy) matches(ticket *Ticket) bool {
+	conditions := policy.ApplicableTo
+	if len(conditions.ContactSegments) > 0 || len(conditions.CompanySegments) > 0 {
+		return false
+	}
+	if len(conditions.CompanyIDs) > 0 && !containsUint64(conditions.CompanyIDs, ticket.CompanyID) {
+		return false
+	}
+	if len(conditions.GroupIDs) > 0 && !containsInt64(conditions.GroupIDs, ticket.GroupID) {
+		return false
+	}
+	if len(conditions.ProductIDs) > 0 && !containsInt64(conditions.ProductIDs, ticket.ProductID) {
+		return false
+	}
+	if len(conditions.Sources) > 0 && !containsSource(conditions.Sources, ticket.Source) {
+		return false
+	}
+	if len(conditions.TicketTypes) > 0 && !containsString(conditions.TicketTypes, ticket.Type) {
+		return false
+	}
+	return true
+}
+
+func containsUint64(values []uint64, value uint64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSource(values []Source, value Source) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(values []int64, value int64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxCalendarDays bounds the day-by-day walk of Add so that a schedule
+// consisting only of holidays cannot loop forever. Between always ends at
+// its second argument and needs no bound.
+const maxCalendarDays = 3660
+
+type workingSpan struct {
+	start, end time.Duration // Offsets from midnight
+}
+
+type businessCalendar struct {
+	key       string // See calendarKey
+	location  *time.Location
+	days      [7]*workingSpan
+	holidays  map[string]bool // "2006-01-02"
+	recurring map[string]bool // "01-02"
+}
+
+// Add returns the time at which d of business time has elapsed after
+// start.
+func (hours *BusinessHours) Add(start time.Time, d time.Duration) (time.Time, error) {
+	calendar, err := hours.calendar()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t := start.In(calendar.location)
+	if d <= 0 {
+		return t, nil
+	}
+	for i := 0; i < maxCalendarDays; i++ {
+		if open, close, ok := calendar.window(t); ok {
+			if t.Before(open) {
+				t = open
+			}
+			if t.Before(close) {
+				available := close.Sub(t)
+				if d <= available {
+					return t.Add(d), nil
+				}
+				d -= available
+			}
+		}
+		t = nextMidnight(t)
+	}
+	return time.Time{}, fmt.Errorf("business hours %q have no working time within %d days", hours.Name, maxCalendarDays)
+}
+
+// Between returns the business time elapsing from a to b, negative when b
+// is before a.
+func (hours *BusinessHours) Between(a time.Time, b time.Time) (time.Duration, error) {
+	if b.Before(a) {
+		d, err := hours.Between(b, a)
+		return -d, err
+	}
+
+	calendar, err := hours.calendar()
+	if err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	t := a.In(calendar.location)
+	for t.Before(b) {
+		if open, close, ok := calendar.window(t); ok {
+			if t.After(open) {
+				open = t
+			}
+			if b.Before(close) {
+				close = b
+			}
+			if open.Before(close) {
+				total += close.Sub(open)
+			}
+		}
+		t = nextMidnight(t)
+	}
+	return total, nil
+}
+
+// IsOpen reports whether t falls within business hours.
+func (hours *BusinessHours) IsOpen(t time.Time) (bool, error) {
+	calendar, err := hours.calendar()
+	if err != nil {
+		return false, err
+	}
+	open, close, ok := calendar.window(t.In(calendar.location))
+	return ok && !t.Before(open) && t.Before(close), nil
+}
+
+// calendarMu guards the calendars cached in BusinessHours.
+var calendarMu sync.Mutex
+
+// calendar returns the parsed schedule. It is cached along with the
+// schedule it was built from and rebuilt whenever the time zone, working
+// days or holidays change, so copies of hours sharing the cache and
+// holidays filled in later are handled.
+func (hours *BusinessHours) calendar() (*businessCalendar, error) {
+	key := hours.calendarKey()
+	calendarMu.Lock()
+	defer calendarMu.Unlock()
+	if hours.parsed == nil || hours.parsed.key != key {
+		calendar, err := hours.parseCalendar()
+		if err != nil {
+			return nil, err
+		}
+		calendar.key = key
+		hours.parsed = calendar
+	}
+	return hours.parsed, nil
+}
+
+// calendarKey describes the fields the calendar is built from.
+func (hours *BusinessHours) calendarKey() string {
+	days := make([]string, 0, len(hours.BusinessHours))
+	for name, day := range hours.BusinessHours {
+		days = append(days, name+"="+day.StartTime+"-"+day.EndTime)
+	}
+	sort.Strings(days)
+
+	var key strings.Builder
+	key.WriteString(hours.TimeZone)
+	for _, day := range days {
+		key.WriteString("\n" + day)
+	}
+	for _, holiday := range hours.Holidays {
+		key.WriteString("\n" + holiday.Date)
+	}
+	return key.String()
+}
+
+func (hours *BusinessHours) parseCalendar() (*businessCalendar, error) {
+	location, err := LoadTimeZone(hours.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &businessCalendar{
+		location:  location,
+		holidays:  map[string]bool{},
+		recurring: map[string]bool{},
+	}
+
+	working := false
+	for name, day := range hours.BusinessHours {
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("business hours %q: unknown day %q", hours.Name, name)
+		}
+		start, err := parseClock(day.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("business hours %q: %w", hours.Name, err)
+		}
+		end, err := parseClock(day.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("business hours %q: %w", hours.Name, err)
+		}
+		// "11:59 pm" stands for the end of the day.
+		if end == 23*time.Hour+59*time.Minute {
+			end = 24 * time.Hour
+		}
+		if end <= start {
+			return nil, fmt.Errorf("business hours %q: %s ends before it starts", hours.Name, name)
+		}
+		calendar.days[weekday] = &workingSpan{start: start, end: end}
+		working = true
+	}
+	if !working {
+		return nil, fmt.Errorf("business hours %q have no working days", hours.Name)
+	}
+
+	for _, holiday := range hours.Holidays {
+		if date, err := time.Parse("2006-01-02", holiday.Date); err == nil {
+			calendar.holidays[date.Format("2006-01-02")] = true
+			continue
+		}
+		if date, err := time.Parse("Jan 02", holiday.Date); err == nil {
+			calendar.recurring[date.Format("01-02")] = true
+			continue
+		}
+		if date, err := time.Parse("Jan 2", holiday.Date); err == nil {
+			calendar.recurring[date.Format("01-02")] = true
+			continue
+		}
+		return nil, fmt.Errorf("business hours %q: cannot parse holiday date %q", hours.Name, holiday.Date)
+	}
+
+	return calendar, nil
+}
+
+// window returns the working interval of the day containing t, t must be
+// in the calendar location.
+func (calendar *businessCalendar) window(t time.Time) (time.Time, time.Time, bool) {
+	span := calendar.days[t.Weekday()]
+	if span == nil || calendar.holidays[t.Format("2006-01-02")] || calendar.recurring[t.Format("01-02")] {
+		return time.Time{}, time.Time{}, false
+	}
+	year, month, day := t.Date()
+	return clockOn(year, month, day, span.start, calendar.location),
+		clockOn(year, month, day, span.end, calendar.location), true
+}
+
+// clockOn returns the wall clock time offset from midnight of the given
+// day, so that DST changes do not shift working hours.
+func clockOn(year int, month time.Month, day int, offset time.Duration, location *time.Location) time.Time {
+	minutes := int(offset / time.Minute)
+	return time.Date(year, month, day, minutes/60, minutes%60, 0, 0, location)
+}
+
+func nextMidnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses "8:00 am", "08:00 AM" or "17:30".
+func parseClock(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, layout := range []string{"3:04 pm", "3:04pm", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse time of day %q", value)
+}
@@ -0,0 +1,377 @@
+package freshdesk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// weekdays returns business hours open from start to end on every listed
+// day, Monday to Friday when none is.
+func weekdays(timeZone, start, end string, days ...string) BusinessHours {
+	if len(days) == 0 {
+		days = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	}
+	hours := BusinessHours{Name: "test", TimeZone: timeZone, BusinessHours: map[string]WorkingDay{}}
+	for _, day := range days {
+		hours.BusinessHours[day] = WorkingDay{StartTime: start, EndTime: end}
+	}
+	return hours
+}
+
+var everyDay = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+func at(t *testing.T, value string, timeZone string) time.Time {
+	t.Helper()
+	location, err := LoadTimeZone(timeZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestBusinessHoursAdd(t *testing.T) {
+	overnight := weekdays("UTC", "10:00 pm", "11:59 pm", "monday")
+	overnight.BusinessHours["tuesday"] = WorkingDay{StartTime: "12:00 am", EndTime: "6:00 am"}
+
+	withHolidays := weekdays("UTC", "9:00 am", "5:00 pm")
+	withHolidays.Holidays = []Holiday{{Name: "Closed", Date: "2024-01-09"}, {Name: "Christmas", Date: "Dec 25"}}
+
+	cases := []struct {
+		name  string
+		hours BusinessHours
+		start string
+		add   time.Duration
+		want  string
+	}{
+		{"within the day", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 10:00", 2 * time.Hour, "2024-01-08 12:00"},
+		{"nothing to add", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-06 10:00", 0, "2024-01-06 10:00"},
+		{"before opening", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 07:00", time.Hour, "2024-01-08 10:00"},
+		{"until closing", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 16:00", time.Hour, "2024-01-08 17:00"},
+		{"next day", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 16:00", 2 * time.Hour, "2024-01-09 10:00"},
+		{"over the weekend", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-12 16:00", 2 * time.Hour, "2024-01-15 10:00"},
+		{"dated holiday", withHolidays, "2024-01-08 16:00", 2 * time.Hour, "2024-01-10 10:00"},
+		{"recurring holiday", withHolidays, "2024-12-24 16:00", 2 * time.Hour, "2024-12-26 10:00"},
+		{"end of day", weekdays("UTC", "9:00 am", "11:59 pm"), "2024-01-08 23:00", 2 * time.Hour, "2024-01-09 10:00"},
+		{"overnight shift", overnight, "2024-01-08 22:30", 3 * time.Hour, "2024-01-09 01:30"},
+	}
+	for _, tc := range cases {
+		hours := tc.hours
+		got, err := hours.Add(at(t, tc.start, "UTC"), tc.add)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if want := at(t, tc.want, "UTC"); !got.Equal(want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, want)
+		}
+	}
+}
+
+func TestBusinessHoursAddTimeZones(t *testing.T) {
+	cases := []struct {
+		name     string
+		hours    BusinessHours
+		start    string // In the time zone of the hours
+		add      time.Duration
+		want     string // Idem
+		wantUTC  string
+		timeZone string
+	}{
+		{"rails name", weekdays("Eastern Time (US & Canada)", "9:00 am", "5:00 pm"),
+			"2024-01-08 08:00", time.Hour, "2024-01-08 10:00", "2024-01-08 15:00", "America/New_York"},
+		{"spring forward", weekdays("America/New_York", "9:00 am", "5:00 pm", everyDay...),
+			"2024-03-09 16:00", 2 * time.Hour, "2024-03-10 10:00", "2024-03-10 14:00", "America/New_York"},
+		{"fall back", weekdays("America/New_York", "9:00 am", "5:00 pm", everyDay...),
+			"2024-11-02 16:00", 2 * time.Hour, "2024-11-03 10:00", "2024-11-03 15:00", "America/New_York"},
+		{"ahead of UTC", weekdays("Asia/Kolkata", "9:00 am", "6:00 pm"),
+			"2024-01-08 17:30", time.Hour, "2024-01-09 09:30", "2024-01-09 04:00", "Asia/Kolkata"},
+	}
+	for _, tc := range cases {
+		hours := tc.hours
+		got, err := hours.Add(at(t, tc.start, tc.timeZone), tc.add)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if want := at(t, tc.want, tc.timeZone); !got.Equal(want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, want)
+		}
+		if got := got.UTC().Format("2006-01-02 15:04"); got != tc.wantUTC {
+			t.Errorf("%s: got %s UTC, want %s", tc.name, got, tc.wantUTC)
+		}
+	}
+}
+
+func TestBusinessHoursBetween(t *testing.T) {
+	withHoliday := weekdays("UTC", "9:00 am", "5:00 pm")
+	withHoliday.Holidays = []Holiday{{Name: "Closed", Date: "2024-01-15"}}
+
+	cases := []struct {
+		name  string
+		hours BusinessHours
+		a, b  string
+		want  time.Duration
+	}{
+		{"same day", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 10:00", "2024-01-08 12:30", 150 * time.Minute},
+		{"outside hours", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 18:00", "2024-01-09 08:00", 0},
+		{"over the weekend", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-12 16:00", "2024-01-15 10:00", 2 * time.Hour},
+		{"holiday", withHoliday, "2024-01-12 16:00", "2024-01-16 10:00", 2 * time.Hour},
+		{"backwards", weekdays("UTC", "9:00 am", "5:00 pm"), "2024-01-08 12:00", "2024-01-08 10:00", -2 * time.Hour},
+		{"spring forward", weekdays("America/New_York", "12:00 am", "11:59 pm", everyDay...), "2024-03-10 00:00", "2024-03-11 00:00", 23 * time.Hour},
+	}
+	for _, tc := range cases {
+		hours := tc.hours
+		location := hours.TimeZone
+		got, err := hours.Between(at(t, tc.a, location), at(t, tc.b, location))
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBusinessHoursIsOpen(t *testing.T) {
+	hours := weekdays("Europe/Paris", "9:00 am", "5:00 pm")
+	hours.Holidays = []Holiday{{Name: "Bastille Day", Date: "Jul 14"}}
+
+	cases := []struct {
+		at   string // UTC
+		want bool
+	}{
+		{"2024-01-08 08:00", true},  // 9:00 in Paris
+		{"2024-01-08 07:59", false}, // 8:59
+		{"2024-01-08 16:00", false}, // 17:00, closing time
+		{"2024-01-06 12:00", false}, // Saturday
+		{"2025-07-14 12:00", false}, // Monday, holiday
+		{"2025-07-15 12:00", true},
+	}
+	for _, tc := range cases {
+		got, err := hours.IsOpen(at(t, tc.at, "UTC"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("IsOpen(%s) = %v", tc.at, got)
+		}
+	}
+}
+
+func TestBusinessHoursErrors(t *testing.T) {
+	badHoliday := weekdays("UTC", "9:00 am", "5:00 pm")
+	badHoliday.Holidays = []Holiday{{Name: "Someday", Date: "soon"}}
+
+	cases := []struct {
+		name  string
+		hours BusinessHours
+	}{
+		{"window across midnight", weekdays("UTC", "10:00 pm", "6:00 am")},
+		{"unknown day", weekdays("UTC", "9:00 am", "5:00 pm", "caturday")},
+		{"unknown time zone", weekdays("Atlantis", "9:00 am", "5:00 pm")},
+		{"bad time of day", weekdays("UTC", "nine", "5:00 pm")},
+		{"bad holiday", badHoliday},
+		{"no working day", BusinessHours{Name: "test", TimeZone: "UTC"}},
+	}
+	for _, tc := range cases {
+		hours := tc.hours
+		if _, err := hours.Add(time.Now(), time.Hour); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestBusinessHoursCalendarCached(t *testing.T) {
+	hours := weekdays("UTC", "9:00 am", "5:00 pm")
+	first, err := hours.calendar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := hours.calendar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("calendar parsed twice")
+	}
+}
+
+func TestBusinessHoursHolidaysFilledInLater(t *testing.T) {
+	hours := weekdays("UTC", "9:00 am", "5:00 pm")
+	if open, err := hours.IsOpen(at(t, "2024-12-25 12:00", "UTC")); err != nil || !open {
+		t.Fatalf("IsOpen = %v, %v", open, err)
+	}
+
+	// A copy shares the cached calendar but not the holidays.
+	closed := hours
+	closed.Holidays = append(closed.Holidays, Holiday{Name: "Christmas", Date: "Dec 25"})
+	if open, err := closed.IsOpen(at(t, "2024-12-25 12:00", "UTC")); err != nil || open {
+		t.Errorf("IsOpen with the holiday = %v, %v", open, err)
+	}
+	if open, err := hours.IsOpen(at(t, "2024-12-25 12:00", "UTC")); err != nil || !open {
+		t.Errorf("IsOpen of the original = %v, %v", open, err)
+	}
+}
+
+// slaPolicy returns a policy with the same targets for every priority.
+func slaPolicy(ID uint64, position int64, respond, resolve time.Duration, business bool) SLAPolicy {
+	policy := SLAPolicy{ID: ID, Name: "policy", Active: true, Position: position, SLATarget: map[string]SLATarget{}}
+	for priority := PriorityLow; priority <= PriorityUrgent; priority++ {
+		policy.SLATarget[fmt.Sprintf("priority_%d", priority)] = SLATarget{
+			RespondWithin: int64(respond / time.Second),
+			ResolveWithin: int64(resolve / time.Second),
+			BusinessHours: business,
+		}
+	}
+	return policy
+}
+
+func TestSLACalculatorPolicyFor(t *testing.T) {
+	def := slaPolicy(1, 1, time.Hour, time.Hour, false)
+	def.IsDefault = true
+	def.Active = false
+
+	vip := slaPolicy(2, 3, time.Hour, time.Hour, false)
+	vip.ApplicableTo.CompanyIDs = []uint64{10}
+
+	support := slaPolicy(3, 2, time.Hour, time.Hour, false)
+	support.ApplicableTo.GroupIDs = []int64{20}
+
+	phone := slaPolicy(4, 4, time.Hour, time.Hour, false)
+	phone.ApplicableTo.Sources = []Source{SourcePhone}
+	phone.ApplicableTo.TicketTypes = []string{"Incident"}
+
+	segment := slaPolicy(5, 0, time.Hour, time.Hour, false)
+	segment.ApplicableTo.ContactSegments = []uint64{1}
+
+	inactive := slaPolicy(6, 0, time.Hour, time.Hour, false)
+	inactive.Active = false
+
+	calc := NewSLACalculator([]SLAPolicy{def, vip, support, phone, segment, inactive}, nil, nil)
+
+	cases := []struct {
+		name   string
+		ticket Ticket
+		want   uint64
+	}{
+		{"default", Ticket{}, 1},
+		{"company", Ticket{CompanyID: 10}, 2},
+		{"group", Ticket{GroupID: 20}, 3},
+		{"lower position first", Ticket{CompanyID: 10, GroupID: 20}, 3},
+		{"all conditions", Ticket{Source: SourcePhone, Type: "incident"}, 4},
+		{"one condition", Ticket{Source: SourcePhone, Type: "Question"}, 1},
+	}
+	for _, tc := range cases {
+		ticket := tc.ticket
+		policy, err := calc.PolicyFor(&ticket)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if policy.ID != tc.want {
+			t.Errorf("%s: got policy %d, want %d", tc.name, policy.ID, tc.want)
+		}
+	}
+
+	if _, err := NewSLACalculator([]SLAPolicy{vip}, nil, nil).PolicyFor(&Ticket{}); err == nil {
+		t.Error("expected an error without a default policy")
+	}
+}
+
+func TestSLACalculatorBusinessHoursFor(t *testing.T) {
+	def := weekdays("UTC", "9:00 am", "5:00 pm")
+	def.ID, def.IsDefault = 1, true
+	night := weekdays("UTC", "6:00 pm", "11:00 pm")
+	night.ID = 2
+
+	calc := NewSLACalculator(nil, []BusinessHours{def, night}, []Group{{ID: 20, BusinessCalendar: 2}, {ID: 21, BusinessCalendar: 9}})
+	for group, want := range map[int64]uint64{0: 1, 20: 2, 21: 1} {
+		hours, err := calc.BusinessHoursFor(&Ticket{GroupID: group})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hours.ID != want {
+			t.Errorf("group %d: got business hours %d, want %d", group, hours.ID, want)
+		}
+	}
+
+	if _, err := NewSLACalculator(nil, []BusinessHours{night}, nil).BusinessHoursFor(&Ticket{}); err == nil {
+		t.Error("expected an error without default business hours")
+	}
+}
+
+func TestSLACalculatorEstimate(t *testing.T) {
+	hours := weekdays("UTC", "9:00 am", "5:00 pm")
+	hours.ID, hours.IsDefault = 1, true
+
+	calendar := slaPolicy(1, 1, 4*time.Hour, 24*time.Hour, false)
+	calendar.IsDefault = true
+	business := slaPolicy(2, 2, 4*time.Hour, 16*time.Hour, true)
+	business.ApplicableTo.GroupIDs = []int64{20}
+
+	calc := NewSLACalculator([]SLAPolicy{calendar, business}, []BusinessHours{hours}, nil)
+
+	cases := []struct {
+		name          string
+		group         int64
+		created, now  string
+		respond       string
+		resolve       string
+		respondLeft   time.Duration
+		resolveLeft   time.Duration
+		businessHours bool
+	}{
+		{"calendar hours", 0, "2024-01-12 16:00", "2024-01-12 17:00", "2024-01-12 20:00", "2024-01-13 16:00", 3 * time.Hour, 23 * time.Hour, false},
+		{"business hours", 20, "2024-01-12 16:00", "2024-01-12 17:00", "2024-01-15 12:00", "2024-01-16 16:00", 3 * time.Hour, 15 * time.Hour, true},
+		{"before opening", 20, "2024-01-12 16:00", "2024-01-15 08:00", "2024-01-15 12:00", "2024-01-16 16:00", 3 * time.Hour, 15 * time.Hour, true},
+		{"calendar breach", 0, "2024-01-12 16:00", "2024-01-13 18:00", "2024-01-12 20:00", "2024-01-13 16:00", -22 * time.Hour, -2 * time.Hour, false},
+		{"business breach", 20, "2024-01-12 16:00", "2024-01-17 10:00", "2024-01-15 12:00", "2024-01-16 16:00", -14 * time.Hour, -2 * time.Hour, true},
+		{"years old", 20, "2004-01-12 09:00", "2024-01-12 17:00", "2004-01-12 13:00", "2004-01-13 17:00", -41756 * time.Hour, -41744 * time.Hour, true},
+	}
+	for _, tc := range cases {
+		created := at(t, tc.created, "UTC")
+		estimate, err := calc.Estimate(&Ticket{GroupID: tc.group, Priority: PriorityHigh, CreatedAt: &created}, at(t, tc.now, "UTC"))
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !estimate.FirstResponseDue.Equal(at(t, tc.respond, "UTC")) || !estimate.ResolutionDue.Equal(at(t, tc.resolve, "UTC")) {
+			t.Errorf("%s: due %v and %v", tc.name, estimate.FirstResponseDue, estimate.ResolutionDue)
+		}
+		if estimate.FirstResponseRemaining != tc.respondLeft || estimate.ResolutionRemaining != tc.resolveLeft {
+			t.Errorf("%s: remaining %v and %v", tc.name, estimate.FirstResponseRemaining, estimate.ResolutionRemaining)
+		}
+		if (estimate.BusinessHours != nil) != tc.businessHours {
+			t.Errorf("%s: business hours %v", tc.name, estimate.BusinessHours)
+		}
+	}
+}
+
+func TestSLACalculatorEstimateErrors(t *testing.T) {
+	def := slaPolicy(1, 1, time.Hour, time.Hour, true)
+	def.IsDefault = true
+	delete(def.SLATarget, "priority_4")
+	calc := NewSLACalculator([]SLAPolicy{def}, nil, nil)
+
+	created := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		ticket Ticket
+	}{
+		{"no creation time", Ticket{Priority: PriorityLow}},
+		{"no target", Ticket{Priority: PriorityUrgent, CreatedAt: &created}},
+		{"no business hours", Ticket{Priority: PriorityLow, CreatedAt: &created}},
+	}
+	for _, tc := range cases {
+		ticket := tc.ticket
+		if _, err := calc.Estimate(&ticket, created); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateSdTicketUnwrapsTicket(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"ticket":{"id":7,"subject":"Printer on fire"}}`)
+
+	ticket, err := stub.client().CreateSdTicket(SdTicketCreatePayload{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 7 || ticket.Subject != "Printer on fire" {
+		t.Errorf("got ticket %d %q", ticket.ID, ticket.Subject)
+	}
+}
+
+func TestCreateTicketReadsPlainTicket(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"id":7,"subject":"Printer on fire"}`)
+
+	ticket, err := stub.client().CreateTicket(TicketCreatePayload{Subject: "Printer on fire"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 7 || ticket.Subject != "Printer on fire" {
+		t.Errorf("got ticket %d %q", ticket.ID, ticket.Subject)
+	}
+
+	var sent map[string]interface{}
+	if err := json.Unmarshal(stub.received()[0].Body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent["subject"] != "Printer on fire" {
+		t.Errorf("sent %v", sent)
+	}
+}
+
+func TestCreateTicketShapesDoNotMix(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"id":7}`)
+	if ticket, err := stub.client().CreateSdTicket(SdTicketCreatePayload{}); err != nil || ticket.ID != 0 {
+		t.Errorf("Sd ticket from a plain answer: %+v, %v", ticket, err)
+	}
+
+	stub = newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"ticket":{"id":7}}`)
+	if ticket, err := stub.client().CreateTicket(TicketCreatePayload{}); err != nil || ticket.ID != 0 {
+		t.Errorf("plain ticket from an Sd answer: %+v, %v", ticket, err)
+	}
+}
+
+func TestCreateSdTicketEmptyError(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 500, "")
+
+	_, err := stub.client().CreateSdTicket(SdTicketCreatePayload{})
+	if err == nil || err.Error() != "Invalid status received: 500" {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestCreateTicketRejectsOK(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 200, `{"id":7}`)
+
+	if _, err := stub.client().CreateTicket(TicketCreatePayload{}); err == nil {
+		t.Error("expected an error for a 200 answer")
+	}
+}
+
+func TestCreateTicketMessageShapes(t *testing.T) {
+	for _, status := range []int{200, 201} {
+		stub := newStubServer(t)
+		stub.reply("POST", "/api/v2/tickets/7/reply", status, `{"conversation":{"id":3,"body":"sd"}}`)
+		message, err := stub.client().CreateSdTicketMessage(7, TicketMessageCreatePayload{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message.Body != "sd" {
+			t.Errorf("status %d: Sd message body %q", status, message.Body)
+		}
+
+		stub = newStubServer(t)
+		stub.reply("POST", "/api/v2/tickets/7/reply", status, `{"id":3,"body":"plain"}`)
+		message, err = stub.client().CreateTicketMessage(7, TicketMessageCreatePayload{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message.Body != "plain" {
+			t.Errorf("status %d: message body %q", status, message.Body)
+		}
+	}
+}
+
+func TestGetTicketIncludesConversations(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/tickets/7", 200, `{"id":7,"conversations":[{"id":1,"body":"first"},{"id":2,"body":"second"}]}`)
+	service := stub.client()
+
+	ticket, err := service.GetTicketWithConversations(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticket.Conversations) != 2 || ticket.Conversations[1].Body != "second" {
+		t.Errorf("got conversations %+v", ticket.Conversations)
+	}
+	if got := stub.received()[0].Query.Get("include"); got != "conversations" {
+		t.Errorf("include = %q", got)
+	}
+
+	if _, err := service.GetTicket(7); err != nil {
+		t.Fatal(err)
+	}
+	if query := stub.received()[1].Query; len(query) != 0 {
+		t.Errorf("GetTicket sent query %v", query)
+	}
+}
+
+func TestGetAllTicketConversationsPages(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets/7/conversations",
+		stubResponse{Status: 200, Body: `[{"id":1},{"id":2}]`, Header: stub.link("/api/v2/tickets/7/conversations?per_page=100&page=2")},
+		stubResponse{Status: 200, Body: `[{"id":3}]`},
+	)
+
+	messages, err := stub.client().GetAllTicketConversations(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 3 {
+		t.Errorf("got %d conversations", len(messages))
+	}
+	requests := stub.received()
+	if len(requests) != 2 || requests[1].Query.Get("page") != "2" {
+		t.Errorf("requests %+v", requests)
+	}
+}
+
+func TestGetAllTicketConversationsStopsOnError(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets/7/conversations",
+		stubResponse{Status: 200, Body: `[{"id":1}]`, Header: stub.link("/api/v2/tickets/7/conversations?page=2")},
+		stubResponse{Status: 500, Body: `{"message":"internal error"}`},
+	)
+
+	messages, err := stub.client().GetAllTicketConversations(7)
+	if err == nil || !strings.Contains(err.Error(), "internal error") || messages != nil {
+		t.Errorf("got %v, %v", messages, err)
+	}
+}
+
+func TestListTicketsQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/tickets", 200, `[{"id":1}]`)
+	since := time.Date(2023, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+
+	tickets, err, hasMore := stub.client().ListTickets(&since, 50, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || hasMore {
+		t.Errorf("got %d tickets, hasMore %v", len(tickets), hasMore)
+	}
+
+	query := stub.received()[0].Query
+	want := map[string]string{
+		"per_page":      "50",
+		"page":          "3",
+		"order_by":      "updated_at",
+		"order_type":    "asc",
+		"updated_since": "2023-05-01T10:00:00Z",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if query.Has("filter") {
+		t.Errorf("unexpected filter %q", query.Get("filter"))
+	}
+}
+
+func TestListTicketsWithoutSince(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("GET", "/api/v2/tickets", 200, `[]`)
+
+	if _, err, _ := stub.client().ListTickets(nil, 30, 1); err != nil {
+		t.Fatal(err)
+	}
+	if stub.received()[0].Query.Has("updated_since") {
+		t.Error("updated_since sent without a time")
+	}
+}
+
+func TestListDeletedTicketsQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets", stubResponse{Status: 200, Body: `[{"id":1}]`, Header: stub.link("/api/v2/tickets?page=2")})
+
+	_, err, hasMore := stub.client().ListDeletedTickets(nil, 30, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasMore {
+		t.Error("hasMore not set with a Link header")
+	}
+	if got := stub.received()[0].Query.Get("filter"); got != "deleted" {
+		t.Errorf("filter = %q", got)
+	}
+}
+
+func TestGetTicketsByCompanyIDQuery(t *testing.T) {
+	stub := newStubServer(t)
+	stub.on("GET", "/api/v2/tickets", stubResponse{Status: 200, Body: `[{"id":1}]`, Header: stub.link("/api/v2/tickets?company_id=9&page=3")})
+
+	tickets, err, hasMore := stub.client().GetTicketsByCompanyID(9, 20, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || !hasMore {
+		t.Errorf("got %d tickets, hasMore %v", len(tickets), hasMore)
+	}
+	query := stub.received()[0].Query
+	if query.Get("company_id") != "9" || query.Get("per_page") != "20" || query.Get("page") != "2" {
+		t.Errorf("query %v", query)
+	}
+}
+
+func TestCreateTicketWithAttachments(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "invoice-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("amount due: 42")
+	file.Close()
+
+	for _, sd := range []bool{false, true} {
+		stub := newStubServer(t)
+		if sd {
+			stub.reply("POST", "/api/v2/tickets", 201, `{"ticket":{"id":7}}`)
+		} else {
+			stub.reply("POST", "/api/v2/tickets", 201, `{"id":7}`)
+		}
+		stub.reply("PUT", "/api/v2/tickets/7", 200, `{"id":7}`)
+		stub.reply("GET", "/api/v2/tickets/7", 200, `{"id":7,"attachments":[{"name":"invoice.txt"}]}`)
+
+		files := []Attachment{{FileName: "invoice.txt", FileType: "text/plain", FileData: file}}
+		var ticket *Ticket
+		if sd {
+			ticket, err = stub.client().CreateSdTicketWithAttachments(SdTicketCreatePayload{}, files)
+		} else {
+			ticket, err = stub.client().CreateTicketWithAttachments(TicketCreatePayload{}, files)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ticket.Attachments) != 1 {
+			t.Errorf("sd %v: ticket not read back, attachments %v", sd, ticket.Attachments)
+		}
+
+		requests := stub.received()
+		if len(requests) != 3 {
+			t.Fatalf("sd %v: sent %d requests", sd, len(requests))
+		}
+		upload := requests[1]
+		if upload.Method != "PUT" || !strings.HasPrefix(upload.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Errorf("sd %v: upload sent as %s %s", sd, upload.Method, upload.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(string(upload.Body), `name="attachments[]"`) || !strings.Contains(string(upload.Body), "amount due: 42") {
+			t.Errorf("sd %v: upload body %q", sd, upload.Body)
+		}
+	}
+}
+
+func TestCreateTicketWithAttachmentsCreateFails(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 400, invalid)
+
+	if _, err := stub.client().CreateTicketWithAttachments(TicketCreatePayload{}, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(stub.received()) != 1 {
+		t.Errorf("sent %d requests after a failed create", len(stub.received()))
+	}
+}
+
+func TestDeleteTicketExpectsNoContent(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("DELETE", "/api/v2/tickets/7", 200, `{"id":7}`)
+
+	if _, err := stub.client().DeleteTicket(7); err == nil {
+		t.Error("expected an error for a 200 answer")
+	}
+}
+
+func TestExecuteScenarioAutomationBody(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/tickets/7/execute_scenario", 200, `{"id":7}`)
+
+	if _, err := stub.client().ExecuteScenarioAutomation(7, 11); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(stub.received()[0].Body); body != `{"scenario_id":11}` {
+		t.Errorf("sent %s", body)
+	}
+}
+
+func TestWatcherBodies(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets/7/watch", 204, "")
+	stub.reply("PUT", "/api/v2/tickets/7/unwatch", 204, "")
+	client := stub.client()
+
+	if _, err := client.AddWatcher(7, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddWatcher(7, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RemoveWatcher(7, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := stub.received()
+	for i, want := range []string{`{"user_id":3}`, `{}`, `{"user_id":3}`} {
+		if body := string(requests[i].Body); body != want {
+			t.Errorf("request %d sent %s, want %s", i, body, want)
+		}
+	}
+}
+
+func TestCreateChildTicket(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("POST", "/api/v2/tickets", 201, `{"id":8,"association_type":2,"associated_tickets_list":[7]}`)
+
+	ticket, err := stub.client().CreateChildTicket(7, TicketCreatePayload{Subject: "Network team", Email: "ops@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.AssociationType != AssociationChild || len(ticket.AssociatedTicketsList) != 1 || ticket.AssociatedTicketsList[0] != 7 {
+		t.Errorf("got %+v", ticket)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(stub.received()[0].Body, &sent)
+	if sent["parent_id"] != 7.0 || sent["subject"] != "Network team" {
+		t.Errorf("sent %v", sent)
+	}
+}
+
+func TestLinkTicketBody(t *testing.T) {
+	stub := newStubServer(t)
+	stub.reply("PUT", "/api/v2/tickets/7/link", 204, "")
+	stub.reply("PUT", "/api/v2/tickets/7/unlink", 204, "")
+
+	if _, err := stub.client().LinkTicket(7, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stub.client().UnlinkTicket(7, 3); err != nil {
+		t.Fatal(err)
+	}
+	for _, request := range stub.received() {
+		if body := string(request.Body); body != `{"tracker_id":3}` {
+			t.Errorf("%s sent %s", request.Path, body)
+		}
+	}
+}
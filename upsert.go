@@ -0,0 +1,183 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// UpsertContact finds the contact by email, phone or unique external ID,
+// taken from the payload, creates it when missing and otherwise updates
+// it. The update is skipped when the contact already has every value of
+// the payload.
+func (service *freshDeskService) UpsertContact(key UpsertKey, payload ContactCreatePayload) (*Contact, UpsertResult, error) {
+	var existing Contact
+	var err error
+	switch key {
+	case UpsertByEmail:
+		if payload.Email == "" {
+			return nil, "", errors.New(ERR_UPSERT_KEY_MISSING)
+		}
+		existing, err = service.FindContactByEmail(payload.Email)
+	case UpsertByPhone:
+		if payload.Phone == "" {
+			return nil, "", errors.New(ERR_UPSERT_KEY_MISSING)
+		}
+		existing, err = service.FindContactByPhone(payload.Phone)
+	case UpsertByExternalID:
+		if payload.UniqueExternalID == "" {
+			return nil, "", errors.New(ERR_UPSERT_KEY_MISSING)
+		}
+		existing, err = service.FindContactByExternalID(payload.UniqueExternalID)
+	default:
+		return nil, "", fmt.Errorf("contacts cannot be upserted by %v", key)
+	}
+
+	if err != nil && err.Error() == ERR_CONTACT_NOT_FOUND {
+		contact, err := service.CreateContact(payload)
+		if err != nil {
+			return nil, "", err
+		}
+		return contact, UpsertCreated, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if unchanged(payload, existing) {
+		return &existing, UpsertUnchanged, nil
+	}
+	contact, err := service.UpdateContact(existing.ID, ContactUpdatePayload(payload))
+	if err != nil {
+		return nil, "", err
+	}
+	return contact, UpsertUpdated, nil
+}
+
+// UpsertCompany finds the company by name or by one of its domains, taken
+// from the payload, creates it when missing and otherwise updates it. The
+// update is skipped when the company already has every value of the
+// payload.
+func (service *freshDeskService) UpsertCompany(key UpsertKey, payload CompanyCreatePayload) (*Company, UpsertResult, error) {
+	var existing *Company
+	var err error
+	switch key {
+	case UpsertByName:
+		if payload.Name == "" {
+			return nil, "", errors.New(ERR_UPSERT_KEY_MISSING)
+		}
+		existing, err = service.GetCompanyByName(payload.Name)
+	case UpsertByDomain:
+		if len(payload.Domains) == 0 {
+			return nil, "", errors.New(ERR_UPSERT_KEY_MISSING)
+		}
+		err = errors.New(ERR_COMPANY_NOT_FOUND)
+		for _, domain := range payload.Domains {
+			existing, err = service.GetCompanyByDomain(domain)
+			if err == nil || err.Error() != ERR_COMPANY_NOT_FOUND {
+				break
+			}
+		}
+	default:
+		return nil, "", fmt.Errorf("companies cannot be upserted by %v", key)
+	}
+
+	if err != nil && err.Error() == ERR_COMPANY_NOT_FOUND {
+		company, err := service.CreateCompany(payload)
+		if err != nil {
+			return nil, "", err
+		}
+		return company, UpsertCreated, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if unchanged(payload, existing) {
+		return existing, UpsertUnchanged, nil
+	}
+	company, err := service.UpdateCompany(existing.ID, CompanyUpdatePayload(payload))
+	if err != nil {
+		return nil, "", err
+	}
+	return company, UpsertUpdated, nil
+}
+
+// unchanged reports whether the record holds every value set in the
+// payload, comparing their JSON encodings.
+func unchanged(payload interface{}, record interface{}) bool {
+	var want, have interface{}
+	if !jsonValue(payload, &want) || !jsonValue(record, &have) {
+		return false
+	}
+	return jsonSubset(want, have)
+}
+
+func jsonValue(v interface{}, out *interface{}) bool {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
+
+// jsonSubset compares decoded JSON values. Objects match when have holds
+// every member of want, lists when they hold the same items in any order,
+// and strings when they are equal or denote the same time.
+func jsonSubset(want, have interface{}) bool {
+	switch want := want.(type) {
+	case map[string]interface{}:
+		have, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for name, value := range want {
+			if !jsonSubset(value, have[name]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		have, ok := have.([]interface{})
+		if !ok || len(want) != len(have) {
+			return false
+		}
+		return reflect.DeepEqual(sortedJSON(want), sortedJSON(have))
+	case string:
+		have, ok := have.(string)
+		if !ok {
+			return false
+		}
+		return want == have || sameTime(want, have)
+	}
+	return reflect.DeepEqual(want, have)
+}
+
+func sortedJSON(items []interface{}) []string {
+	sorted := make([]string, len(items))
+	for i, item := range items {
+		data, _ := json.Marshal(item)
+		sorted[i] = string(data)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// sameTime matches dates sent as 2006-01-02 with the timestamps returned
+// by the API.
+func sameTime(a, b string) bool {
+	parse := func(s string) (time.Time, bool) {
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+	ta, okA := parse(a)
+	tb, okB := parse(b)
+	return okA && okB && ta.Equal(tb)
+}
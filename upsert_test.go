@@ -0,0 +1,184 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUpsertContact(t *testing.T) {
+	payload := ContactCreatePayload{Name: "Jane", Email: "jane@example.com", Tags: []string{"vip", "beta"}}
+
+	t.Run("created", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/contacts", 200, `{"total":0,"results":[]}`)
+		stub.reply("POST", "/api/v2/contacts", 201, `{"id":7,"name":"Jane"}`)
+
+		contact, result, err := stub.client().UpsertContact(UpsertByEmail, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertCreated || contact.ID != 7 {
+			t.Errorf("got %v %d", result, contact.ID)
+		}
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/contacts", 200, `{"total":1,"results":[{"id":7,"name":"Janet","email":"jane@example.com","tags":["vip","beta"]}]}`)
+		stub.reply("PUT", "/api/v2/contacts/7", 200, `{"id":7,"name":"Jane"}`)
+
+		contact, result, err := stub.client().UpsertContact(UpsertByEmail, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertUpdated || contact.Name != "Jane" {
+			t.Errorf("got %v %q", result, contact.Name)
+		}
+		var sent map[string]interface{}
+		json.Unmarshal(stub.received()[1].Body, &sent)
+		if sent["name"] != "Jane" {
+			t.Errorf("sent %v", sent)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/contacts", 200, `{"total":1,"results":[{"id":7,"name":"Jane","email":"jane@example.com","tags":["beta","vip"],"phone":"+15550100"}]}`)
+
+		contact, result, err := stub.client().UpsertContact(UpsertByEmail, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertUnchanged || contact.ID != 7 {
+			t.Errorf("got %v %d", result, contact.ID)
+		}
+		if len(stub.received()) != 1 {
+			t.Errorf("sent %d requests", len(stub.received()))
+		}
+	})
+
+	t.Run("by phone and external id", func(t *testing.T) {
+		for key, filter := range map[UpsertKey]string{UpsertByPhone: "phone", UpsertByExternalID: "unique_external_id"} {
+			stub := newStubServer(t)
+			stub.reply("GET", "/api/v2/contacts", 200, `[]`)
+			stub.reply("POST", "/api/v2/contacts", 201, `{"id":7}`)
+
+			_, result, err := stub.client().UpsertContact(key, ContactCreatePayload{Phone: "+15550100", UniqueExternalID: "ext-7"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != UpsertCreated || !stub.received()[0].Query.Has(filter) {
+				t.Errorf("%v: got %v, query %v", key, result, stub.received()[0].Query)
+			}
+		}
+	})
+
+	t.Run("key missing", func(t *testing.T) {
+		for _, key := range []UpsertKey{UpsertByEmail, UpsertByPhone, UpsertByExternalID} {
+			stub := newStubServer(t)
+			_, _, err := stub.client().UpsertContact(key, ContactCreatePayload{Name: "Jane"})
+			if err == nil || err.Error() != ERR_UPSERT_KEY_MISSING {
+				t.Errorf("%v: got %v", key, err)
+			}
+			if len(stub.received()) != 0 {
+				t.Errorf("%v: sent %d requests", key, len(stub.received()))
+			}
+		}
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		stub := newStubServer(t)
+		if _, _, err := stub.client().UpsertContact(UpsertByDomain, payload); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("lookup fails", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/contacts", 500, `{"message":"boom"}`)
+
+		if _, _, err := stub.client().UpsertContact(UpsertByEmail, payload); err == nil {
+			t.Error("expected an error")
+		}
+		if len(stub.received()) != 1 {
+			t.Errorf("sent %d requests", len(stub.received()))
+		}
+	})
+}
+
+func TestUpsertCompany(t *testing.T) {
+	t.Run("created by name", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[]}`)
+		stub.reply("POST", "/api/v2/companies", 201, `{"id":7,"name":"Acme"}`)
+
+		company, result, err := stub.client().UpsertCompany(UpsertByName, CompanyCreatePayload{Name: "Acme"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertCreated || company.ID != 7 {
+			t.Errorf("got %v %d", result, company.ID)
+		}
+	})
+
+	t.Run("updated by second domain", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.on("GET", "/api/v2/search/companies",
+			stubResponse{Status: 200, Body: `{"total":0,"results":[]}`},
+			stubResponse{Status: 200, Body: `{"total":1,"results":[{"id":7,"name":"Acme","domains":["acme.io"]}]}`},
+		)
+		stub.reply("PUT", "/api/v2/companies/7", 200, `{"id":7,"name":"Acme","domains":["acme.com","acme.io"]}`)
+
+		payload := CompanyCreatePayload{Name: "Acme", Domains: []string{"acme.com", "acme.io"}}
+		company, result, err := stub.client().UpsertCompany(UpsertByDomain, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertUpdated || len(company.Domains) != 2 {
+			t.Errorf("got %v %v", result, company.Domains)
+		}
+		if got := stub.received()[1].Query.Get("query"); got != `"domain:'acme.io'"` {
+			t.Errorf("second lookup %q", got)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/search/companies", 200, `{"total":1,"results":[{"id":7,"name":"Acme","domains":["acme.io","acme.com"],"note":"kept"}]}`)
+
+		payload := CompanyCreatePayload{Name: "Acme", Domains: []string{"acme.com", "acme.io"}}
+		_, result, err := stub.client().UpsertCompany(UpsertByDomain, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != UpsertUnchanged || len(stub.received()) != 1 {
+			t.Errorf("got %v after %d requests", result, len(stub.received()))
+		}
+	})
+
+	t.Run("key missing", func(t *testing.T) {
+		stub := newStubServer(t)
+		for _, key := range []UpsertKey{UpsertByName, UpsertByDomain} {
+			if _, _, err := stub.client().UpsertCompany(key, CompanyCreatePayload{}); err == nil || err.Error() != ERR_UPSERT_KEY_MISSING {
+				t.Errorf("%v: got %v", key, err)
+			}
+		}
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		stub := newStubServer(t)
+		if _, _, err := stub.client().UpsertCompany(UpsertByEmail, CompanyCreatePayload{Name: "Acme"}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("create fails", func(t *testing.T) {
+		stub := newStubServer(t)
+		stub.reply("GET", "/api/v2/companies/autocomplete", 200, `{"companies":[]}`)
+		stub.reply("POST", "/api/v2/companies", 409, `{"description":"Validation failed"}`)
+
+		if _, _, err := stub.client().UpsertCompany(UpsertByName, CompanyCreatePayload{Name: "Acme"}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
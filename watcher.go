@@ -0,0 +1,369 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type ChangeKind string
+
+const (
+	ChangeCreated ChangeKind = "created"
+	ChangeUpdated ChangeKind = "updated"
+	ChangeDeleted ChangeKind = "deleted"
+)
+
+// Change is emitted by a Watcher for every ticket or contact that was
+// created, updated or deleted. Exactly one of Ticket and Contact is set.
+type Change struct {
+	Kind      ChangeKind
+	Ticket    *Ticket
+	Contact   *Contact
+	UpdatedAt time.Time
+}
+
+// Cursor is the position of one feed: records updated before Since have
+// been emitted, Seen holds the records emitted within the overlap window
+// with the update time they were emitted for.
+type Cursor struct {
+	Since time.Time            `json:"since"`
+	Seen  map[uint64]time.Time `json:"seen,omitempty"`
+}
+
+type Checkpoint struct {
+	Tickets         Cursor `json:"tickets"`
+	DeletedTickets  Cursor `json:"deleted_tickets"`
+	Contacts        Cursor `json:"contacts"`
+	DeletedContacts Cursor `json:"deleted_contacts"`
+}
+
+// CheckpointStore persists the watcher position between runs. Load returns
+// a zero Checkpoint when nothing was saved yet.
+type CheckpointStore interface {
+	Load() (Checkpoint, error)
+	Save(checkpoint Checkpoint) error
+}
+
+type fileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore keeps the checkpoint in a JSON file, replaced
+// atomically on every save.
+func NewFileCheckpointStore(path string) CheckpointStore {
+	return &fileCheckpointStore{path: path}
+}
+
+func (store *fileCheckpointStore) Load() (Checkpoint, error) {
+	var checkpoint Checkpoint
+	data, err := os.ReadFile(store.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+func (store *fileCheckpointStore) Save(checkpoint Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), store.path)
+}
+
+// Watcher polls tickets and contacts updated since its checkpoint and
+// emits the changes on a channel, for accounts that cannot use webhooks.
+//
+// Cursors advance on the update times reported by Freshdesk, so the local
+// clock does not matter; each poll re-reads an overlap window to catch
+// records committed late, and records already emitted for the same update
+// time are skipped. A record updated several times between two polls is
+// emitted once. The checkpoint is saved after the changes of a poll have
+// been received from the channel, so delivery is at least once. A poll
+// reaching the page limit of the ticket lists continues from the newest
+// ticket seen; the contact lists have no limit and are read to the end.
+type Watcher struct {
+	client   Client
+	store    CheckpointStore
+	interval time.Duration
+	overlap  time.Duration
+	pageSize int
+	start    time.Time
+
+	changes chan Change
+	runMu   sync.Mutex
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Freshdesk refuses ticket list pages beyond this one.
+const maxListPages = 300
+
+func NewWatcher(client Client, store CheckpointStore, interval time.Duration) *Watcher {
+	return &Watcher{
+		client:   client,
+		store:    store,
+		interval: interval,
+		overlap:  time.Minute,
+		pageSize: 100,
+		changes:  make(chan Change),
+	}
+}
+
+// SetOverlap sets how far back each poll re-reads, one minute by default.
+func (watcher *Watcher) SetOverlap(overlap time.Duration) {
+	watcher.overlap = overlap
+}
+
+// SetStartTime sets where feeds without a checkpoint begin. By default
+// they begin at the first poll and only later changes are emitted.
+func (watcher *Watcher) SetStartTime(start time.Time) {
+	watcher.start = start
+}
+
+// Changes returns the channel the changes are emitted on. It is closed
+// when the watcher stops.
+func (watcher *Watcher) Changes() <-chan Change {
+	return watcher.changes
+}
+
+// Start polls every interval until Stop is called. Poll failures are
+// logged and retried at the next interval. A watcher starts once: Start
+// fails when it was started before, even if stopped since, or when the
+// interval is not positive.
+func (watcher *Watcher) Start() error {
+	if watcher.interval <= 0 {
+		return fmt.Errorf("watcher interval %v is not positive", watcher.interval)
+	}
+
+	watcher.runMu.Lock()
+	defer watcher.runMu.Unlock()
+	if watcher.started {
+		return errors.New(ERR_ALREADY_STARTED)
+	}
+	watcher.started = true
+
+	watcher.stop = make(chan struct{})
+	watcher.done = make(chan struct{})
+	go func() {
+		defer close(watcher.done)
+		defer close(watcher.changes)
+		for {
+			if err := watcher.Poll(); err == errWatcherStopped {
+				return
+			} else if err != nil {
+				log.Println(err)
+			}
+			select {
+			case <-time.After(watcher.interval):
+			case <-watcher.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (watcher *Watcher) Stop() {
+	watcher.runMu.Lock()
+	stop, done := watcher.stop, watcher.done
+	watcher.runMu.Unlock()
+	if stop == nil {
+		return
+	}
+	watcher.once.Do(func() { close(stop) })
+	<-done
+}
+
+var errWatcherStopped = errors.New("watcher stopped")
+
+// Poll runs one polling round over all feeds. It blocks until the changes
+// found have been received from the Changes channel.
+func (watcher *Watcher) Poll() error {
+	checkpoint, err := watcher.store.Load()
+	if err != nil {
+		return err
+	}
+
+	// Only the ticket lists are ordered by update time.
+	feeds := []struct {
+		cursor  *Cursor
+		list    func(since *time.Time, page int) ([]Change, error, bool)
+		ordered bool
+	}{
+		{&checkpoint.Tickets, watcher.listTickets(watcher.client.ListTickets, false), true},
+		{&checkpoint.DeletedTickets, watcher.listTickets(watcher.client.ListDeletedTickets, true), true},
+		{&checkpoint.Contacts, watcher.listContacts(watcher.client.ListContacts, false), false},
+		{&checkpoint.DeletedContacts, watcher.listContacts(watcher.client.ListDeletedContacts, true), false},
+	}
+	for _, feed := range feeds {
+		if err := watcher.pollFeed(feed.cursor, feed.list, feed.ordered); err != nil {
+			return err
+		}
+		if err := watcher.store.Save(checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (watcher *Watcher) pollFeed(cursor *Cursor, list func(since *time.Time, page int) ([]Change, error, bool), ordered bool) error {
+	if cursor.Since.IsZero() {
+		cursor.Since = watcher.start
+		if cursor.Since.IsZero() {
+			cursor.Since = time.Now().UTC()
+		}
+	}
+	if cursor.Seen == nil {
+		cursor.Seen = map[uint64]time.Time{}
+	}
+
+	previous := cursor.Since
+	from := previous.Add(-watcher.overlap)
+	since := from
+	latest := previous
+
+	for page := 1; ; page++ {
+		changes, err, more := list(&since, page)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			id := change.id()
+			if seen, ok := cursor.Seen[id]; ok && !change.UpdatedAt.After(seen) {
+				continue
+			}
+			if change.Kind == ChangeUpdated && change.createdAt() != nil && !change.createdAt().Before(from) {
+				if _, ok := cursor.Seen[id]; !ok {
+					change.Kind = ChangeCreated
+				}
+			}
+
+			select {
+			case watcher.changes <- change:
+			case <-watcher.stopped():
+				return errWatcherStopped
+			}
+
+			cursor.Seen[id] = change.UpdatedAt
+			if change.UpdatedAt.After(latest) {
+				latest = change.UpdatedAt
+			}
+		}
+
+		if !more {
+			break
+		}
+		// Past the page limit an ordered feed restarts from the newest
+		// change seen, which only helps if it moved forward. Other feeds
+		// are read to their last page.
+		if ordered && page >= maxListPages {
+			restart := latest.Add(-watcher.overlap)
+			if !restart.After(since) {
+				break
+			}
+			since, page = restart, 0
+		}
+	}
+
+	cursor.Since = latest
+	for id, updated := range cursor.Seen {
+		if updated.Before(latest.Add(-watcher.overlap)) {
+			delete(cursor.Seen, id)
+		}
+	}
+	return nil
+}
+
+func (watcher *Watcher) stopped() <-chan struct{} {
+	watcher.runMu.Lock()
+	defer watcher.runMu.Unlock()
+	return watcher.stop
+}
+
+func (watcher *Watcher) listTickets(list func(*time.Time, int, int) ([]Ticket, error, bool), deleted bool) func(*time.Time, int) ([]Change, error, bool) {
+	return func(since *time.Time, page int) ([]Change, error, bool) {
+		tickets, err, more := list(since, watcher.pageSize, page)
+		if err != nil {
+			return nil, err, false
+		}
+		changes := make([]Change, 0, len(tickets))
+		for i := range tickets {
+			change := Change{Kind: ChangeUpdated, Ticket: &tickets[i]}
+			if deleted {
+				change.Kind = ChangeDeleted
+			}
+			if tickets[i].UpdatedAt != nil {
+				change.UpdatedAt = *tickets[i].UpdatedAt
+			}
+			changes = append(changes, change)
+		}
+		return changes, nil, more
+	}
+}
+
+func (watcher *Watcher) listContacts(list func(*time.Time, int, int) ([]Contact, error, bool), deleted bool) func(*time.Time, int) ([]Change, error, bool) {
+	return func(since *time.Time, page int) ([]Change, error, bool) {
+		contacts, err, more := list(since, watcher.pageSize, page)
+		if err != nil {
+			return nil, err, false
+		}
+		changes := make([]Change, 0, len(contacts))
+		for i := range contacts {
+			change := Change{Kind: ChangeUpdated, Contact: &contacts[i]}
+			if deleted {
+				change.Kind = ChangeDeleted
+			}
+			if contacts[i].UpdatedAt != nil {
+				change.UpdatedAt = *contacts[i].UpdatedAt
+			}
+			changes = append(changes, change)
+		}
+		return changes, nil, more
+	}
+}
+
+func (change *Change) id() uint64 {
+	if change.Ticket != nil {
+		return change.Ticket.ID
+	}
+	return change.Contact.ID
+}
+
+func (change *Change) createdAt() *time.Time {
+	if change.Ticket != nil {
+		return change.Ticket.CreatedAt
+	}
+	return change.Contact.CreatedAt
+}
@@ -0,0 +1,260 @@
+package freshdesk
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchedClient serves the feeds of a Watcher from memory, the other
+// methods of Client are left unimplemented.
+type watchedClient struct {
+	Client
+
+	mu              sync.Mutex
+	tickets         []Ticket
+	deletedTickets  []Ticket
+	contacts        []Contact
+	deletedContacts []Contact
+	err             error
+}
+
+func (client *watchedClient) ListTickets(since *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return updatedTickets(client.tickets, since), client.err, false
+}
+
+func (client *watchedClient) ListDeletedTickets(since *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return updatedTickets(client.deletedTickets, since), client.err, false
+}
+
+func (client *watchedClient) ListContacts(since *time.Time, pageSize, page int) ([]Contact, error, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return updatedContacts(client.contacts, since), client.err, false
+}
+
+func (client *watchedClient) ListDeletedContacts(since *time.Time, pageSize, page int) ([]Contact, error, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return updatedContacts(client.deletedContacts, since), client.err, false
+}
+
+func updatedTickets(tickets []Ticket, since *time.Time) []Ticket {
+	var result []Ticket
+	for _, ticket := range tickets {
+		if !ticket.UpdatedAt.Before(*since) {
+			result = append(result, ticket)
+		}
+	}
+	return result
+}
+
+func updatedContacts(contacts []Contact, since *time.Time) []Contact {
+	var result []Contact
+	for _, contact := range contacts {
+		if !contact.UpdatedAt.Before(*since) {
+			result = append(result, contact)
+		}
+	}
+	return result
+}
+
+func minutesAfter(start time.Time, minutes int) *time.Time {
+	t := start.Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+// poll runs one polling round and returns the changes emitted.
+func poll(t *testing.T, watcher *Watcher) []Change {
+	t.Helper()
+	done := make(chan error)
+	go func() { done <- watcher.Poll() }()
+
+	var changes []Change
+	for {
+		select {
+		case change := <-watcher.Changes():
+			changes = append(changes, change)
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			return changes
+		}
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	start := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	client := &watchedClient{
+		tickets: []Ticket{
+			{ID: 1, CreatedAt: minutesAfter(start, -60), UpdatedAt: minutesAfter(start, -30)}, // Before the start
+			{ID: 2, CreatedAt: minutesAfter(start, 5), UpdatedAt: minutesAfter(start, 5)},
+			{ID: 3, CreatedAt: minutesAfter(start, -60), UpdatedAt: minutesAfter(start, 10)},
+		},
+		deletedTickets: []Ticket{{ID: 4, UpdatedAt: minutesAfter(start, 7)}},
+		contacts:       []Contact{{ID: 5, CreatedAt: minutesAfter(start, -60), UpdatedAt: minutesAfter(start, 8)}},
+	}
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	watcher := NewWatcher(client, store, time.Minute)
+	watcher.SetStartTime(start)
+
+	changes := poll(t, watcher)
+	var got []string
+	for _, change := range changes {
+		got = append(got, string(change.Kind))
+	}
+	if len(changes) != 4 || changes[0].Ticket.ID != 2 || changes[1].Ticket.ID != 3 || changes[2].Ticket.ID != 4 || changes[3].Contact.ID != 5 {
+		t.Fatalf("got %d changes %v", len(changes), got)
+	}
+	if got[0] != "created" || got[1] != "updated" || got[2] != "deleted" || got[3] != "updated" {
+		t.Errorf("kinds = %v", got)
+	}
+
+	checkpoint, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkpoint.Tickets.Since.Equal(*minutesAfter(start, 10)) || !checkpoint.Contacts.Since.Equal(*minutesAfter(start, 8)) {
+		t.Errorf("checkpoint = %+v", checkpoint)
+	}
+
+	// Within the overlap window, records emitted already are skipped and
+	// only the later update is emitted.
+	if changes := poll(t, watcher); len(changes) != 0 {
+		t.Errorf("polled again %d changes", len(changes))
+	}
+	client.mu.Lock()
+	client.tickets[2].UpdatedAt = minutesAfter(start, 11)
+	client.mu.Unlock()
+	changes = poll(t, watcher)
+	if len(changes) != 1 || changes[0].Ticket.ID != 3 || changes[0].Kind != ChangeUpdated {
+		t.Errorf("got %+v", changes)
+	}
+}
+
+func TestWatcherResumesFromCheckpoint(t *testing.T) {
+	start := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	client := &watchedClient{tickets: []Ticket{{ID: 1, UpdatedAt: minutesAfter(start, 5)}}}
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	first := NewWatcher(client, store, time.Minute)
+	first.SetStartTime(start)
+	if changes := poll(t, first); len(changes) != 1 {
+		t.Fatalf("got %d changes", len(changes))
+	}
+
+	client.tickets = append(client.tickets, Ticket{ID: 2, UpdatedAt: minutesAfter(start, 20)})
+	second := NewWatcher(client, store, time.Minute)
+	changes := poll(t, second)
+	if len(changes) != 1 || changes[0].Ticket.ID != 2 {
+		t.Errorf("got %+v", changes)
+	}
+}
+
+func TestWatcherPollError(t *testing.T) {
+	client := &watchedClient{err: errors.New("rate limited")}
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err := NewWatcher(client, store, time.Minute).Poll(); err == nil || err.Error() != "rate limited" {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestWatcherStart(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err := NewWatcher(&watchedClient{}, store, 0).Start(); err == nil {
+		t.Error("started with a zero interval")
+	}
+
+	start := time.Now().UTC()
+	client := &watchedClient{tickets: []Ticket{{ID: 1, CreatedAt: &start, UpdatedAt: &start}}}
+	watcher := NewWatcher(client, store, time.Millisecond)
+	watcher.SetStartTime(start.Add(-time.Minute))
+	if err := watcher.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Start(); err == nil || err.Error() != ERR_ALREADY_STARTED {
+		t.Errorf("second Start = %v", err)
+	}
+
+	if change := <-watcher.Changes(); change.Ticket.ID != 1 || change.Kind != ChangeCreated {
+		t.Errorf("got %+v", change)
+	}
+	watcher.Stop()
+	watcher.Stop()
+	if _, open := <-watcher.Changes(); open {
+		t.Error("changes channel still open")
+	}
+	if err := watcher.Start(); err == nil {
+		t.Error("restarted a stopped watcher")
+	}
+}
+
+// pagedClient serves the ticket and contact feeds one record per page.
+// Tickets are ordered by update time, contacts are not.
+type pagedClient struct {
+	watchedClient
+
+	ticketPages  []int
+	contactPages int
+}
+
+func (client *pagedClient) ListTickets(since *time.Time, pageSize, page int) ([]Ticket, error, bool) {
+	client.ticketPages = append(client.ticketPages, page)
+	tickets := updatedTickets(client.tickets, since)
+	if page > len(tickets) {
+		return nil, nil, false
+	}
+	return tickets[page-1 : page], nil, page < len(tickets)
+}
+
+func (client *pagedClient) ListContacts(since *time.Time, pageSize, page int) ([]Contact, error, bool) {
+	client.contactPages = page
+	contacts := updatedContacts(client.contacts, since)
+	if page > len(contacts) {
+		return nil, nil, false
+	}
+	return contacts[page-1 : page], nil, page < len(contacts)
+}
+
+func TestWatcherPageLimit(t *testing.T) {
+	start := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	const records = maxListPages + 5
+	client := &pagedClient{}
+	for i := 0; i < records; i++ {
+		client.tickets = append(client.tickets, Ticket{ID: uint64(i + 1), UpdatedAt: minutesAfter(start, i)})
+		// The newest contacts come first.
+		client.contacts = append(client.contacts, Contact{ID: uint64(i + 1), UpdatedAt: minutesAfter(start, records-i)})
+	}
+	watcher := NewWatcher(client, NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json")), time.Minute)
+	watcher.SetStartTime(start)
+
+	tickets, contacts := map[uint64]int{}, map[uint64]int{}
+	for _, change := range poll(t, watcher) {
+		if change.Ticket != nil {
+			tickets[change.Ticket.ID]++
+		} else {
+			contacts[change.Contact.ID]++
+		}
+	}
+	for id := uint64(1); id <= records; id++ {
+		if tickets[id] != 1 || contacts[id] != 1 {
+			t.Errorf("record %d: emitted %d tickets and %d contacts", id, tickets[id], contacts[id])
+		}
+	}
+
+	for _, page := range client.ticketPages {
+		if page > maxListPages {
+			t.Fatalf("listed ticket page %d", page)
+		}
+	}
+	if client.contactPages != records {
+		t.Errorf("contacts read up to page %d", client.contactPages)
+	}
+}
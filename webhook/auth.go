@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Authenticator checks that a webhook call comes from the helpdesk.
+type Authenticator interface {
+	Authenticate(r *http.Request, body []byte) error
+}
+
+type AuthenticatorFunc func(r *http.Request, body []byte) error
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request, body []byte) error {
+	return f(r, body)
+}
+
+var errUnauthorized = errors.New("webhook: unauthorized")
+
+// SharedSecret accepts calls carrying secret in the given header, set up as
+// a custom header of the webhook action.
+func SharedSecret(header string, secret string) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request, body []byte) error {
+		if !equal(r.Header.Get(header), secret) {
+			return errUnauthorized
+		}
+		return nil
+	})
+}
+
+// BasicAuth accepts calls using the credentials configured under the
+// webhook action's "Requires authentication" option.
+func BasicAuth(user string, password string) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request, body []byte) error {
+		u, p, ok := r.BasicAuth()
+		// Evaluate both comparisons to keep the timing independent of
+		// which one fails.
+		userOk, passwordOk := equal(u, user), equal(p, password)
+		if !ok || !userOk || !passwordOk {
+			return errUnauthorized
+		}
+		return nil
+	})
+}
+
+// HMACSHA256 accepts calls whose header holds the HMAC-SHA256 of the body,
+// hex or base64 encoded, optionally prefixed with "sha256=". Freshdesk does
+// not sign requests itself; this is meant for relays that do.
+func HMACSHA256(header string, secret string) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request, body []byte) error {
+		signature := strings.TrimPrefix(strings.TrimSpace(r.Header.Get(header)), "sha256=")
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := mac.Sum(nil)
+
+		if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+		return errUnauthorized
+	})
+}
+
+// AnyOf accepts calls accepted by at least one of the authenticators.
+func AnyOf(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request, body []byte) error {
+		for _, authenticator := range authenticators {
+			if authenticator.Authenticate(r, body) == nil {
+				return nil
+			}
+		}
+		return errUnauthorized
+	})
+}
+
+func equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
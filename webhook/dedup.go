@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// DedupState is what a Deduplicator knows of an event id.
+type DedupState int
+
+const (
+	DedupNew      DedupState = iota // Unknown until now, claimed by the caller
+	DedupInFlight                   // Claimed by a delivery still running
+	DedupDone                       // Handled
+)
+
+// Deduplicator remembers the events being handled and handled already, so
+// that retries of a delivered call are acknowledged without being
+// dispatched again. Services running several replicas can back it with a
+// shared store.
+type Deduplicator interface {
+	// Claim returns the state of ID and claims it when it is new, in one
+	// atomic step so that concurrent retries of a call are dispatched
+	// once. Shared stores should let claims expire, so that a replica
+	// dying while handling an event does not block its retries forever.
+	Claim(ID string) DedupState
+	// Done records that the claimed ID was handled.
+	Done(ID string)
+	// Forget drops the claim on ID after its handling failed, so that the
+	// retry of the call is dispatched again.
+	Forget(ID string)
+}
+
+type memoryDeduplicator struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	seen   map[string]dedupEntry
+	pruned time.Time
+}
+
+type dedupEntry struct {
+	at   time.Time // When handled
+	done bool
+}
+
+// NewMemoryDeduplicator remembers handled event ids in memory for ttl.
+// Claims are held until Done or Forget.
+func NewMemoryDeduplicator(ttl time.Duration) Deduplicator {
+	return &memoryDeduplicator{
+		ttl:  ttl,
+		seen: map[string]dedupEntry{},
+	}
+}
+
+func (d *memoryDeduplicator) Claim(ID string) DedupState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := d.seen[ID]; ok {
+		if !entry.done {
+			return DedupInFlight
+		}
+		if now.Sub(entry.at) < d.ttl {
+			return DedupDone
+		}
+	}
+	d.seen[ID] = dedupEntry{}
+	if now.Sub(d.pruned) >= d.ttl {
+		for id, entry := range d.seen {
+			if entry.done && now.Sub(entry.at) >= d.ttl {
+				delete(d.seen, id)
+			}
+		}
+		d.pruned = now
+	}
+	return DedupNew
+}
+
+func (d *memoryDeduplicator) Done(ID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen[ID] = dedupEntry{at: time.Now(), done: true}
+}
+
+func (d *memoryDeduplicator) Forget(ID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, ID)
+}
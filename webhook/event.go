@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+type EventKind string
+
+const (
+	TicketCreated       EventKind = "ticket_created"
+	TicketUpdated       EventKind = "ticket_updated"
+	TicketDeleted       EventKind = "ticket_deleted"
+	ConversationCreated EventKind = "conversation_created"
+	ContactCreated      EventKind = "contact_created"
+	ContactUpdated      EventKind = "contact_updated"
+	Unknown             EventKind = "unknown"
+)
+
+// Event is a decoded webhook call. Which of Ticket, Contact and Message are
+// set depends on the placeholders the automation rule sends.
+type Event struct {
+	ID         string // The event_id of the body, used to detect retries
+	Kind       EventKind
+	Ticket     *freshdesk.Ticket
+	Contact    *freshdesk.Contact
+	Message    *freshdesk.TicketMessage
+	ReceivedAt time.Time
+
+	// Fields holds the flat placeholders of the "simple" webhook format,
+	// Raw the request body as received.
+	Fields map[string]interface{}
+	Raw    json.RawMessage
+}
+
+// Two body formats are understood.
+//
+// The "advanced" format is a JSON template written in the automation rule,
+// with the event kind and the records as objects:
+//
+//	{"event": "ticket_updated", "event_id": "{{ticket.id}}-{{ticket.updated_at}}",
+//	 "ticket": {"id": {{ticket.id}}, "status": "{{ticket.status}}"},
+//	 "contact": {"email": "{{ticket.contact.email}}"}}
+//
+// The "simple" format is what Freshdesk sends when placeholders are
+// picked from the list: {"freshdesk_webhook": {"ticket_id": 1, ...}}.
+// Keys starting with ticket_contact_ or ticket_requester_ fill Contact,
+// other ticket_ keys fill Ticket.
+//
+// Placeholders are substituted as text, so numbers and booleans may
+// arrive quoted and status, priority and source as their names; both are
+// accepted. When the body does not name the event kind, the "event" query
+// parameter of the webhook URL is used.
+//
+// Only calls carrying an event_id are deduplicated: two identical bodies
+// without one, such as the same status change made twice, are distinct
+// events.
+type envelope struct {
+	Event     EventKind              `json:"event"`
+	EventID   string                 `json:"event_id"`
+	Ticket    map[string]interface{} `json:"ticket"`
+	Contact   map[string]interface{} `json:"contact"`
+	Message   map[string]interface{} `json:"conversation"`
+	Simple    map[string]interface{} `json:"freshdesk_webhook"`
+	Reply     map[string]interface{} `json:"message"`
+	Requester map[string]interface{} `json:"requester"`
+}
+
+func decodeEvent(body []byte, kind EventKind) (*Event, error) {
+	var raw envelope
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		ID:         raw.EventID,
+		Kind:       raw.Event,
+		ReceivedAt: time.Now(),
+		Raw:        json.RawMessage(body),
+	}
+	if event.Kind == "" {
+		event.Kind = kind
+	}
+	if event.Kind == "" {
+		event.Kind = Unknown
+	}
+
+	ticket, contact, message := raw.Ticket, raw.Contact, raw.Message
+	if contact == nil {
+		contact = raw.Requester
+	}
+	if message == nil {
+		message = raw.Reply
+	}
+	if raw.Simple != nil {
+		event.Fields = raw.Simple
+		ticket, contact = splitSimple(raw.Simple)
+	}
+
+	if ticket != nil {
+		event.Ticket = &freshdesk.Ticket{}
+		if err := decodeLenient(ticket, event.Ticket); err != nil {
+			return nil, err
+		}
+	}
+	if contact != nil {
+		event.Contact = &freshdesk.Contact{}
+		if err := decodeLenient(contact, event.Contact); err != nil {
+			return nil, err
+		}
+	}
+	if message != nil {
+		event.Message = &freshdesk.TicketMessage{}
+		if err := decodeLenient(message, event.Message); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func splitSimple(fields map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	ticket := map[string]interface{}{}
+	var contact map[string]interface{}
+	for key, value := range fields {
+		switch {
+		case strings.HasPrefix(key, "ticket_contact_"), strings.HasPrefix(key, "ticket_requester_"):
+			if contact == nil {
+				contact = map[string]interface{}{}
+			}
+			name := strings.TrimPrefix(strings.TrimPrefix(key, "ticket_contact_"), "ticket_requester_")
+			contact[name] = value
+		case strings.HasPrefix(key, "ticket_"):
+			ticket[strings.TrimPrefix(key, "ticket_")] = value
+		}
+	}
+	if len(ticket) == 0 {
+		ticket = nil
+	}
+	return ticket, contact
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	statusType        = reflect.TypeOf(freshdesk.Status(0))
+	priorityType      = reflect.TypeOf(freshdesk.Priority(0))
+	sourceType        = reflect.TypeOf(freshdesk.Source(0))
+	messageSourceType = reflect.TypeOf(freshdesk.MessageSource(0))
+)
+
+// enumValue parses the name of a status, priority or source for a field of
+// that type, custom statuses included once registered with
+// freshdesk.RegisterStatus. The field type decides rather than the key:
+// tickets and messages both have a "source", numbered differently.
+func enumValue(target reflect.Type, text string) (int64, bool) {
+	switch target {
+	case statusType:
+		status, err := freshdesk.ParseStatus(text)
+		return int64(status), err == nil
+	case priorityType:
+		priority, err := freshdesk.ParsePriority(text)
+		return int64(priority), err == nil
+	case sourceType:
+		source, err := freshdesk.ParseSource(text)
+		return int64(source), err == nil
+	case messageSourceType:
+		source, err := freshdesk.ParseMessageSource(text)
+		return int64(source), err == nil
+	}
+	return 0, false
+}
+
+// decodeLenient decodes fields into the struct out points to, converting
+// textual placeholder values to the types of the target fields. Values
+// that cannot be converted are dropped rather than failing the event.
+func decodeLenient(fields map[string]interface{}, out interface{}) error {
+	t := reflect.TypeOf(out).Elem()
+	clean := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		value, ok := fields[name]
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		if converted, ok := convertValue(value, field.Type); ok {
+			clean[name] = converted
+		}
+	}
+
+	data, err := json.Marshal(clean)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func convertValue(value interface{}, target reflect.Type) (interface{}, bool) {
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	text, isText := value.(string)
+
+	switch {
+	case target == timeType:
+		if !isText {
+			return nil, false
+		}
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05 -0700", "Mon, 2 Jan, 2006 at 3:04 PM"} {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+				return parsed, true
+			}
+		}
+		return nil, false
+
+	case target.Kind() >= reflect.Int && target.Kind() <= reflect.Uint64:
+		if !isText {
+			_, isNumber := value.(float64)
+			return value, isNumber
+		}
+		text = strings.TrimSpace(text)
+		if number, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return number, true
+		}
+		if number, ok := enumValue(target, text); ok {
+			return number, true
+		}
+		return nil, false
+
+	case target.Kind() == reflect.Bool:
+		if !isText {
+			_, isBool := value.(bool)
+			return value, isBool
+		}
+		parsed, err := strconv.ParseBool(strings.TrimSpace(text))
+		return parsed, err == nil
+
+	case target.Kind() == reflect.Slice && target.Elem().Kind() == reflect.String:
+		if !isText {
+			_, isList := value.([]interface{})
+			return value, isList
+		}
+		var list []string
+		for _, part := range strings.Split(text, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+		return list, true
+
+	case target.Kind() == reflect.String:
+		if isText {
+			return text, true
+		}
+		if number, ok := value.(float64); ok {
+			return strconv.FormatFloat(number, 'f', -1, 64), true
+		}
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,139 @@
+// Package webhook receives the calls Freshdesk automation rules make with
+// the "Trigger webhook" action and dispatches them as typed events.
+//
+//	handler := webhook.NewHandler(webhook.SharedSecret("X-Webhook-Secret", secret))
+//	handler.On(webhook.TicketCreated, func(event *webhook.Event) error {
+//		log.Println("new ticket", event.Ticket.ID)
+//		return nil
+//	})
+//	http.Handle("/freshdesk", handler)
+package webhook
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HandlerFunc processes an event. Returning an error answers the call with
+// a server error, which makes Freshdesk retry it.
+type HandlerFunc func(event *Event) error
+
+// MaxBodySize bounds the accepted request bodies.
+const MaxBodySize = 1 << 20
+
+type Handler struct {
+	auth  Authenticator
+	dedup Deduplicator
+
+	mu       sync.RWMutex
+	handlers map[EventKind][]HandlerFunc
+	any      []HandlerFunc
+}
+
+// NewHandler returns a handler authenticating calls with auth, which may
+// be nil to accept every call. Retries of calls carrying an event_id are
+// detected for 24 hours; a retry arriving while the first delivery is
+// still being handled is answered 503 Service Unavailable, so that it is
+// retried again if that delivery fails.
+func NewHandler(auth Authenticator) *Handler {
+	return &Handler{
+		auth:     auth,
+		dedup:    NewMemoryDeduplicator(24 * time.Hour),
+		handlers: map[EventKind][]HandlerFunc{},
+	}
+}
+
+// SetDeduplicator replaces the in-memory deduplicator, nil disables
+// deduplication.
+func (h *Handler) SetDeduplicator(dedup Deduplicator) {
+	h.dedup = dedup
+}
+
+// On registers fn for events of the given kind.
+func (h *Handler) On(kind EventKind, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[kind] = append(h.handlers[kind], fn)
+}
+
+// OnAny registers fn for every event, after the kind specific handlers.
+func (h *Handler) OnAny(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.any = append(h.any, fn)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > MaxBodySize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if h.auth != nil {
+		if err := h.auth.Authenticate(r, body); err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	event, err := decodeEvent(body, EventKind(r.URL.Query().Get("event")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dedup := h.dedup != nil && event.ID != ""
+	if dedup {
+		switch h.dedup.Claim(event.ID) {
+		case DedupDone:
+			w.WriteHeader(http.StatusOK)
+			return
+		case DedupInFlight:
+			// The first delivery may still fail, the retry must not be
+			// acknowledged before it succeeds.
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	if err := h.dispatch(event); err != nil {
+		log.Println(err)
+		if dedup {
+			h.dedup.Forget(event.ID)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if dedup {
+		h.dedup.Done(event.ID)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) dispatch(event *Event) error {
+	h.mu.RLock()
+	handlers := append(append([]HandlerFunc(nil), h.handlers[event.Kind]...), h.any...)
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
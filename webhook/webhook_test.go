@@ -0,0 +1,379 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	freshdesk "github.com/Peter2121/freshdesk-go"
+)
+
+func TestMain(m *testing.M) {
+	// Failing handlers are logged, which only clutters the output.
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func call(h http.Handler, target string, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func sign(body string, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func TestAuthenticators(t *testing.T) {
+	const body = `{"event":"ticket_created"}`
+	basic := func(user, password string) http.Header {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.SetBasicAuth(user, password)
+		return r.Header
+	}
+
+	cases := []struct {
+		name   string
+		auth   Authenticator
+		header http.Header
+		want   int
+	}{
+		{"secret", SharedSecret("X-Secret", "s3cret"), http.Header{"X-Secret": {"s3cret"}}, http.StatusOK},
+		{"wrong secret", SharedSecret("X-Secret", "s3cret"), http.Header{"X-Secret": {"s3cre"}}, http.StatusUnauthorized},
+		{"missing secret", SharedSecret("X-Secret", "s3cret"), nil, http.StatusUnauthorized},
+		{"basic", BasicAuth("fd", "pass"), basic("fd", "pass"), http.StatusOK},
+		{"wrong password", BasicAuth("fd", "pass"), basic("fd", "word"), http.StatusUnauthorized},
+		{"wrong user", BasicAuth("fd", "pass"), basic("df", "pass"), http.StatusUnauthorized},
+		{"missing credentials", BasicAuth("fd", "pass"), nil, http.StatusUnauthorized},
+		{"hex hmac", HMACSHA256("X-Signature", "key"), http.Header{"X-Signature": {hex.EncodeToString(sign(body, "key"))}}, http.StatusOK},
+		{"prefixed hmac", HMACSHA256("X-Signature", "key"), http.Header{"X-Signature": {"sha256=" + hex.EncodeToString(sign(body, "key"))}}, http.StatusOK},
+		{"base64 hmac", HMACSHA256("X-Signature", "key"), http.Header{"X-Signature": {base64.StdEncoding.EncodeToString(sign(body, "key"))}}, http.StatusOK},
+		{"hmac of another key", HMACSHA256("X-Signature", "key"), http.Header{"X-Signature": {hex.EncodeToString(sign(body, "other"))}}, http.StatusUnauthorized},
+		{"any of", AnyOf(SharedSecret("X-Secret", "s3cret"), BasicAuth("fd", "pass")), basic("fd", "pass"), http.StatusOK},
+		{"none of", AnyOf(SharedSecret("X-Secret", "s3cret"), BasicAuth("fd", "pass")), basic("fd", "word"), http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		handler := NewHandler(tc.auth)
+		dispatched := false
+		handler.OnAny(func(event *Event) error {
+			dispatched = true
+			return nil
+		})
+		w := call(handler, "/", body, tc.header)
+		if w.Code != tc.want || dispatched != (tc.want == http.StatusOK) {
+			t.Errorf("%s: got %d, dispatched %v", tc.name, w.Code, dispatched)
+		}
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	handler := NewHandler(nil)
+	handler.OnAny(func(event *Event) error {
+		t.Errorf("dispatched %+v", event)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "POST, PUT" {
+		t.Errorf("GET answered %d, Allow %q", w.Code, w.Header().Get("Allow"))
+	}
+	if w := call(handler, "/", `{"ticket":`, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid JSON answered %d", w.Code)
+	}
+	large := `{"ticket":{"description":"` + strings.Repeat("x", MaxBodySize) + `"}}`
+	if w := call(handler, "/", large, nil); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body answered %d", w.Code)
+	}
+}
+
+// receive serves one call and returns the events dispatched.
+func receive(t *testing.T, target string, body string) []*Event {
+	t.Helper()
+	handler := NewHandler(nil)
+	var events []*Event
+	handler.OnAny(func(event *Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if w := call(handler, target, body, nil); w.Code != http.StatusOK {
+		t.Fatalf("answered %d: %s", w.Code, w.Body)
+	}
+	return events
+}
+
+func TestAdvancedFormat(t *testing.T) {
+	events := receive(t, "/", `{
+		"event": "conversation_created",
+		"event_id": "42-2024-01-08T10:00:00Z",
+		"ticket": {"id": "42", "status": "Pending", "priority": 3, "source": "Email", "tags": "vip, billing", "spam": "false", "due_by": "2024-01-10T10:00:00Z"},
+		"requester": {"email": "jane@example.com", "name": "Jane"},
+		"message": {"body_text": "Thanks", "private": "true", "source": 2}
+	}`)
+	if len(events) != 1 {
+		t.Fatalf("dispatched %d events", len(events))
+	}
+	event := events[0]
+	if event.Kind != ConversationCreated || event.ID != "42-2024-01-08T10:00:00Z" {
+		t.Errorf("kind %q, id %q", event.Kind, event.ID)
+	}
+	ticket := event.Ticket
+	if ticket.ID != 42 || ticket.Status != freshdesk.StatusPending || ticket.Priority != freshdesk.PriorityHigh ||
+		ticket.Source != freshdesk.SourceEmail || len(ticket.Tags) != 2 || ticket.Tags[1] != "billing" || ticket.Spam ||
+		ticket.DueBy == nil || !ticket.DueBy.Equal(time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("ticket = %+v", ticket)
+	}
+	if event.Contact == nil || event.Contact.Email != "jane@example.com" {
+		t.Errorf("contact = %+v", event.Contact)
+	}
+	if event.Message == nil || event.Message.BodyText != "Thanks" || !event.Message.IsPrivate || event.Message.Source != freshdesk.MessageSourceNote {
+		t.Errorf("message = %+v", event.Message)
+	}
+}
+
+func TestSourceNamesByFieldType(t *testing.T) {
+	cases := []struct {
+		ticket  string
+		message string
+		want    freshdesk.Source
+		wantMsg freshdesk.MessageSource
+	}{
+		{"Phone", "Phone", freshdesk.SourcePhone, freshdesk.MessageSourcePhone},
+		{"email", "note", freshdesk.SourceEmail, freshdesk.MessageSourceNote},
+		{"Portal", "Forwarded Email", freshdesk.SourcePortal, freshdesk.MessageSourceForwardedEmail},
+		{"3", "9", freshdesk.SourcePhone, freshdesk.MessageSourcePhone},
+	}
+	for _, tc := range cases {
+		events := receive(t, "/", `{"event": "conversation_created",
+			"ticket": {"id": 1, "source": "`+tc.ticket+`"},
+			"conversation": {"id": 2, "source": "`+tc.message+`"}}`)
+		if len(events) != 1 {
+			t.Fatalf("dispatched %d events", len(events))
+		}
+		if got := events[0].Ticket.Source; got != tc.want {
+			t.Errorf("ticket source %q = %d, want %d", tc.ticket, got, tc.want)
+		}
+		if got := events[0].Message.Source; got != tc.wantMsg {
+			t.Errorf("message source %q = %d, want %d", tc.message, got, tc.wantMsg)
+		}
+	}
+}
+
+func TestSimpleFormat(t *testing.T) {
+	events := receive(t, "/hooks?event=ticket_updated", `{"freshdesk_webhook": {
+		"ticket_id": 7,
+		"ticket_subject": "Printer on fire",
+		"ticket_status": "Resolved",
+		"ticket_priority": "Urgent",
+		"ticket_contact_email": "jane@example.com",
+		"ticket_requester_name": "Jane",
+		"ticket_cf_order": "A-12"
+	}}`)
+	if len(events) != 1 {
+		t.Fatalf("dispatched %d events", len(events))
+	}
+	event := events[0]
+	if event.Kind != TicketUpdated || event.ID != "" {
+		t.Errorf("kind %q, id %q", event.Kind, event.ID)
+	}
+	if event.Ticket.ID != 7 || event.Ticket.Subject != "Printer on fire" || event.Ticket.Status != freshdesk.StatusResolved || event.Ticket.Priority != freshdesk.PriorityUrgent {
+		t.Errorf("ticket = %+v", event.Ticket)
+	}
+	if event.Contact == nil || event.Contact.Email != "jane@example.com" || event.Contact.Name != "Jane" {
+		t.Errorf("contact = %+v", event.Contact)
+	}
+	if event.Fields["ticket_cf_order"] != "A-12" {
+		t.Errorf("fields = %v", event.Fields)
+	}
+}
+
+func TestLenientDecoding(t *testing.T) {
+	events := receive(t, "/", `{"ticket": {"id": "{{ticket.id}}", "priority": "whenever", "subject": 42, "spam": "maybe", "due_by": "soon", "status": "2"}}`)
+	ticket := events[0].Ticket
+	if events[0].Kind != Unknown {
+		t.Errorf("kind = %q", events[0].Kind)
+	}
+	if ticket.ID != 0 || ticket.Priority != 0 || ticket.Subject != "42" || ticket.Spam || ticket.DueBy != nil || ticket.Status != freshdesk.StatusOpen {
+		t.Errorf("ticket = %+v", ticket)
+	}
+}
+
+func TestDispatchByKind(t *testing.T) {
+	handler := NewHandler(nil)
+	var got []string
+	handler.On(TicketCreated, func(event *Event) error {
+		got = append(got, "created")
+		return nil
+	})
+	handler.OnAny(func(event *Event) error {
+		got = append(got, "any "+string(event.Kind))
+		return nil
+	})
+
+	call(handler, "/", `{"event":"ticket_created"}`, nil)
+	call(handler, "/", `{"event":"ticket_deleted"}`, nil)
+	if strings.Join(got, ", ") != "created, any ticket_created, any ticket_deleted" {
+		t.Errorf("dispatched %v", got)
+	}
+}
+
+func TestDeduplication(t *testing.T) {
+	handler := NewHandler(nil)
+	count := 0
+	fail := true
+	handler.OnAny(func(event *Event) error {
+		count++
+		if event.ID == "retried" && fail {
+			fail = false
+			return errors.New("database down")
+		}
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if w := call(handler, "/", `{"event_id":"once"}`, nil); w.Code != http.StatusOK {
+			t.Errorf("answered %d", w.Code)
+		}
+	}
+	if count != 1 {
+		t.Errorf("event with an id dispatched %d times", count)
+	}
+
+	count = 0
+	for i := 0; i < 2; i++ {
+		call(handler, "/", `{"freshdesk_webhook":{"ticket_id":7,"ticket_status":"Open"}}`, nil)
+	}
+	if count != 2 {
+		t.Errorf("event without id dispatched %d times", count)
+	}
+
+	count = 0
+	if w := call(handler, "/", `{"event_id":"retried"}`, nil); w.Code != http.StatusInternalServerError {
+		t.Errorf("failed handler answered %d", w.Code)
+	}
+	if w := call(handler, "/", `{"event_id":"retried"}`, nil); w.Code != http.StatusOK || count != 2 {
+		t.Errorf("retry answered %d, dispatched %d times", w.Code, count)
+	}
+
+	handler.SetDeduplicator(nil)
+	count = 0
+	call(handler, "/", `{"event_id":"once"}`, nil)
+	if count != 1 {
+		t.Errorf("without deduplicator dispatched %d times", count)
+	}
+}
+
+func TestConcurrentRetries(t *testing.T) {
+	handler := NewHandler(nil)
+	var mu sync.Mutex
+	count := 0
+	handler.OnAny(func(event *Event) error {
+		mu.Lock()
+		count++
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- call(handler, "/", `{"event_id":"42"}`, nil).Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+	if count != 1 {
+		t.Errorf("dispatched %d times", count)
+	}
+	answered := map[int]int{}
+	for code := range codes {
+		answered[code]++
+	}
+	// Calls arriving after the first delivery finished are acknowledged,
+	// the others are asked to retry.
+	if answered[http.StatusOK] < 1 || answered[http.StatusOK]+answered[http.StatusServiceUnavailable] != 8 {
+		t.Errorf("answered %v", answered)
+	}
+	if w := call(handler, "/", `{"event_id":"42"}`, nil); w.Code != http.StatusOK || count != 1 {
+		t.Errorf("later retry answered %d, dispatched %d times", w.Code, count)
+	}
+}
+
+func TestRetryDuringFailingDelivery(t *testing.T) {
+	handler := NewHandler(nil)
+	started, release := make(chan struct{}), make(chan struct{})
+	count := 0
+	handler.OnAny(func(event *Event) error {
+		count++
+		if count == 1 {
+			close(started)
+			<-release
+			return errors.New("database down")
+		}
+		return nil
+	})
+
+	first := make(chan int)
+	go func() { first <- call(handler, "/", `{"event_id":"42"}`, nil).Code }()
+	<-started
+
+	w := call(handler, "/", `{"event_id":"42"}`, nil)
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" {
+		t.Errorf("retry during the first delivery answered %d", w.Code)
+	}
+	close(release)
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Errorf("failed delivery answered %d", code)
+	}
+
+	if w := call(handler, "/", `{"event_id":"42"}`, nil); w.Code != http.StatusOK || count != 2 {
+		t.Errorf("retry after the failure answered %d, dispatched %d times", w.Code, count)
+	}
+}
+
+func TestMemoryDeduplicatorExpires(t *testing.T) {
+	dedup := NewMemoryDeduplicator(20 * time.Millisecond)
+	if state := dedup.Claim("a"); state != DedupNew {
+		t.Errorf("new id: %v", state)
+	}
+	if state := dedup.Claim("a"); state != DedupInFlight {
+		t.Errorf("claimed id: %v", state)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if state := dedup.Claim("a"); state != DedupInFlight {
+		t.Errorf("claim expired: %v", state)
+	}
+	dedup.Done("a")
+	if state := dedup.Claim("a"); state != DedupDone {
+		t.Errorf("handled id: %v", state)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if state := dedup.Claim("a"); state != DedupNew {
+		t.Errorf("id remembered past its ttl: %v", state)
+	}
+	dedup.Forget("a")
+	if state := dedup.Claim("a"); state != DedupNew {
+		t.Errorf("forgotten id: %v", state)
+	}
+}